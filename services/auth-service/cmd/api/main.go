@@ -2,21 +2,31 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/jvieiradev/titanwatch/auth-service/internal/delivery/http/handler"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/delivery/http/middleware"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/delivery/http/router"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/service"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/breach"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/database"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/directory"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/federation"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/mail"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/passkey"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/usecase"
 	"github.com/jvieiradev/titanwatch/auth-service/pkg/config"
 )
@@ -47,28 +57,243 @@ func main() {
 	log.Println("✓ Connected to Redis")
 
 	// Inicializar serviços de infraestrutura
-	passwordService := crypto.NewPasswordService()
+	passwordHasher, err := newPasswordHasher(cfg.Password)
+	if err != nil {
+		log.Fatalf("Failed to initialize password hashing: %v", err)
+	}
+	passwordService := crypto.NewPasswordService(passwordHasher)
+	keyRing, err := loadKeyRing(cfg.JWT)
+	if err != nil {
+		log.Fatalf("Failed to load JWT signing keys: %v", err)
+	}
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	go keyRing.WatchReload(backgroundCtx, cfg.JWT.KeyRingReloadInterval)
+
 	jwtService := crypto.NewJWTService(
-		cfg.JWT.Secret,
+		keyRing,
 		cfg.JWT.AccessTokenExpiry,
 		cfg.JWT.RefreshTokenExpiry,
 	)
-	log.Println("✓ Initialized crypto services")
+	activeKey := keyRing.Active()
+	log.Printf("✓ Initialized crypto services (JWT %s, kid=%s)", activeKey.Method.Alg(), activeKey.ID)
 
 	// Inicializar repositórios
 	userRepo := database.NewPostgresUserRepository(db)
 	sessionRepo := database.NewPostgresSessionRepository(db)
+	auditRepo := database.NewPostgresAuditRepository(db)
+	invitationRepo := database.NewPostgresInvitationRepository(db)
+	passwordResetRepo := database.NewPostgresPasswordResetRepository(db)
+	passwordHistoryRepo := database.NewPostgresPasswordHistoryRepository(db)
+	recoveryCodeRepo := database.NewPostgresRecoveryCodeRepository(db)
+	webAuthnCredentialRepo := database.NewPostgresWebAuthnCredentialRepository(db)
+	oauthClientRepo := database.NewPostgresOAuthClientRepository(db)
+	oauthConsentRepo := database.NewPostgresOAuthConsentRepository(db)
+	userIdentityRepo := database.NewPostgresUserIdentityRepository(db)
 	log.Println("✓ Initialized repositories")
 
+	// Lista de revogação de access tokens
+	revocationList := cache.NewTokenRevocationList(redisClient, cfg.JWT.AccessTokenExpiry)
+
+	// Proteção contra força bruta no login
+	loginThrottle := cache.NewLoginThrottle(redisClient, cache.LoginThrottlePolicy{
+		MaxAccountFailures: int64(cfg.Auth.LoginMaxAttempts),
+		MaxIPFailures:      int64(cfg.Auth.LoginIPMaxAttempts),
+		BaseLockout:        cfg.Auth.LoginLockout,
+		MaxLockout:         cfg.Auth.LoginMaxLockout,
+		FailureWindow:      cfg.Auth.LoginFailureWindow,
+	})
+
+	// Segundo fator (TOTP)
+	secretBox, err := loadSecretBox(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize MFA encryption: %v", err)
+	}
+	mfaStore := cache.NewMFAStore(redisClient, cfg.MFA.ChallengeExpiry)
+
+	// Passkeys (WebAuthn)
+	passkeyService, err := passkey.NewService(
+		cfg.WebAuthn.RPID,
+		cfg.WebAuthn.RPDisplayName,
+		cfg.WebAuthn.RPOrigins,
+		cfg.WebAuthn.ChallengeExpiry,
+	)
+	if err != nil {
+		log.Fatalf("Failed to initialize WebAuthn: %v", err)
+	}
+	webAuthnStore := cache.NewWebAuthnStore(redisClient, cfg.WebAuthn.ChallengeExpiry)
+	log.Printf("✓ Initialized WebAuthn (rp_id=%s)", cfg.WebAuthn.RPID)
+
+	// Pedidos de autorização e authorization codes do OAuth
+	oauthStore := cache.NewOAuthStore(redisClient, cfg.OAuth.RequestExpiry, cfg.OAuth.CodeExpiry)
+
+	// Login federado por provedores de identidade externos
+	federationRegistry, err := newFederationRegistry(cfg.Federation)
+	if err != nil {
+		log.Fatalf("Failed to configure identity providers: %v", err)
+	}
+	federationStore := cache.NewFederationStore(redisClient, cfg.Federation.StateExpiry, cfg.Federation.CodeExpiry)
+	log.Printf("✓ Configured %d identity provider(s)", len(cfg.Federation.Providers))
+
+	// Entrega de emails
+	mailer := newMailer(cfg.Mail)
+	log.Printf("✓ Initialized mailer (%s)", cfg.Mail.Driver)
+
 	// Inicializar domain services
-	validationService := service.NewValidationService()
+	passwordPolicy, err := newPasswordPolicy(cfg.Password)
+	if err != nil {
+		log.Fatalf("Failed to initialize password policy: %v", err)
+	}
+	validationService := service.NewValidationService(passwordPolicy)
+
+	// Backend de senha do login: banco local ou diretório LDAP
+	authenticator, err := newAuthenticator(cfg, userRepo, userIdentityRepo, passwordService, validationService)
+	if err != nil {
+		log.Fatalf("Failed to configure authentication backend: %v", err)
+	}
+	log.Printf("✓ Configured authentication backend (%s)", cfg.Auth.Backend)
 
 	// Inicializar use cases
-	registerUseCase := usecase.NewRegisterUserUseCase(userRepo, passwordService, validationService)
-	loginUseCase := usecase.NewLoginUseCase(userRepo, sessionRepo, passwordService, jwtService, validationService)
-	logoutUseCase := usecase.NewLogoutUseCase(sessionRepo)
-	refreshTokenUseCase := usecase.NewRefreshTokenUseCase(userRepo, sessionRepo, jwtService)
-	verifyTokenUseCase := usecase.NewVerifyTokenUseCase(userRepo, jwtService)
+	registerUseCase := usecase.NewRegisterUserUseCase(
+		userRepo,
+		passwordService,
+		validationService,
+		mailer,
+		cfg.Auth.SelfRegistration,
+		cfg.Auth.ConcealRegistration,
+	)
+	loginUseCase := usecase.NewLoginUseCase(sessionRepo, webAuthnCredentialRepo, authenticator, jwtService, validationService, mfaStore, loginThrottle)
+	logoutUseCase := usecase.NewLogoutUseCase(sessionRepo, revocationList)
+	logoutAllUseCase := usecase.NewLogoutAllUseCase(sessionRepo, revocationList)
+	refreshTokenUseCase := usecase.NewRefreshTokenUseCase(userRepo, sessionRepo, auditRepo, jwtService, revocationList)
+	listSessionsUseCase := usecase.NewListSessionsUseCase(sessionRepo)
+	// Sessões rotacionadas precisam durar tanto quanto o refresh token que substituíram;
+	// apagá-las antes desativaria a detecção de reutilização
+	revokedSessionRetention := cfg.Auth.RevokedSessionRetention
+	if revokedSessionRetention < cfg.JWT.RefreshTokenExpiry {
+		log.Printf("AUTH_REVOKED_SESSION_RETENTION (%s) is shorter than JWT_REFRESH_TOKEN_EXPIRY; using %s",
+			revokedSessionRetention, cfg.JWT.RefreshTokenExpiry)
+		revokedSessionRetention = cfg.JWT.RefreshTokenExpiry
+	}
+	purgeSessionsUseCase := usecase.NewPurgeSessionsUseCase(sessionRepo, passwordResetRepo, revokedSessionRetention)
+	go purgeSessionsPeriodically(backgroundCtx, purgeSessionsUseCase, cfg.Auth.SessionCleanupInterval)
+	revokeSessionUseCase := usecase.NewRevokeSessionUseCase(sessionRepo, revocationList)
+	verifyTokenUseCase := usecase.NewVerifyTokenUseCase(userRepo, oauthClientRepo, jwtService, revocationList)
+	listSigningKeysUseCase := usecase.NewListSigningKeysUseCase(jwtService)
+	rotateSigningKeyUseCase := usecase.NewRotateSigningKeyUseCase(jwtService, cfg.JWT.KeyRingReloadInterval)
+	revokeUserTokensUseCase := usecase.NewRevokeUserTokensUseCase(userRepo, sessionRepo, revocationList)
+	unlockUserUseCase := usecase.NewUnlockUserUseCase(userRepo, loginThrottle)
+	listUsersUseCase := usecase.NewListUsersUseCase(userRepo)
+	getUserUseCase := usecase.NewGetUserUseCase(userRepo)
+	changeUserRoleUseCase := usecase.NewChangeUserRoleUseCase(userRepo, revocationList)
+	activateUserUseCase := usecase.NewActivateUserUseCase(userRepo)
+	deactivateUserUseCase := usecase.NewDeactivateUserUseCase(userRepo, sessionRepo, revocationList)
+	deleteUserUseCase := usecase.NewDeleteUserUseCase(userRepo, revocationList)
+	createInvitationUseCase := usecase.NewCreateInvitationUseCase(invitationRepo, userRepo, validationService, cfg.Auth.InvitationExpiry)
+	listInvitationsUseCase := usecase.NewListInvitationsUseCase(invitationRepo)
+	revokeInvitationUseCase := usecase.NewRevokeInvitationUseCase(invitationRepo)
+	acceptInvitationUseCase := usecase.NewAcceptInvitationUseCase(invitationRepo, registerUseCase)
+	requestPasswordResetUseCase := usecase.NewRequestPasswordResetUseCase(
+		userRepo,
+		passwordResetRepo,
+		validationService,
+		mailer,
+		cfg.Auth.PasswordResetExpiry,
+		cfg.Auth.PasswordResetURL,
+	)
+	resetPasswordUseCase := usecase.NewResetPasswordUseCase(
+		userRepo,
+		sessionRepo,
+		passwordResetRepo,
+		passwordHistoryRepo,
+		passwordService,
+		validationService,
+		revocationList,
+		cfg.Auth.PasswordHistorySize,
+	)
+	changePasswordUseCase := usecase.NewChangePasswordUseCase(
+		userRepo,
+		sessionRepo,
+		passwordHistoryRepo,
+		userIdentityRepo,
+		authenticator,
+		passwordService,
+		validationService,
+		revocationList,
+		cfg.Auth.PasswordHistorySize,
+	)
+	setupTOTPUseCase := usecase.NewSetupTOTPUseCase(userRepo, secretBox, cfg.MFA.Issuer)
+	enableTOTPUseCase := usecase.NewEnableTOTPUseCase(userRepo, recoveryCodeRepo, secretBox, mfaStore)
+	disableTOTPUseCase := usecase.NewDisableTOTPUseCase(userRepo, recoveryCodeRepo, authenticator)
+	completeMFALoginUseCase := usecase.NewCompleteMFALoginUseCase(
+		userRepo,
+		sessionRepo,
+		recoveryCodeRepo,
+		jwtService,
+		secretBox,
+		mfaStore,
+		loginThrottle,
+	)
+	beginPasskeyRegistrationUseCase := usecase.NewBeginPasskeyRegistrationUseCase(userRepo, webAuthnCredentialRepo, passkeyService, webAuthnStore)
+	finishPasskeyRegistrationUseCase := usecase.NewFinishPasskeyRegistrationUseCase(
+		userRepo,
+		webAuthnCredentialRepo,
+		passkeyService,
+		webAuthnStore,
+		validationService,
+	)
+	listPasskeysUseCase := usecase.NewListPasskeysUseCase(webAuthnCredentialRepo)
+	deletePasskeyUseCase := usecase.NewDeletePasskeyUseCase(userRepo, webAuthnCredentialRepo, authenticator)
+	beginPasskeyLoginUseCase := usecase.NewBeginPasskeyLoginUseCase(userRepo, webAuthnCredentialRepo, passkeyService, webAuthnStore, mfaStore)
+	finishPasskeyLoginUseCase := usecase.NewFinishPasskeyLoginUseCase(
+		userRepo,
+		sessionRepo,
+		webAuthnCredentialRepo,
+		jwtService,
+		passkeyService,
+		webAuthnStore,
+		mfaStore,
+	)
+	createOAuthClientUseCase := usecase.NewCreateOAuthClientUseCase(oauthClientRepo, validationService)
+	listOAuthClientsUseCase := usecase.NewListOAuthClientsUseCase(oauthClientRepo)
+	deleteOAuthClientUseCase := usecase.NewDeleteOAuthClientUseCase(oauthClientRepo)
+	authorizeUseCase := usecase.NewAuthorizeUseCase(oauthClientRepo, oauthStore, cfg.OAuth.LoginURL)
+	getAuthorizationRequestUseCase := usecase.NewGetAuthorizationRequestUseCase(oauthClientRepo, oauthConsentRepo, oauthStore)
+	completeAuthorizationUseCase := usecase.NewCompleteAuthorizationUseCase(userRepo, sessionRepo, oauthClientRepo, oauthConsentRepo, oauthStore)
+	oauthTokenUseCase := usecase.NewOAuthTokenUseCase(
+		oauthClientRepo,
+		userRepo,
+		sessionRepo,
+		oauthStore,
+		jwtService,
+		refreshTokenUseCase,
+		cfg.OIDC.Issuer,
+		cfg.OIDC.IDTokenExpiry,
+	)
+	introspectTokenUseCase := usecase.NewIntrospectTokenUseCase(oauthClientRepo, userRepo, sessionRepo, jwtService, revocationList)
+	revokeOAuthTokenUseCase := usecase.NewRevokeOAuthTokenUseCase(oauthClientRepo, sessionRepo, jwtService, revocationList)
+	getUserInfoUseCase := usecase.NewGetUserInfoUseCase(userRepo)
+	endSessionUseCase := usecase.NewEndSessionUseCase(oauthClientRepo, sessionRepo, jwtService, revocationList, cfg.OIDC.Issuer)
+	listFederatedProvidersUseCase := usecase.NewListFederatedProvidersUseCase(federationRegistry)
+	startFederatedLoginUseCase := usecase.NewStartFederatedLoginUseCase(federationRegistry, federationStore, cfg.Federation.CallbackBaseURL)
+	completeFederatedLoginUseCase := usecase.NewCompleteFederatedLoginUseCase(
+		federationRegistry,
+		federationStore,
+		userRepo,
+		userIdentityRepo,
+		passwordService,
+		validationService,
+		cfg.Federation.CallbackBaseURL,
+		cfg.Federation.LoginURL,
+	)
+	exchangeFederatedLoginUseCase := usecase.NewExchangeFederatedLoginUseCase(
+		userRepo,
+		sessionRepo,
+		webAuthnCredentialRepo,
+		jwtService,
+		mfaStore,
+		federationStore,
+	)
 	log.Println("✓ Initialized use cases")
 
 	// Inicializar handlers
@@ -76,15 +301,88 @@ func main() {
 		registerUseCase,
 		loginUseCase,
 		logoutUseCase,
+		logoutAllUseCase,
 		refreshTokenUseCase,
 		verifyTokenUseCase,
 	)
 
+	sessionHandler := handler.NewSessionHandler(listSessionsUseCase, revokeSessionUseCase)
+	keyHandler := handler.NewKeyHandler(jwtService, listSigningKeysUseCase, rotateSigningKeyUseCase)
+	adminHandler := handler.NewAdminHandler(
+		registerUseCase,
+		listUsersUseCase,
+		getUserUseCase,
+		changeUserRoleUseCase,
+		activateUserUseCase,
+		deactivateUserUseCase,
+		deleteUserUseCase,
+		revokeUserTokensUseCase,
+		unlockUserUseCase,
+	)
+	invitationHandler := handler.NewInvitationHandler(
+		createInvitationUseCase,
+		listInvitationsUseCase,
+		revokeInvitationUseCase,
+		acceptInvitationUseCase,
+	)
+	passwordHandler := handler.NewPasswordHandler(
+		requestPasswordResetUseCase,
+		resetPasswordUseCase,
+		changePasswordUseCase,
+	)
+	mfaHandler := handler.NewMFAHandler(
+		setupTOTPUseCase,
+		enableTOTPUseCase,
+		disableTOTPUseCase,
+		completeMFALoginUseCase,
+	)
+	passkeyHandler := handler.NewPasskeyHandler(
+		beginPasskeyRegistrationUseCase,
+		finishPasskeyRegistrationUseCase,
+		listPasskeysUseCase,
+		deletePasskeyUseCase,
+		beginPasskeyLoginUseCase,
+		finishPasskeyLoginUseCase,
+	)
+	oauthHandler := handler.NewOAuthHandler(
+		authorizeUseCase,
+		getAuthorizationRequestUseCase,
+		completeAuthorizationUseCase,
+		oauthTokenUseCase,
+		introspectTokenUseCase,
+		revokeOAuthTokenUseCase,
+	)
+	oauthClientHandler := handler.NewOAuthClientHandler(
+		createOAuthClientUseCase,
+		listOAuthClientsUseCase,
+		deleteOAuthClientUseCase,
+	)
+	oidcHandler := handler.NewOIDCHandler(cfg.OIDC.Issuer, jwtService, getUserInfoUseCase, endSessionUseCase)
+	federationHandler := handler.NewFederationHandler(
+		listFederatedProvidersUseCase,
+		startFederatedLoginUseCase,
+		completeFederatedLoginUseCase,
+		exchangeFederatedLoginUseCase,
+		strings.HasPrefix(cfg.Federation.CallbackBaseURL, "https://"),
+	)
+
 	// Inicializar middlewares
-	authMiddleware := middleware.NewAuthMiddleware(jwtService)
+	authMiddleware := middleware.NewAuthMiddleware(jwtService, revocationList)
+
+	// Limites de requisição por rota
+	var rateLimiter *cache.RateLimiter
+	if cfg.RateLimit.Enabled {
+		rateLimiter = cache.NewRateLimiter(redisClient)
+	}
 
 	// Configurar rotas
-	r := router.SetupRoutes(authHandler, authMiddleware)
+	// Headers de IP encaminhados só valem vindos dos proxies configurados
+	trustedProxies, err := middleware.ParseTrustedProxies(cfg.Server.TrustedProxies)
+	if err != nil {
+		log.Fatalf("Failed to parse trusted proxies: %v", err)
+	}
+
+	r := router.SetupRoutes(authHandler, sessionHandler, keyHandler, adminHandler, invitationHandler, passwordHandler, mfaHandler, passkeyHandler, oauthHandler, oauthClientHandler, oidcHandler, federationHandler, authMiddleware, rateLimiter, trustedProxies)
 	log.Println("✓ Routes configured")
 
 	// Iniciar servidor HTTP
@@ -129,3 +427,219 @@ func main() {
 		log.Println("✓ Server stopped gracefully")
 	}
 }
+
+// purgeSessionsPeriodically remove sessões expiradas e revogadas e tokens de redefinição
+// de senha expirados até o contexto ser cancelado
+func purgeSessionsPeriodically(ctx context.Context, uc *usecase.PurgeSessionsUseCase, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			removed, err := uc.Execute(ctx)
+			if err != nil {
+				log.Printf("Session cleanup failed: %v", err)
+				continue
+			}
+			if removed > 0 {
+				log.Printf("Session cleanup removed %d session(s)", removed)
+			}
+		}
+	}
+}
+
+// newPasswordHasher cria o hasher usado nos novos hashes de senha
+func newPasswordHasher(cfg config.PasswordConfig) (crypto.PasswordHasher, error) {
+	return crypto.NewPasswordHasher(
+		cfg.Algorithm,
+		crypto.Argon2idParams{
+			Memory:      uint32(cfg.Argon2Memory),
+			Time:        uint32(cfg.Argon2Time),
+			Parallelism: uint8(cfg.Argon2Parallelism),
+		},
+		cfg.BcryptCost,
+	)
+}
+
+// newPasswordPolicy monta a política de senhas, carregando a base de senhas vazadas se configurada
+func newPasswordPolicy(cfg config.PasswordConfig) (service.PasswordPolicy, error) {
+	policy := service.PasswordPolicy{
+		MinLength:   cfg.MinLength,
+		MinStrength: cfg.MinStrength,
+	}
+
+	if cfg.BreachedCorpusPath != "" {
+		corpus, err := breach.LoadCorpus(cfg.BreachedCorpusPath)
+		if err != nil {
+			return policy, err
+		}
+		policy.Breached = corpus
+		log.Printf("✓ Loaded breached password corpus (%d hashes)", corpus.Len())
+	}
+
+	return policy, nil
+}
+
+// loadSecretBox usa MFA_ENCRYPTION_KEY ou, na ausência dela, uma chave derivada do JWT_SECRET
+func loadSecretBox(cfg *config.Config) (*crypto.SecretBox, error) {
+	if cfg.MFA.EncryptionKey == "" {
+		log.Println("MFA_ENCRYPTION_KEY not set, deriving MFA encryption key from JWT_SECRET")
+		key := sha256.Sum256([]byte(cfg.JWT.Secret))
+		return crypto.NewSecretBox(key[:])
+	}
+
+	key, err := base64.StdEncoding.DecodeString(cfg.MFA.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("MFA_ENCRYPTION_KEY must be base64: %w", err)
+	}
+	return crypto.NewSecretBox(key)
+}
+
+// newFederationRegistry valida os provedores de identidade configurados
+func newFederationRegistry(cfg config.FederationConfig) (*federation.Registry, error) {
+	configs := make([]federation.Config, 0, len(cfg.Providers))
+	for _, provider := range cfg.Providers {
+		if provider.Issuer == "" || provider.ClientID == "" {
+			return nil, fmt.Errorf("provider %s: issuer and client ID are required", provider.Name)
+		}
+		if !entity.IsValidRole(entity.UserRole(provider.DefaultRole)) {
+			return nil, fmt.Errorf("provider %s: invalid default role %q", provider.Name, provider.DefaultRole)
+		}
+
+		configs = append(configs, federation.Config{
+			Name:         provider.Name,
+			DisplayName:  provider.DisplayName,
+			Issuer:       provider.Issuer,
+			ClientID:     provider.ClientID,
+			ClientSecret: provider.ClientSecret,
+			Scopes:       provider.Scopes,
+			DefaultRole:  provider.DefaultRole,
+			TrustEmail:   provider.TrustEmail,
+		})
+	}
+
+	return federation.NewRegistry(configs), nil
+}
+
+// newAuthenticator seleciona o backend que valida a senha no login
+func newAuthenticator(
+	cfg *config.Config,
+	userRepo repository.UserRepository,
+	identityRepo repository.UserIdentityRepository,
+	passwordService *crypto.PasswordService,
+	validationService *service.ValidationService,
+) (usecase.Authenticator, error) {
+	local := usecase.NewLocalAuthenticator(userRepo, passwordService)
+
+	switch cfg.Auth.Backend {
+	case "local":
+		return local, nil
+	case "ldap":
+		if cfg.LDAP.URL == "" || cfg.LDAP.BaseDN == "" {
+			return nil, fmt.Errorf("LDAP URL and base DN are required")
+		}
+	default:
+		return nil, fmt.Errorf("unknown backend %q", cfg.Auth.Backend)
+	}
+
+	roleGroups := make(map[entity.UserRole][]string, len(cfg.LDAP.RoleGroups))
+	for role, groups := range cfg.LDAP.RoleGroups {
+		roleGroups[entity.UserRole(role)] = groups
+	}
+
+	defaultRole := entity.UserRole(cfg.LDAP.DefaultRole)
+	if defaultRole == "none" {
+		defaultRole = ""
+	} else if !entity.IsValidRole(defaultRole) {
+		return nil, fmt.Errorf("invalid LDAP default role %q", cfg.LDAP.DefaultRole)
+	}
+
+	var fallback usecase.Authenticator
+	if cfg.LDAP.LocalFallback {
+		fallback = local
+	}
+
+	dir := directory.NewDirectory(directory.Config{
+		URL:                cfg.LDAP.URL,
+		StartTLS:           cfg.LDAP.StartTLS,
+		InsecureSkipVerify: cfg.LDAP.InsecureSkipVerify,
+		BindDN:             cfg.LDAP.BindDN,
+		BindPassword:       cfg.LDAP.BindPassword,
+		BaseDN:             cfg.LDAP.BaseDN,
+		UserFilter:         cfg.LDAP.UserFilter,
+		IDAttribute:        cfg.LDAP.IDAttribute,
+		EmailAttribute:     cfg.LDAP.EmailAttribute,
+		NameAttribute:      cfg.LDAP.NameAttribute,
+		GroupAttribute:     cfg.LDAP.GroupAttribute,
+		GroupBaseDN:        cfg.LDAP.GroupBaseDN,
+		GroupFilter:        cfg.LDAP.GroupFilter,
+		Timeout:            cfg.LDAP.Timeout,
+	})
+
+	return usecase.NewLDAPAuthenticator(
+		dir,
+		userRepo,
+		identityRepo,
+		passwordService,
+		validationService,
+		roleGroups,
+		defaultRole,
+		fallback,
+	), nil
+}
+
+// newMailer seleciona o adaptador de entrega de emails configurado
+func newMailer(cfg config.MailConfig) mail.Mailer {
+	if cfg.Driver == "smtp" {
+		return mail.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From)
+	}
+	return mail.NewLogMailer(cfg.OutboxDir)
+}
+
+// loadKeyRing usa o keyring em JWT_KEYS_DIR (com rotação) ou uma chave estática
+func loadKeyRing(cfg config.JWTConfig) (*crypto.KeyRing, error) {
+	if cfg.KeysDir == "" {
+		key, err := loadSigningKey(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.NewStaticKeyRing(key), nil
+	}
+
+	store := crypto.NewFileKeyStore(cfg.KeysDir)
+	return crypto.NewFileKeyRing(store, func() (*crypto.SigningKey, error) {
+		if cfg.PrivateKeyPath != "" {
+			return crypto.LoadSigningKeyFromFile(cfg.PrivateKeyPath, cfg.SigningAlgorithm, cfg.KeyID)
+		}
+
+		alg := cfg.SigningAlgorithm
+		if alg == "" || alg == "HS256" {
+			alg = crypto.DefaultKeyAlgorithm
+		}
+		return crypto.GenerateSigningKey(alg)
+	})
+}
+
+// loadSigningKey usa a chave privada PEM configurada ou, na ausência dela, o segredo HS256
+func loadSigningKey(cfg config.JWTConfig) (*crypto.SigningKey, error) {
+	if cfg.PrivateKeyPath != "" {
+		return crypto.LoadSigningKeyFromFile(cfg.PrivateKeyPath, cfg.SigningAlgorithm, cfg.KeyID)
+	}
+
+	if cfg.SigningAlgorithm != "" && cfg.SigningAlgorithm != "HS256" {
+		return nil, fmt.Errorf("JWT_PRIVATE_KEY_PATH is required for %s", cfg.SigningAlgorithm)
+	}
+
+	kid := cfg.KeyID
+	if kid == "" {
+		kid = "default"
+	}
+	return crypto.NewHMACSigningKey(kid, []byte(cfg.Secret)), nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/service"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/breach"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/database"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/usecase"
+	"github.com/jvieiradev/titanwatch/auth-service/pkg/config"
+)
+
+// Cria o primeiro admin. Não faz nada se já existir algum admin ativo; admins apenas
+// desativados não contam, para que o comando recupere o acesso administrativo.
+func main() {
+	email := os.Getenv("BOOTSTRAP_ADMIN_EMAIL")
+	password := os.Getenv("BOOTSTRAP_ADMIN_PASSWORD")
+	name := os.Getenv("BOOTSTRAP_ADMIN_NAME")
+	if name == "" {
+		name = "Administrator"
+	}
+
+	if email == "" || password == "" {
+		log.Fatal("Usage: BOOTSTRAP_ADMIN_EMAIL=... BOOTSTRAP_ADMIN_PASSWORD=... [BOOTSTRAP_ADMIN_NAME=...] go run cmd/bootstrap/main.go")
+	}
+
+	// Carregar configurações
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Conectar ao banco
+	db, err := database.NewPostgresConnection(cfg.GetDSN())
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	userRepo := database.NewPostgresUserRepository(db)
+
+	admins, err := userRepo.CountActiveByRole(ctx, entity.RoleAdmin)
+	if err != nil {
+		log.Fatalf("Failed to count admins: %v", err)
+	}
+	if admins > 0 {
+		log.Println("An active admin already exists, nothing to do")
+		return
+	}
+
+	passwordHasher, err := crypto.NewPasswordHasher(
+		cfg.Password.Algorithm,
+		crypto.Argon2idParams{
+			Memory:      uint32(cfg.Password.Argon2Memory),
+			Time:        uint32(cfg.Password.Argon2Time),
+			Parallelism: uint8(cfg.Password.Argon2Parallelism),
+		},
+		cfg.Password.BcryptCost,
+	)
+	if err != nil {
+		log.Fatalf("Failed to initialize password hashing: %v", err)
+	}
+
+	passwordPolicy := service.PasswordPolicy{
+		MinLength:   cfg.Password.MinLength,
+		MinStrength: cfg.Password.MinStrength,
+	}
+	if cfg.Password.BreachedCorpusPath != "" {
+		corpus, err := breach.LoadCorpus(cfg.Password.BreachedCorpusPath)
+		if err != nil {
+			log.Fatalf("Failed to load breached password corpus: %v", err)
+		}
+		passwordPolicy.Breached = corpus
+	}
+
+	registerUseCase := usecase.NewRegisterUserUseCase(
+		userRepo,
+		crypto.NewPasswordService(passwordHasher),
+		service.NewValidationService(passwordPolicy),
+		nil,
+		false,
+		false,
+	)
+
+	output, err := registerUseCase.CreateWithRole(ctx, usecase.RegisterUserInput{
+		Email:    email,
+		Password: password,
+		Name:     name,
+		Role:     entity.RoleAdmin,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create admin: %v", err)
+	}
+
+	log.Printf("Admin %s created with ID %s", output.Email, output.UserID)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	"github.com/jvieiradev/titanwatch/auth-service/pkg/config"
+)
+
+const usage = "Usage: go run cmd/keys/main.go [list|rotate [alg]|retire <kid>|prune]"
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal(usage)
+	}
+
+	// Carregar configurações
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	if cfg.JWT.KeysDir == "" {
+		log.Fatal("JWT_KEYS_DIR must be set to manage signing keys")
+	}
+
+	store := crypto.NewFileKeyStore(cfg.JWT.KeysDir)
+	keyRing, err := crypto.NewFileKeyRing(store, func() (*crypto.SigningKey, error) {
+		alg := cfg.JWT.SigningAlgorithm
+		if alg == "" || alg == "HS256" {
+			alg = crypto.DefaultKeyAlgorithm
+		}
+		return crypto.GenerateSigningKey(alg)
+	})
+	if err != nil {
+		log.Fatalf("Failed to load keyring: %v", err)
+	}
+
+	switch os.Args[1] {
+	case "list":
+		listKeys(keyRing)
+	case "rotate":
+		alg := ""
+		if len(os.Args) > 2 {
+			alg = os.Args[2]
+		}
+		// A nova chave só assina após o intervalo de recarga das instâncias em execução;
+		// a anterior permanece válida até o último refresh token emitido com ela expirar
+		key, err := keyRing.Rotate(alg, cfg.JWT.KeyRingReloadInterval, cfg.JWT.RefreshTokenExpiry)
+		if err != nil {
+			log.Fatalf("Rotation failed: %v", err)
+		}
+		log.Printf("New key: %s (%s), signing from %s", key.ID, key.Method.Alg(), key.NotBefore.Format(time.RFC3339))
+	case "retire":
+		if len(os.Args) < 3 {
+			log.Fatal(usage)
+		}
+		if err := keyRing.Retire(os.Args[2]); err != nil {
+			log.Fatalf("Retire failed: %v", err)
+		}
+		log.Printf("Key %s retired", os.Args[2])
+	case "prune":
+		removed, err := store.Prune(time.Now())
+		if err != nil {
+			log.Fatalf("Prune failed: %v", err)
+		}
+		log.Printf("Removed %d retired key(s): %v", len(removed), removed)
+	default:
+		log.Fatal(usage)
+	}
+}
+
+func listKeys(keyRing *crypto.KeyRing) {
+	active := keyRing.Active()
+	for _, key := range keyRing.Keys() {
+		status := "verify-only"
+		switch {
+		case key.ID == active.ID:
+			status = "active"
+		case key.NotBefore.After(time.Now()):
+			status = "pending"
+		}
+
+		retireAt := "-"
+		if key.RetireAt != nil {
+			retireAt = key.RetireAt.Format(time.RFC3339)
+		}
+
+		log.Printf("%s  %-6s  %-11s  not_before=%s  retire_at=%s",
+			key.ID, key.Method.Alg(), status, key.NotBefore.Format(time.RFC3339), retireAt)
+	}
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+)
+
+// Provedor OpenID Connect mínimo para testar o login federado localmente. Qualquer
+// email digitado é aceito; não use fora do ambiente de desenvolvimento.
+//
+//	STUB_IDP_ADDR=:9000 STUB_IDP_ISSUER=http://localhost:9000 go run cmd/stubidp/main.go
+func main() {
+	addr := getEnv("STUB_IDP_ADDR", ":9000")
+	issuer := strings.TrimSuffix(getEnv("STUB_IDP_ISSUER", "http://localhost:9000"), "/")
+
+	key, err := crypto.GenerateSigningKey("ES256")
+	if err != nil {
+		log.Fatalf("Failed to generate signing key: %v", err)
+	}
+
+	idp := &stubIdP{
+		issuer:       issuer,
+		clientID:     getEnv("STUB_IDP_CLIENT_ID", "auth-service"),
+		clientSecret: getEnv("STUB_IDP_CLIENT_SECRET", "stub-secret"),
+		jwtService:   crypto.NewJWTService(crypto.NewStaticKeyRing(key), 0, 0),
+		codes:        make(map[string]stubCode),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("/jwks", idp.jwks)
+	mux.HandleFunc("/authorize", idp.authorize)
+	mux.HandleFunc("/token", idp.token)
+
+	log.Printf("Stub identity provider %s listening on %s (client_id=%s)", issuer, addr, idp.clientID)
+	log.Fatal(http.ListenAndServe(addr, mux))
+}
+
+// codeExpiry é a validade dos authorization codes emitidos
+const codeExpiry = time.Minute
+
+type stubIdP struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	jwtService   *crypto.JWTService
+
+	mu    sync.Mutex
+	codes map[string]stubCode
+}
+
+// stubCode é a autorização concedida no formulário, trocada no token endpoint
+type stubCode struct {
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	email         string
+	emailVerified bool
+	name          string
+	expiresAt     time.Time
+}
+
+func (s *stubIdP) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                s.issuer,
+		"authorization_endpoint":                s.issuer + "/authorize",
+		"token_endpoint":                        s.issuer + "/token",
+		"jwks_uri":                              s.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"ES256"},
+		"code_challenge_methods_supported":      []string{crypto.PKCEMethodS256},
+	})
+}
+
+func (s *stubIdP) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.jwtService.JWKS())
+}
+
+var loginPage = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Stub IdP</title></head>
+<body>
+<h1>Stub identity provider</h1>
+<form method="post" action="/authorize">
+{{range $name, $value := .Params}}<input type="hidden" name="{{$name}}" value="{{$value}}">
+{{end}}<p><label>Email <input name="email" type="email" required></label></p>
+<p><label>Name <input name="name"></label></p>
+<p><label><input name="email_verified" type="checkbox" value="true" checked> Email verified</label></p>
+<p><button name="action" value="approve">Sign in</button> <button name="action" value="deny" formnovalidate>Deny</button></p>
+</form>
+</body>
+</html>`))
+
+// authorize exibe o formulário de login (GET) e emite o code (POST). Qualquer
+// redirect_uri é aceita: o stub não mantém registro de clientes.
+func (s *stubIdP) authorize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	params := map[string]string{}
+	for _, name := range []string{"client_id", "redirect_uri", "state", "nonce", "code_challenge", "code_challenge_method", "response_type"} {
+		params[name] = r.Form.Get(name)
+	}
+
+	if params["client_id"] != s.clientID || params["response_type"] != "code" || params["redirect_uri"] == "" {
+		http.Error(w, "invalid client_id, response_type or redirect_uri", http.StatusBadRequest)
+		return
+	}
+	if params["code_challenge_method"] != crypto.PKCEMethodS256 || !crypto.ValidCodeChallenge(params["code_challenge"]) {
+		http.Error(w, "PKCE (S256) is required", http.StatusBadRequest)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := loginPage.Execute(w, map[string]interface{}{"Params": params}); err != nil {
+			log.Printf("Failed to render login page: %v", err)
+		}
+		return
+	}
+
+	redirect := url.Values{}
+	if params["state"] != "" {
+		redirect.Set("state", params["state"])
+	}
+
+	if r.Form.Get("action") == "deny" {
+		redirect.Set("error", "access_denied")
+		http.Redirect(w, r, appendQuery(params["redirect_uri"], redirect), http.StatusFound)
+		return
+	}
+
+	code, err := crypto.GenerateOpaqueToken()
+	if err != nil {
+		http.Error(w, "failed to generate code", http.StatusInternalServerError)
+		return
+	}
+
+	s.mu.Lock()
+	s.codes[code] = stubCode{
+		redirectURI:   params["redirect_uri"],
+		nonce:         params["nonce"],
+		codeChallenge: params["code_challenge"],
+		email:         strings.TrimSpace(r.Form.Get("email")),
+		emailVerified: r.Form.Get("email_verified") == "true",
+		name:          strings.TrimSpace(r.Form.Get("name")),
+		expiresAt:     time.Now().Add(codeExpiry),
+	}
+	s.mu.Unlock()
+
+	redirect.Set("code", code)
+	http.Redirect(w, r, appendQuery(params["redirect_uri"], redirect), http.StatusFound)
+}
+
+// token troca o code pelo ID token, exigindo a autenticação do cliente e o PKCE
+func (s *stubIdP) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		tokenError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != s.clientID || clientSecret != s.clientSecret {
+		tokenError(w, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		tokenError(w, http.StatusBadRequest, "unsupported_grant_type")
+		return
+	}
+
+	s.mu.Lock()
+	grant, found := s.codes[r.PostForm.Get("code")]
+	delete(s.codes, r.PostForm.Get("code"))
+	s.mu.Unlock()
+
+	if !found || time.Now().After(grant.expiresAt) ||
+		grant.redirectURI != r.PostForm.Get("redirect_uri") ||
+		!crypto.VerifyPKCE(grant.codeChallenge, r.PostForm.Get("code_verifier")) {
+		tokenError(w, http.StatusBadRequest, "invalid_grant")
+		return
+	}
+
+	// O subject é derivado do email, para que o mesmo email volte como a mesma identidade
+	sum := sha256.Sum256([]byte(strings.ToLower(grant.email)))
+	idToken, err := s.jwtService.GenerateIDToken(crypto.IDToken{
+		Issuer:        s.issuer,
+		Subject:       "stub-" + hex.EncodeToString(sum[:8]),
+		Audience:      s.clientID,
+		Nonce:         grant.nonce,
+		AuthTime:      time.Now(),
+		Email:         grant.email,
+		EmailVerified: grant.emailVerified,
+		Name:          grant.name,
+	}, 5*time.Minute)
+	if err != nil {
+		tokenError(w, http.StatusInternalServerError, "server_error")
+		return
+	}
+
+	accessToken, err := crypto.GenerateOpaqueToken()
+	if err != nil {
+		tokenError(w, http.StatusInternalServerError, "server_error")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func tokenError(w http.ResponseWriter, status int, code string) {
+	writeJSON(w, status, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(payload)
+}
+
+func appendQuery(rawURL string, params url.Values) string {
+	separator := "?"
+	if strings.Contains(rawURL, "?") {
+		separator = "&"
+	}
+	return rawURL + separator + params.Encode()
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
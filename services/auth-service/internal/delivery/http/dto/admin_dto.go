@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+// ChangeRoleRequest DTO para alteração de role
+type ChangeRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// AdminUserDTO DTO para dados completos de um usuário
+type AdminUserDTO struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// UserListResponse DTO para listagem paginada de usuários
+type UserListResponse struct {
+	Users   []AdminUserDTO `json:"users"`
+	Page    int            `json:"page"`
+	PerPage int            `json:"per_page"`
+	Total   int            `json:"total"`
+}
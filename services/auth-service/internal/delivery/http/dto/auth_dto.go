@@ -19,6 +19,11 @@ type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+// LogoutRequest DTO para logout da sessão atual
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
 // AuthResponse DTO para resposta de autenticação
 type AuthResponse struct {
 	AccessToken  string  `json:"access_token"`
@@ -42,10 +47,13 @@ type UserDTO struct {
 
 // VerifyTokenResponse DTO para resposta de verificação de token
 type VerifyTokenResponse struct {
-	Valid  bool   `json:"valid"`
-	UserID string `json:"user_id,omitempty"`
-	Email  string `json:"email,omitempty"`
-	Role   string `json:"role,omitempty"`
+	Valid       bool   `json:"valid"`
+	SubjectType string `json:"sub_type,omitempty"`
+	UserID      string `json:"user_id,omitempty"`
+	Email       string `json:"email,omitempty"`
+	Role        string `json:"role,omitempty"`
+	ClientID    string `json:"client_id,omitempty"`
+	Scope       string `json:"scope,omitempty"`
 }
 
 // ErrorResponse DTO para resposta de erro
@@ -0,0 +1,12 @@
+package dto
+
+// FederatedProviderDTO DTO para um provedor de identidade externo disponível no login
+type FederatedProviderDTO struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+}
+
+// FederatedExchangeRequest DTO para trocar o code do login federado pelos tokens
+type FederatedExchangeRequest struct {
+	Code string `json:"code"`
+}
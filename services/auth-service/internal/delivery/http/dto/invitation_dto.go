@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+// CreateInvitationRequest DTO para emissão de convite
+type CreateInvitationRequest struct {
+	Email          string `json:"email"`
+	Role           string `json:"role"`
+	ExpiresInHours int    `json:"expires_in_hours,omitempty"`
+}
+
+// AcceptInvitationRequest DTO para aceite de convite
+type AcceptInvitationRequest struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+// InvitationDTO DTO para dados de um convite
+type InvitationDTO struct {
+	ID         string     `json:"id"`
+	Email      string     `json:"email"`
+	Role       string     `json:"role"`
+	Status     string     `json:"status"`
+	InvitedBy  string     `json:"invited_by"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+}
+
+// CreateInvitationResponse DTO de resposta da emissão; o token não é exibido novamente
+type CreateInvitationResponse struct {
+	Invitation InvitationDTO `json:"invitation"`
+	Token      string        `json:"token"`
+}
+
+// InvitationListResponse DTO para listagem paginada de convites
+type InvitationListResponse struct {
+	Invitations []InvitationDTO `json:"invitations"`
+	Page        int             `json:"page"`
+	PerPage     int             `json:"per_page"`
+	Total       int             `json:"total"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+// RotateKeyRequest DTO para rotação da chave de assinatura
+type RotateKeyRequest struct {
+	Algorithm string `json:"alg"`
+}
+
+// SigningKeyDTO DTO para dados de uma chave de assinatura
+type SigningKeyDTO struct {
+	KeyID     string     `json:"kid"`
+	Algorithm string     `json:"alg"`
+	NotBefore time.Time  `json:"not_before"`
+	RetireAt  *time.Time `json:"retire_at,omitempty"`
+	Active    bool       `json:"active"`
+}
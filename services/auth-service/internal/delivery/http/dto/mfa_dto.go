@@ -0,0 +1,36 @@
+package dto
+
+// MFAChallengeResponse DTO de resposta do login quando o segundo fator é exigido
+type MFAChallengeResponse struct {
+	MFARequired bool     `json:"mfa_required"`
+	MFAToken    string   `json:"mfa_token"`
+	Methods     []string `json:"methods"`
+}
+
+// MFALoginRequest DTO para concluir o login com o segundo fator
+type MFALoginRequest struct {
+	MFAToken     string `json:"mfa_token"`
+	Code         string `json:"code,omitempty"`
+	RecoveryCode string `json:"recovery_code,omitempty"`
+}
+
+// TOTPSetupResponse DTO com o segredo TOTP pendente de confirmação
+type TOTPSetupResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// TOTPVerifyRequest DTO para confirmação do TOTP
+type TOTPVerifyRequest struct {
+	Code string `json:"code"`
+}
+
+// TOTPVerifyResponse DTO com os códigos de recuperação, exibidos uma única vez
+type TOTPVerifyResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TOTPDisableRequest DTO para desativação do TOTP
+type TOTPDisableRequest struct {
+	Password string `json:"password"`
+}
@@ -0,0 +1,110 @@
+package dto
+
+import "time"
+
+// CreateOAuthClientRequest DTO para registro de cliente OAuth
+type CreateOAuthClientRequest struct {
+	Name         string   `json:"name"`
+	RedirectURIs []string `json:"redirect_uris"`
+	// PostLogoutRedirectURIs são os destinos aceitos pelo end_session_endpoint
+	PostLogoutRedirectURIs []string `json:"post_logout_redirect_uris"`
+	Scopes                 []string `json:"scopes"`
+	GrantTypes             []string `json:"grant_types"`
+	// AccessTokenTTL em segundos, para tokens client_credentials (0 usa a padrão)
+	AccessTokenTTL int  `json:"access_token_ttl"`
+	Confidential   bool `json:"confidential"`
+	SkipConsent    bool `json:"skip_consent"`
+}
+
+// OAuthClientDTO DTO para dados de um cliente OAuth
+type OAuthClientDTO struct {
+	ClientID     string   `json:"client_id"`
+	Name         string   `json:"name"`
+	Confidential bool     `json:"confidential"`
+	RedirectURIs []string `json:"redirect_uris"`
+	// PostLogoutRedirectURIs são os destinos aceitos pelo end_session_endpoint
+	PostLogoutRedirectURIs []string `json:"post_logout_redirect_uris"`
+	Scopes                 []string `json:"scopes"`
+	GrantTypes             []string `json:"grant_types"`
+	// AccessTokenTTL em segundos (0 usa a padrão)
+	AccessTokenTTL int       `json:"access_token_ttl"`
+	SkipConsent    bool      `json:"skip_consent"`
+	IsActive       bool      `json:"is_active"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// CreateOAuthClientResponse DTO de resposta do registro; o segredo não é exibido novamente
+type CreateOAuthClientResponse struct {
+	Client       OAuthClientDTO `json:"client"`
+	ClientSecret string         `json:"client_secret,omitempty"`
+}
+
+// AuthorizationRequestResponse DTO com os dados exibidos na tela de consentimento
+type AuthorizationRequestResponse struct {
+	ClientID        string   `json:"client_id"`
+	ClientName      string   `json:"client_name"`
+	Scopes          []string `json:"scopes"`
+	ConsentRequired bool     `json:"consent_required"`
+}
+
+// AuthorizationDecisionResponse DTO com o destino do navegador após a decisão
+type AuthorizationDecisionResponse struct {
+	RedirectTo string `json:"redirect_to"`
+}
+
+// OAuthTokenResponse DTO de resposta do token endpoint (RFC 6749, seção 5.1)
+type OAuthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+}
+
+// OAuthErrorResponse DTO de erro dos endpoints OAuth (RFC 6749, seção 5.2)
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// OpenIDConfigurationResponse DTO do documento de discovery do OpenID Connect
+type OpenIDConfigurationResponse struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	EndSessionEndpoint                string   `json:"end_session_endpoint"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+// UserInfoResponse DTO de resposta do userinfo endpoint
+type UserInfoResponse struct {
+	Subject   string `json:"sub"`
+	Name      string `json:"name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Role      string `json:"role,omitempty"`
+	UpdatedAt int64  `json:"updated_at,omitempty"`
+}
+
+// IntrospectionResponse DTO de resposta da introspecção (RFC 7662, seção 2.2)
+type IntrospectionResponse struct {
+	Active      bool   `json:"active"`
+	TokenType   string `json:"token_type,omitempty"`
+	SubjectType string `json:"sub_type,omitempty"`
+	Subject     string `json:"sub,omitempty"`
+	Username    string `json:"username,omitempty"`
+	ClientID    string `json:"client_id,omitempty"`
+	Scope       string `json:"scope,omitempty"`
+	TokenID     string `json:"jti,omitempty"`
+	ExpiresAt   int64  `json:"exp,omitempty"`
+	IssuedAt    int64  `json:"iat,omitempty"`
+}
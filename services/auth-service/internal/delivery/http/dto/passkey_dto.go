@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// PasskeyOptionsResponse DTO com as opções repassadas a navigator.credentials.create/get
+type PasskeyOptionsResponse struct {
+	ChallengeToken string          `json:"challenge_token,omitempty"`
+	Options        json.RawMessage `json:"options"`
+}
+
+// PasskeyRegisterRequest DTO para concluir o registro de uma passkey
+type PasskeyRegisterRequest struct {
+	Name       string          `json:"name,omitempty"`
+	Credential json.RawMessage `json:"credential"`
+}
+
+// PasskeyLoginBeginRequest DTO para iniciar o login com passkey.
+// Com mfa_token, a passkey é usada como segundo fator do login com senha.
+type PasskeyLoginBeginRequest struct {
+	MFAToken string `json:"mfa_token,omitempty"`
+}
+
+// PasskeyLoginFinishRequest DTO para concluir o login com passkey
+type PasskeyLoginFinishRequest struct {
+	ChallengeToken string          `json:"challenge_token"`
+	MFAToken       string          `json:"mfa_token,omitempty"`
+	Credential     json.RawMessage `json:"credential"`
+}
+
+// PasskeyDeleteRequest DTO para remoção de uma passkey
+type PasskeyDeleteRequest struct {
+	Password string `json:"password"`
+}
+
+// PasskeyDTO DTO para dados de uma passkey registrada
+type PasskeyDTO struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Transports []string   `json:"transports"`
+	Synced     bool       `json:"synced"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+// ForgotPasswordRequest DTO para solicitação de redefinição de senha
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// ResetPasswordRequest DTO para redefinição de senha com token
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// ChangePasswordRequest DTO para troca de senha pelo próprio usuário
+type ChangePasswordRequest struct {
+	CurrentPassword     string `json:"current_password"`
+	NewPassword         string `json:"new_password"`
+	RevokeOtherSessions bool   `json:"revoke_other_sessions"`
+}
+
+// ChangePasswordResponse DTO de resposta da troca de senha
+type ChangePasswordResponse struct {
+	RevokedSessions int `json:"revoked_sessions"`
+}
+
+// PasswordPolicyErrorResponse DTO de erro para senha rejeitada pela política de senhas
+type PasswordPolicyErrorResponse struct {
+	Error   string              `json:"error"`
+	Message string              `json:"message"`
+	Reasons []PasswordReasonDTO `json:"reasons"`
+	// Strength é a força estimada da senha, de 0 (muito fraca) a 4 (muito forte)
+	Strength int `json:"strength"`
+}
+
+// PasswordReasonDTO DTO de um motivo de rejeição da senha
+type PasswordReasonDTO struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,295 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/delivery/http/dto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/usecase"
+)
+
+type AdminHandler struct {
+	registerUseCase         *usecase.RegisterUserUseCase
+	listUsersUseCase        *usecase.ListUsersUseCase
+	getUserUseCase          *usecase.GetUserUseCase
+	changeUserRoleUseCase   *usecase.ChangeUserRoleUseCase
+	activateUserUseCase     *usecase.ActivateUserUseCase
+	deactivateUserUseCase   *usecase.DeactivateUserUseCase
+	deleteUserUseCase       *usecase.DeleteUserUseCase
+	revokeUserTokensUseCase *usecase.RevokeUserTokensUseCase
+	unlockUserUseCase       *usecase.UnlockUserUseCase
+}
+
+func NewAdminHandler(
+	registerUseCase *usecase.RegisterUserUseCase,
+	listUsersUseCase *usecase.ListUsersUseCase,
+	getUserUseCase *usecase.GetUserUseCase,
+	changeUserRoleUseCase *usecase.ChangeUserRoleUseCase,
+	activateUserUseCase *usecase.ActivateUserUseCase,
+	deactivateUserUseCase *usecase.DeactivateUserUseCase,
+	deleteUserUseCase *usecase.DeleteUserUseCase,
+	revokeUserTokensUseCase *usecase.RevokeUserTokensUseCase,
+	unlockUserUseCase *usecase.UnlockUserUseCase,
+) *AdminHandler {
+	return &AdminHandler{
+		registerUseCase:         registerUseCase,
+		listUsersUseCase:        listUsersUseCase,
+		getUserUseCase:          getUserUseCase,
+		changeUserRoleUseCase:   changeUserRoleUseCase,
+		activateUserUseCase:     activateUserUseCase,
+		deactivateUserUseCase:   deactivateUserUseCase,
+		deleteUserUseCase:       deleteUserUseCase,
+		revokeUserTokensUseCase: revokeUserTokensUseCase,
+		unlockUserUseCase:       unlockUserUseCase,
+	}
+}
+
+// CreateUser handler - cria usuário com qualquer role válida
+func (h *AdminHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var req dto.RegisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	input := usecase.RegisterUserInput{
+		Email:    req.Email,
+		Password: req.Password,
+		Name:     req.Name,
+		Role:     entity.UserRole(req.Role),
+	}
+
+	output, err := h.registerUseCase.CreateWithRole(r.Context(), input)
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, dto.SuccessResponse{
+		Message: "User created successfully",
+		Data: dto.UserDTO{
+			ID:    output.UserID,
+			Email: output.Email,
+			Name:  output.Name,
+			Role:  output.Role,
+		},
+	})
+}
+
+// ListUsers handler
+func (h *AdminHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+
+	output, err := h.listUsersUseCase.Execute(r.Context(), usecase.ListUsersInput{
+		Page:    page,
+		PerPage: perPage,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	users := make([]dto.AdminUserDTO, 0, len(output.Users))
+	for _, user := range output.Users {
+		users = append(users, toAdminUserDTO(user))
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Users retrieved successfully",
+		Data: dto.UserListResponse{
+			Users:   users,
+			Page:    output.Page,
+			PerPage: output.PerPage,
+			Total:   output.Total,
+		},
+	})
+}
+
+// GetUser handler
+func (h *AdminHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	output, err := h.getUserUseCase.Execute(r.Context(), usecase.GetUserInput{UserID: userID})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "User retrieved successfully",
+		Data:    toAdminUserDTO(*output),
+	})
+}
+
+// ChangeRole handler
+func (h *AdminHandler) ChangeRole(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := currentUserID(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	var req dto.ChangeRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	output, err := h.changeUserRoleUseCase.Execute(r.Context(), usecase.ChangeUserRoleInput{
+		ActorID: actorID,
+		UserID:  userID,
+		Role:    entity.UserRole(req.Role),
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "User role updated successfully",
+		Data:    toAdminUserDTO(*output),
+	})
+}
+
+// ActivateUser handler
+func (h *AdminHandler) ActivateUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	output, err := h.activateUserUseCase.Execute(r.Context(), usecase.ActivateUserInput{UserID: userID})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "User activated successfully",
+		Data:    toAdminUserDTO(*output),
+	})
+}
+
+// DeactivateUser handler
+func (h *AdminHandler) DeactivateUser(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := currentUserID(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	output, err := h.deactivateUserUseCase.Execute(r.Context(), usecase.DeactivateUserInput{
+		ActorID: actorID,
+		UserID:  userID,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "User deactivated successfully",
+		Data:    toAdminUserDTO(*output),
+	})
+}
+
+// DeleteUser handler
+func (h *AdminHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := currentUserID(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := h.deleteUserUseCase.Execute(r.Context(), usecase.DeleteUserInput{
+		ActorID: actorID,
+		UserID:  userID,
+	}); err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "User deleted successfully",
+	})
+}
+
+// RevokeUserTokens handler
+func (h *AdminHandler) RevokeUserTokens(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	input := usecase.RevokeUserTokensInput{
+		UserID: userID,
+	}
+
+	if err := h.revokeUserTokensUseCase.Execute(r.Context(), input); err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "User tokens revoked successfully",
+	})
+}
+
+// UnlockUser handler - remove o bloqueio de login por excesso de falhas
+func (h *AdminHandler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := h.unlockUserUseCase.Execute(r.Context(), usecase.UnlockUserInput{UserID: userID}); err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "User unlocked successfully",
+	})
+}
+
+func toAdminUserDTO(user usecase.UserDetailsOutput) dto.AdminUserDTO {
+	return dto.AdminUserDTO{
+		ID:        user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+		Role:      user.Role,
+		IsActive:  user.IsActive,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
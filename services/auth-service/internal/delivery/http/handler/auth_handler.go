@@ -3,20 +3,30 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"io"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/delivery/http/dto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/delivery/http/middleware"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/service"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/usecase"
 	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
 )
 
+const maxUserAgentLength = 512
+
 type AuthHandler struct {
 	registerUseCase     *usecase.RegisterUserUseCase
 	loginUseCase        *usecase.LoginUseCase
 	logoutUseCase       *usecase.LogoutUseCase
+	logoutAllUseCase    *usecase.LogoutAllUseCase
 	refreshTokenUseCase *usecase.RefreshTokenUseCase
 	verifyTokenUseCase  *usecase.VerifyTokenUseCase
 }
@@ -25,6 +35,7 @@ func NewAuthHandler(
 	registerUseCase *usecase.RegisterUserUseCase,
 	loginUseCase *usecase.LoginUseCase,
 	logoutUseCase *usecase.LogoutUseCase,
+	logoutAllUseCase *usecase.LogoutAllUseCase,
 	refreshTokenUseCase *usecase.RefreshTokenUseCase,
 	verifyTokenUseCase *usecase.VerifyTokenUseCase,
 ) *AuthHandler {
@@ -32,6 +43,7 @@ func NewAuthHandler(
 		registerUseCase:     registerUseCase,
 		loginUseCase:        loginUseCase,
 		logoutUseCase:       logoutUseCase,
+		logoutAllUseCase:    logoutAllUseCase,
 		refreshTokenUseCase: refreshTokenUseCase,
 		verifyTokenUseCase:  verifyTokenUseCase,
 	}
@@ -58,6 +70,14 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Resposta idêntica para emails novos e já cadastrados
+	if output.Concealed {
+		respondWithJSON(w, http.StatusAccepted, dto.SuccessResponse{
+			Message: "Registration received. Check your email to continue",
+		})
+		return
+	}
+
 	respondWithJSON(w, http.StatusCreated, dto.SuccessResponse{
 		Message: "User registered successfully",
 		Data: dto.UserDTO{
@@ -78,8 +98,10 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	input := usecase.LoginInput{
-		Email:    req.Email,
-		Password: req.Password,
+		Email:     req.Email,
+		Password:  req.Password,
+		IPAddress: clientIP(r),
+		UserAgent: userAgent(r),
 	}
 
 	output, err := h.loginUseCase.Execute(r.Context(), input)
@@ -88,6 +110,20 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	respondWithLogin(w, output)
+}
+
+// respondWithLogin responde com os tokens ou, se exigido, com o desafio de segundo fator
+func respondWithLogin(w http.ResponseWriter, output *usecase.LoginOutput) {
+	if output.MFARequired {
+		respondWithJSON(w, http.StatusOK, dto.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    output.MFAToken,
+			Methods:     output.MFAMethods,
+		})
+		return
+	}
+
 	respondWithJSON(w, http.StatusOK, dto.AuthResponse{
 		AccessToken:  output.AccessToken,
 		RefreshToken: output.RefreshToken,
@@ -100,7 +136,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// Logout handler
+// Logout handler - encerra apenas a sessão atual
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	// Extrair userID do contexto (colocado pelo middleware)
 	userID, ok := r.Context().Value("user_id").(string)
@@ -115,12 +151,27 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Refresh token opcional, para tokens emitidos sem sid
+	var req dto.LogoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	sessionID, _ := r.Context().Value("session_id").(string)
+	tokenID, _ := r.Context().Value("token_id").(string)
+	tokenExpiresAt, _ := r.Context().Value("token_expires_at").(time.Time)
+
 	input := usecase.LogoutInput{
-		UserID: userUUID,
+		UserID:         userUUID,
+		SessionID:      sessionID,
+		RefreshToken:   req.RefreshToken,
+		TokenID:        tokenID,
+		TokenExpiresAt: tokenExpiresAt,
 	}
 
 	if err := h.logoutUseCase.Execute(r.Context(), input); err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to logout")
+		handleUseCaseError(w, err)
 		return
 	}
 
@@ -129,6 +180,41 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// LogoutAll handler - encerra todas as sessões do usuário
+func (h *AuthHandler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	// Extrair userID do contexto (colocado pelo middleware)
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	// Dados do access token atual, para revogá-lo antes da expiração
+	tokenID, _ := r.Context().Value("token_id").(string)
+	tokenExpiresAt, _ := r.Context().Value("token_expires_at").(time.Time)
+
+	input := usecase.LogoutAllInput{
+		UserID:         userUUID,
+		TokenID:        tokenID,
+		TokenExpiresAt: tokenExpiresAt,
+	}
+
+	if err := h.logoutAllUseCase.Execute(r.Context(), input); err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Logged out from all sessions successfully",
+	})
+}
+
 // RefreshToken handler
 func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	var req dto.RefreshTokenRequest
@@ -139,6 +225,8 @@ func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 
 	input := usecase.RefreshTokenInput{
 		RefreshToken: req.RefreshToken,
+		IPAddress:    clientIP(r),
+		UserAgent:    userAgent(r),
 	}
 
 	output, err := h.refreshTokenUseCase.Execute(r.Context(), input)
@@ -178,12 +266,19 @@ func (h *AuthHandler) VerifyToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, dto.VerifyTokenResponse{
-		Valid:  output.Valid,
-		UserID: output.UserID.String(),
-		Email:  output.Email,
-		Role:   output.Role,
-	})
+	response := dto.VerifyTokenResponse{
+		Valid:       output.Valid,
+		SubjectType: output.SubjectType,
+		Email:       output.Email,
+		Role:        output.Role,
+		ClientID:    output.ClientID,
+		Scope:       output.Scope,
+	}
+	if output.UserID != uuid.Nil {
+		response.UserID = output.UserID.String()
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
 }
 
 // Helper functions
@@ -208,11 +303,69 @@ func respondWithError(w http.ResponseWriter, code int, message string) {
 	})
 }
 
+// currentUserID extrai o ID do usuário autenticado (colocado pelo middleware)
+func currentUserID(r *http.Request) (uuid.UUID, bool) {
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, false
+	}
+
+	return userUUID, true
+}
+
+// clientIP retorna o IP do cliente registrado em sessões e usado no bloqueio por IP.
+// Vem de middleware.ClientIP, a mesma fonte dos limites por IP: headers encaminhados
+// só valem de proxies confiáveis, para que o bloqueio não seja contornado trocando-os.
+func clientIP(r *http.Request) string {
+	return middleware.ClientIP(r)
+}
+
+// userAgent retorna o User-Agent limitado ao tamanho armazenado na sessão
+func userAgent(r *http.Request) string {
+	ua := r.UserAgent()
+	if len(ua) > maxUserAgentLength {
+		ua = strings.ToValidUTF8(ua[:maxUserAgentLength], "")
+	}
+	return ua
+}
+
 func handleUseCaseError(w http.ResponseWriter, err error) {
+	var policyErr *service.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		respondWithPasswordPolicyError(w, policyErr)
+		return
+	}
+
+	if validationErr, ok := service.AsValidationError(err); ok {
+		respondWithError(w, http.StatusBadRequest, validationErr.Error())
+		return
+	}
+
+	var rateLimitErr *pkgerrors.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		w.Header().Set("Retry-After", retryAfterSeconds(rateLimitErr.RetryAfter))
+		respondWithError(w, http.StatusTooManyRequests, err.Error())
+		return
+	}
+
 	switch {
 	case errors.Is(err, pkgerrors.ErrUserNotFound):
 		respondWithError(w, http.StatusNotFound, err.Error())
-	case errors.Is(err, pkgerrors.ErrUserAlreadyExists):
+	case errors.Is(err, pkgerrors.ErrInvalidRole):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, pkgerrors.ErrRoleNotAllowed):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, pkgerrors.ErrRegistrationDisabled):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, pkgerrors.ErrSelfModification):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, pkgerrors.ErrUserAlreadyExists),
+		errors.Is(err, pkgerrors.ErrDirectoryAccountConflict):
 		respondWithError(w, http.StatusConflict, err.Error())
 	case errors.Is(err, pkgerrors.ErrInvalidCredentials):
 		respondWithError(w, http.StatusUnauthorized, err.Error())
@@ -220,11 +373,88 @@ func handleUseCaseError(w http.ResponseWriter, err error) {
 		respondWithError(w, http.StatusUnauthorized, err.Error())
 	case errors.Is(err, pkgerrors.ErrExpiredToken):
 		respondWithError(w, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, pkgerrors.ErrTokenReused):
+		respondWithError(w, http.StatusUnauthorized, err.Error())
 	case errors.Is(err, pkgerrors.ErrTokenRevoked):
 		respondWithError(w, http.StatusUnauthorized, err.Error())
-	case errors.Is(err, pkgerrors.ErrUserInactive):
+	case errors.Is(err, pkgerrors.ErrSessionNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, pkgerrors.ErrInvitationNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, pkgerrors.ErrInvitationNotPending):
+		respondWithError(w, http.StatusGone, err.Error())
+	case errors.Is(err, pkgerrors.ErrResetTokenInvalid):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, pkgerrors.ErrIncorrectPassword),
+		errors.Is(err, pkgerrors.ErrPasswordManagedByDirectory):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, pkgerrors.ErrPasswordReused):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, pkgerrors.ErrPasswordTooLong):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, pkgerrors.ErrMFAAlreadyEnabled):
+		respondWithError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, pkgerrors.ErrMFANotEnabled):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, pkgerrors.ErrMFASetupRequired):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, pkgerrors.ErrInvalidMFACode):
+		respondWithError(w, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, pkgerrors.ErrMFAChallengeInvalid):
+		respondWithError(w, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, pkgerrors.ErrPasskeyNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, pkgerrors.ErrPasskeyAlreadyRegistered):
+		respondWithError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, pkgerrors.ErrPasskeyChallengeInvalid):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, pkgerrors.ErrPasskeyVerification):
+		respondWithError(w, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, pkgerrors.ErrPasskeyCloned):
+		respondWithError(w, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, pkgerrors.ErrOAuthClientNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, pkgerrors.ErrAuthorizationRequestInvalid):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, pkgerrors.ErrFederatedProviderNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, pkgerrors.ErrFederatedLoginInvalid):
+		respondWithError(w, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, pkgerrors.ErrIdentityProviderDown):
+		// O erro carrega detalhes da conexão com o provedor; registrar sem expor
+		log.Printf("Identity provider error: %v", err)
+		respondWithError(w, http.StatusBadGateway, pkgerrors.ErrIdentityProviderDown.Error())
+	case errors.Is(err, pkgerrors.ErrUserInactive),
+		errors.Is(err, pkgerrors.ErrDirectoryAccessDenied):
 		respondWithError(w, http.StatusForbidden, err.Error())
 	default:
 		respondWithError(w, http.StatusInternalServerError, "Internal server error")
 	}
 }
+
+// respondWithPasswordPolicyError devolve todos os motivos de rejeição da senha
+func respondWithPasswordPolicyError(w http.ResponseWriter, policyErr *service.PasswordPolicyError) {
+	reasons := make([]dto.PasswordReasonDTO, 0, len(policyErr.Violations))
+	for _, violation := range policyErr.Violations {
+		reasons = append(reasons, dto.PasswordReasonDTO{
+			Code:    violation.Code,
+			Message: violation.Message,
+		})
+	}
+
+	respondWithJSON(w, http.StatusBadRequest, dto.PasswordPolicyErrorResponse{
+		Error:    http.StatusText(http.StatusBadRequest),
+		Message:  policyErr.Error(),
+		Reasons:  reasons,
+		Strength: policyErr.Strength,
+	})
+}
+
+// retryAfterSeconds formata a espera para o header Retry-After (segundos, arredondados para cima)
+func retryAfterSeconds(d time.Duration) string {
+	seconds := int64(math.Ceil(d.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return strconv.FormatInt(seconds, 10)
+}
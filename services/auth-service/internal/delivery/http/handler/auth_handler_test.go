@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/service"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/mail"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/usecase"
+)
+
+// fakeUserRepository guarda usuários em memória; métodos não usados pelo cadastro
+// causam panic pela interface embutida
+type fakeUserRepository struct {
+	repository.UserRepository
+	users map[string]*entity.User
+}
+
+func (r *fakeUserRepository) Create(ctx context.Context, user *entity.User) error {
+	r.users[user.Email] = user
+	return nil
+}
+
+func (r *fakeUserRepository) EmailExists(ctx context.Context, email string) (bool, error) {
+	_, ok := r.users[email]
+	return ok, nil
+}
+
+type discardMailer struct{}
+
+func (discardMailer) Send(ctx context.Context, msg mail.Message) error { return nil }
+
+func newRegisterHandler(t *testing.T, concealExisting bool, users ...*entity.User) *AuthHandler {
+	t.Helper()
+
+	hasher, err := crypto.NewBcryptHasher(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &fakeUserRepository{users: make(map[string]*entity.User)}
+	for _, user := range users {
+		repo.users[user.Email] = user
+	}
+
+	registerUseCase := usecase.NewRegisterUserUseCase(
+		repo,
+		crypto.NewPasswordService(hasher),
+		service.NewValidationService(service.PasswordPolicy{MinLength: 8}),
+		discardMailer{},
+		true,
+		concealExisting,
+	)
+	return NewAuthHandler(registerUseCase, nil, nil, nil, nil, nil)
+}
+
+func register(h *AuthHandler, email string) *httptest.ResponseRecorder {
+	body := `{"email":"` + email + `","password":"correct-horse-battery-staple","name":"Test User"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.Register(rec, req)
+	return rec
+}
+
+func TestRegisterConcealedRespondsAcceptedForNewAndExistingEmails(t *testing.T) {
+	existing := entity.NewUser("taken@example.com", "hash", "Existing", entity.RoleViewer)
+	h := newRegisterHandler(t, true, existing)
+
+	created := register(h, "new@example.com")
+	duplicate := register(h, "taken@example.com")
+
+	for name, rec := range map[string]*httptest.ResponseRecorder{"new": created, "existing": duplicate} {
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("%s email: expected status 202, got %d: %s", name, rec.Code, rec.Body)
+		}
+		if strings.Contains(rec.Body.String(), "@example.com") {
+			t.Fatalf("%s email: response must not contain account data: %s", name, rec.Body)
+		}
+	}
+
+	// A resposta não pode distinguir um email já cadastrado
+	if created.Body.String() != duplicate.Body.String() {
+		t.Fatalf("responses differ:\nnew:      %s\nexisting: %s", created.Body, duplicate.Body)
+	}
+}
+
+func TestRegisterWithoutConcealmentReportsConflict(t *testing.T) {
+	existing := entity.NewUser("taken@example.com", "hash", "Existing", entity.RoleViewer)
+	h := newRegisterHandler(t, false, existing)
+
+	if rec := register(h, "new@example.com"); rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body)
+	}
+	if rec := register(h, "taken@example.com"); rec.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d: %s", rec.Code, rec.Body)
+	}
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/delivery/http/dto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/usecase"
+)
+
+// federationCookie guarda o vínculo entre o login federado e o navegador que o iniciou
+const federationCookie = "titanwatch_federation"
+
+type FederationHandler struct {
+	listProvidersUseCase *usecase.ListFederatedProvidersUseCase
+	startLoginUseCase    *usecase.StartFederatedLoginUseCase
+	completeLoginUseCase *usecase.CompleteFederatedLoginUseCase
+	exchangeLoginUseCase *usecase.ExchangeFederatedLoginUseCase
+	secureCookie         bool
+}
+
+func NewFederationHandler(
+	listProvidersUseCase *usecase.ListFederatedProvidersUseCase,
+	startLoginUseCase *usecase.StartFederatedLoginUseCase,
+	completeLoginUseCase *usecase.CompleteFederatedLoginUseCase,
+	exchangeLoginUseCase *usecase.ExchangeFederatedLoginUseCase,
+	secureCookie bool,
+) *FederationHandler {
+	return &FederationHandler{
+		listProvidersUseCase: listProvidersUseCase,
+		startLoginUseCase:    startLoginUseCase,
+		completeLoginUseCase: completeLoginUseCase,
+		exchangeLoginUseCase: exchangeLoginUseCase,
+		secureCookie:         secureCookie,
+	}
+}
+
+// ListProviders handler - provedores exibidos como opção na tela de login
+func (h *FederationHandler) ListProviders(w http.ResponseWriter, r *http.Request) {
+	providers, err := h.listProvidersUseCase.Execute(r.Context())
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	data := make([]dto.FederatedProviderDTO, 0, len(providers))
+	for _, provider := range providers {
+		data = append(data, dto.FederatedProviderDTO{
+			Name:        provider.Name,
+			DisplayName: provider.DisplayName,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Identity providers retrieved successfully",
+		Data:    data,
+	})
+}
+
+// Start handler - redireciona o navegador para o login no provedor
+func (h *FederationHandler) Start(w http.ResponseWriter, r *http.Request) {
+	output, err := h.startLoginUseCase.Execute(r.Context(), usecase.StartFederatedLoginInput{
+		Provider: chi.URLParam(r, "provider"),
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	h.setBindingCookie(w, output.Binding, 0)
+	http.Redirect(w, r, output.RedirectTo, http.StatusFound)
+}
+
+// Callback handler - retorno do provedor; redireciona para o frontend com o code ou o error
+func (h *FederationHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var binding string
+	if cookie, err := r.Cookie(federationCookie); err == nil {
+		binding = cookie.Value
+	}
+	h.setBindingCookie(w, "", -1)
+
+	output, err := h.completeLoginUseCase.Execute(r.Context(), usecase.CompleteFederatedLoginInput{
+		Provider: chi.URLParam(r, "provider"),
+		State:    query.Get("state"),
+		Code:     query.Get("code"),
+		Error:    query.Get("error"),
+		Binding:  binding,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	http.Redirect(w, r, output.RedirectTo, http.StatusFound)
+}
+
+// Exchange handler - troca o code do login federado pelos tokens (ou pelo desafio MFA)
+func (h *FederationHandler) Exchange(w http.ResponseWriter, r *http.Request) {
+	var req dto.FederatedExchangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	output, err := h.exchangeLoginUseCase.Execute(r.Context(), usecase.ExchangeFederatedLoginInput{
+		Code:      req.Code,
+		IPAddress: clientIP(r),
+		UserAgent: userAgent(r),
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithLogin(w, output)
+}
+
+// setBindingCookie grava (ou, com maxAge negativo, remove) o cookie de vínculo. SameSite
+// Lax mantém o cookie no redirecionamento de volta do provedor.
+func (h *FederationHandler) setBindingCookie(w http.ResponseWriter, value string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     federationCookie,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   h.secureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/delivery/http/dto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/usecase"
+)
+
+type InvitationHandler struct {
+	createInvitationUseCase *usecase.CreateInvitationUseCase
+	listInvitationsUseCase  *usecase.ListInvitationsUseCase
+	revokeInvitationUseCase *usecase.RevokeInvitationUseCase
+	acceptInvitationUseCase *usecase.AcceptInvitationUseCase
+}
+
+func NewInvitationHandler(
+	createInvitationUseCase *usecase.CreateInvitationUseCase,
+	listInvitationsUseCase *usecase.ListInvitationsUseCase,
+	revokeInvitationUseCase *usecase.RevokeInvitationUseCase,
+	acceptInvitationUseCase *usecase.AcceptInvitationUseCase,
+) *InvitationHandler {
+	return &InvitationHandler{
+		createInvitationUseCase: createInvitationUseCase,
+		listInvitationsUseCase:  listInvitationsUseCase,
+		revokeInvitationUseCase: revokeInvitationUseCase,
+		acceptInvitationUseCase: acceptInvitationUseCase,
+	}
+}
+
+// CreateInvitation handler - emite um convite de uso único (admin)
+func (h *InvitationHandler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := currentUserID(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.CreateInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	output, err := h.createInvitationUseCase.Execute(r.Context(), usecase.CreateInvitationInput{
+		InvitedBy: actorID,
+		Email:     req.Email,
+		Role:      entity.UserRole(req.Role),
+		ExpiresIn: time.Duration(req.ExpiresInHours) * time.Hour,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, dto.SuccessResponse{
+		Message: "Invitation created successfully",
+		Data: dto.CreateInvitationResponse{
+			Invitation: toInvitationDTO(output.Invitation),
+			Token:      output.Token,
+		},
+	})
+}
+
+// ListInvitations handler (admin)
+func (h *InvitationHandler) ListInvitations(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+
+	output, err := h.listInvitationsUseCase.Execute(r.Context(), usecase.ListInvitationsInput{
+		Page:    page,
+		PerPage: perPage,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	invitations := make([]dto.InvitationDTO, 0, len(output.Invitations))
+	for _, invitation := range output.Invitations {
+		invitations = append(invitations, toInvitationDTO(invitation))
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Invitations retrieved successfully",
+		Data: dto.InvitationListResponse{
+			Invitations: invitations,
+			Page:        output.Page,
+			PerPage:     output.PerPage,
+			Total:       output.Total,
+		},
+	})
+}
+
+// RevokeInvitation handler (admin)
+func (h *InvitationHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	invitationID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid invitation ID")
+		return
+	}
+
+	err = h.revokeInvitationUseCase.Execute(r.Context(), usecase.RevokeInvitationInput{
+		InvitationID: invitationID,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Invitation revoked successfully",
+	})
+}
+
+// AcceptInvitation handler - rota pública; o convidado define nome e senha
+func (h *InvitationHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	var req dto.AcceptInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	output, err := h.acceptInvitationUseCase.Execute(r.Context(), usecase.AcceptInvitationInput{
+		Token:    chi.URLParam(r, "token"),
+		Name:     req.Name,
+		Password: req.Password,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, dto.SuccessResponse{
+		Message: "Invitation accepted successfully",
+		Data: dto.UserDTO{
+			ID:    output.UserID,
+			Email: output.Email,
+			Name:  output.Name,
+			Role:  output.Role,
+		},
+	})
+}
+
+func toInvitationDTO(invitation usecase.InvitationOutput) dto.InvitationDTO {
+	return dto.InvitationDTO{
+		ID:         invitation.ID,
+		Email:      invitation.Email,
+		Role:       invitation.Role,
+		Status:     invitation.Status,
+		InvitedBy:  invitation.InvitedBy,
+		ExpiresAt:  invitation.ExpiresAt,
+		CreatedAt:  invitation.CreatedAt,
+		AcceptedAt: invitation.AcceptedAt,
+	}
+}
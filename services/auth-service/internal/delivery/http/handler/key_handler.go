@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/delivery/http/dto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/usecase"
+)
+
+type KeyHandler struct {
+	jwtService       *crypto.JWTService
+	listKeysUseCase  *usecase.ListSigningKeysUseCase
+	rotateKeyUseCase *usecase.RotateSigningKeyUseCase
+}
+
+func NewKeyHandler(
+	jwtService *crypto.JWTService,
+	listKeysUseCase *usecase.ListSigningKeysUseCase,
+	rotateKeyUseCase *usecase.RotateSigningKeyUseCase,
+) *KeyHandler {
+	return &KeyHandler{
+		jwtService:       jwtService,
+		listKeysUseCase:  listKeysUseCase,
+		rotateKeyUseCase: rotateKeyUseCase,
+	}
+}
+
+// JWKS publica as chaves públicas para verificação offline dos tokens
+func (h *KeyHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	respondWithJSON(w, http.StatusOK, h.jwtService.JWKS())
+}
+
+// ListKeys handler
+func (h *KeyHandler) ListKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.listKeysUseCase.Execute(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to list signing keys")
+		return
+	}
+
+	data := make([]dto.SigningKeyDTO, 0, len(keys))
+	for _, key := range keys {
+		data = append(data, toSigningKeyDTO(key))
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Signing keys retrieved successfully",
+		Data:    data,
+	})
+}
+
+// RotateKey handler
+func (h *KeyHandler) RotateKey(w http.ResponseWriter, r *http.Request) {
+	var req dto.RotateKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	output, err := h.rotateKeyUseCase.Execute(r.Context(), usecase.RotateSigningKeyInput{
+		Algorithm: req.Algorithm,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, crypto.ErrKeyRotationUnsupported):
+			respondWithError(w, http.StatusConflict, crypto.ErrKeyRotationUnsupported.Error())
+		case errors.Is(err, crypto.ErrUnsupportedKey):
+			respondWithError(w, http.StatusBadRequest, crypto.ErrUnsupportedKey.Error())
+		default:
+			respondWithError(w, http.StatusInternalServerError, "Failed to rotate signing key")
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, dto.SuccessResponse{
+		Message: "Signing key rotated successfully",
+		Data:    toSigningKeyDTO(*output),
+	})
+}
+
+func toSigningKeyDTO(key usecase.SigningKeyOutput) dto.SigningKeyDTO {
+	return dto.SigningKeyDTO{
+		KeyID:     key.KeyID,
+		Algorithm: key.Algorithm,
+		NotBefore: key.NotBefore,
+		RetireAt:  key.RetireAt,
+		Active:    key.Active,
+	}
+}
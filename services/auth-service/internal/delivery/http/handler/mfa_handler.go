@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/delivery/http/dto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/usecase"
+)
+
+type MFAHandler struct {
+	setupTOTPUseCase        *usecase.SetupTOTPUseCase
+	enableTOTPUseCase       *usecase.EnableTOTPUseCase
+	disableTOTPUseCase      *usecase.DisableTOTPUseCase
+	completeMFALoginUseCase *usecase.CompleteMFALoginUseCase
+}
+
+func NewMFAHandler(
+	setupTOTPUseCase *usecase.SetupTOTPUseCase,
+	enableTOTPUseCase *usecase.EnableTOTPUseCase,
+	disableTOTPUseCase *usecase.DisableTOTPUseCase,
+	completeMFALoginUseCase *usecase.CompleteMFALoginUseCase,
+) *MFAHandler {
+	return &MFAHandler{
+		setupTOTPUseCase:        setupTOTPUseCase,
+		enableTOTPUseCase:       enableTOTPUseCase,
+		disableTOTPUseCase:      disableTOTPUseCase,
+		completeMFALoginUseCase: completeMFALoginUseCase,
+	}
+}
+
+// SetupTOTP handler - gera o segredo e a URI otpauth para o aplicativo autenticador
+func (h *MFAHandler) SetupTOTP(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	output, err := h.setupTOTPUseCase.Execute(r.Context(), usecase.SetupTOTPInput{UserID: userID})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Scan the URI with your authenticator app and confirm with a code",
+		Data: dto.TOTPSetupResponse{
+			Secret:     output.Secret,
+			OTPAuthURI: output.OTPAuthURI,
+		},
+	})
+}
+
+// VerifyTOTP handler - confirma o TOTP e retorna os códigos de recuperação
+func (h *MFAHandler) VerifyTOTP(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.TOTPVerifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	output, err := h.enableTOTPUseCase.Execute(r.Context(), usecase.EnableTOTPInput{
+		UserID: userID,
+		Code:   req.Code,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Two-factor authentication enabled",
+		Data: dto.TOTPVerifyResponse{
+			RecoveryCodes: output.RecoveryCodes,
+		},
+	})
+}
+
+// DisableTOTP handler
+func (h *MFAHandler) DisableTOTP(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.TOTPDisableRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	err := h.disableTOTPUseCase.Execute(r.Context(), usecase.DisableTOTPInput{
+		UserID:   userID,
+		Password: req.Password,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Two-factor authentication disabled",
+	})
+}
+
+// LoginMFA handler - troca o desafio de MFA por access/refresh tokens
+func (h *MFAHandler) LoginMFA(w http.ResponseWriter, r *http.Request) {
+	var req dto.MFALoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	output, err := h.completeMFALoginUseCase.Execute(r.Context(), usecase.CompleteMFALoginInput{
+		MFAToken:     req.MFAToken,
+		Code:         req.Code,
+		RecoveryCode: req.RecoveryCode,
+		IPAddress:    clientIP(r),
+		UserAgent:    userAgent(r),
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithLogin(w, output)
+}
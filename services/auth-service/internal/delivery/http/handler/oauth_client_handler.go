@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/delivery/http/dto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/usecase"
+)
+
+type OAuthClientHandler struct {
+	createOAuthClientUseCase *usecase.CreateOAuthClientUseCase
+	listOAuthClientsUseCase  *usecase.ListOAuthClientsUseCase
+	deleteOAuthClientUseCase *usecase.DeleteOAuthClientUseCase
+}
+
+func NewOAuthClientHandler(
+	createOAuthClientUseCase *usecase.CreateOAuthClientUseCase,
+	listOAuthClientsUseCase *usecase.ListOAuthClientsUseCase,
+	deleteOAuthClientUseCase *usecase.DeleteOAuthClientUseCase,
+) *OAuthClientHandler {
+	return &OAuthClientHandler{
+		createOAuthClientUseCase: createOAuthClientUseCase,
+		listOAuthClientsUseCase:  listOAuthClientsUseCase,
+		deleteOAuthClientUseCase: deleteOAuthClientUseCase,
+	}
+}
+
+// CreateClient handler - registra um cliente OAuth
+func (h *OAuthClientHandler) CreateClient(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateOAuthClientRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	output, err := h.createOAuthClientUseCase.Execute(r.Context(), usecase.CreateOAuthClientInput{
+		Name:                   req.Name,
+		RedirectURIs:           req.RedirectURIs,
+		PostLogoutRedirectURIs: req.PostLogoutRedirectURIs,
+		Scopes:                 req.Scopes,
+		GrantTypes:             req.GrantTypes,
+		AccessTokenTTL:         time.Duration(req.AccessTokenTTL) * time.Second,
+		Confidential:           req.Confidential,
+		SkipConsent:            req.SkipConsent,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, dto.SuccessResponse{
+		Message: "OAuth client created successfully",
+		Data: dto.CreateOAuthClientResponse{
+			Client:       toOAuthClientDTO(output.Client),
+			ClientSecret: output.ClientSecret,
+		},
+	})
+}
+
+// ListClients handler
+func (h *OAuthClientHandler) ListClients(w http.ResponseWriter, r *http.Request) {
+	output, err := h.listOAuthClientsUseCase.Execute(r.Context())
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	clients := make([]dto.OAuthClientDTO, 0, len(output))
+	for _, client := range output {
+		clients = append(clients, toOAuthClientDTO(client))
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "OAuth clients retrieved successfully",
+		Data:    clients,
+	})
+}
+
+// DeleteClient handler
+func (h *OAuthClientHandler) DeleteClient(w http.ResponseWriter, r *http.Request) {
+	clientID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid client ID")
+		return
+	}
+
+	if err := h.deleteOAuthClientUseCase.Execute(r.Context(), usecase.DeleteOAuthClientInput{
+		ClientID: clientID,
+	}); err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "OAuth client deleted successfully",
+	})
+}
+
+func toOAuthClientDTO(client usecase.OAuthClientOutput) dto.OAuthClientDTO {
+	return dto.OAuthClientDTO{
+		ClientID:               client.ClientID,
+		Name:                   client.Name,
+		Confidential:           client.Confidential,
+		RedirectURIs:           client.RedirectURIs,
+		PostLogoutRedirectURIs: client.PostLogoutRedirectURIs,
+		Scopes:                 client.Scopes,
+		GrantTypes:             client.GrantTypes,
+		AccessTokenTTL:         int(client.AccessTokenTTL.Seconds()),
+		SkipConsent:            client.SkipConsent,
+		IsActive:               client.IsActive,
+		CreatedAt:              client.CreatedAt,
+	}
+}
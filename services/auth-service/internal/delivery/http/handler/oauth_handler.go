@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/delivery/http/dto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/usecase"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+// maxTokenRequestSize limita o corpo form-urlencoded dos endpoints OAuth
+const maxTokenRequestSize = 16 << 10
+
+type OAuthHandler struct {
+	authorizeUseCase               *usecase.AuthorizeUseCase
+	getAuthorizationRequestUseCase *usecase.GetAuthorizationRequestUseCase
+	completeAuthorizationUseCase   *usecase.CompleteAuthorizationUseCase
+	oauthTokenUseCase              *usecase.OAuthTokenUseCase
+	introspectTokenUseCase         *usecase.IntrospectTokenUseCase
+	revokeOAuthTokenUseCase        *usecase.RevokeOAuthTokenUseCase
+}
+
+func NewOAuthHandler(
+	authorizeUseCase *usecase.AuthorizeUseCase,
+	getAuthorizationRequestUseCase *usecase.GetAuthorizationRequestUseCase,
+	completeAuthorizationUseCase *usecase.CompleteAuthorizationUseCase,
+	oauthTokenUseCase *usecase.OAuthTokenUseCase,
+	introspectTokenUseCase *usecase.IntrospectTokenUseCase,
+	revokeOAuthTokenUseCase *usecase.RevokeOAuthTokenUseCase,
+) *OAuthHandler {
+	return &OAuthHandler{
+		authorizeUseCase:               authorizeUseCase,
+		getAuthorizationRequestUseCase: getAuthorizationRequestUseCase,
+		completeAuthorizationUseCase:   completeAuthorizationUseCase,
+		oauthTokenUseCase:              oauthTokenUseCase,
+		introspectTokenUseCase:         introspectTokenUseCase,
+		revokeOAuthTokenUseCase:        revokeOAuthTokenUseCase,
+	}
+}
+
+// Authorize handler - authorization endpoint. Valida o pedido e redireciona o
+// navegador para o login e consentimento no frontend.
+func (h *OAuthHandler) Authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	output, err := h.authorizeUseCase.Execute(r.Context(), usecase.AuthorizeInput{
+		ResponseType:        query.Get("response_type"),
+		ClientID:            query.Get("client_id"),
+		RedirectURI:         query.Get("redirect_uri"),
+		Scope:               query.Get("scope"),
+		State:               query.Get("state"),
+		Nonce:               query.Get("nonce"),
+		CodeChallenge:       query.Get("code_challenge"),
+		CodeChallengeMethod: query.Get("code_challenge_method"),
+	})
+	if err != nil {
+		var redirectErr *usecase.AuthorizationRedirectError
+		if errors.As(err, &redirectErr) {
+			http.Redirect(w, r, redirectErr.Location(), http.StatusFound)
+			return
+		}
+		respondWithOAuthError(w, err, false)
+		return
+	}
+
+	http.Redirect(w, r, output.RedirectTo, http.StatusFound)
+}
+
+// GetAuthorizationRequest handler - dados do pedido para a tela de consentimento
+func (h *OAuthHandler) GetAuthorizationRequest(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	output, err := h.getAuthorizationRequestUseCase.Execute(r.Context(), usecase.GetAuthorizationRequestInput{
+		RequestID: chi.URLParam(r, "id"),
+		UserID:    userID,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Authorization request retrieved successfully",
+		Data: dto.AuthorizationRequestResponse{
+			ClientID:        output.ClientID,
+			ClientName:      output.ClientName,
+			Scopes:          output.Scopes,
+			ConsentRequired: output.ConsentRequired,
+		},
+	})
+}
+
+// ApproveAuthorization handler - o usuário autoriza o cliente
+func (h *OAuthHandler) ApproveAuthorization(w http.ResponseWriter, r *http.Request) {
+	h.completeAuthorization(w, r, true)
+}
+
+// DenyAuthorization handler - o usuário nega o acesso ao cliente
+func (h *OAuthHandler) DenyAuthorization(w http.ResponseWriter, r *http.Request) {
+	h.completeAuthorization(w, r, false)
+}
+
+func (h *OAuthHandler) completeAuthorization(w http.ResponseWriter, r *http.Request, approve bool) {
+	userID, ok := currentUserID(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	sessionID, _ := r.Context().Value("session_id").(string)
+
+	output, err := h.completeAuthorizationUseCase.Execute(r.Context(), usecase.CompleteAuthorizationInput{
+		RequestID: chi.URLParam(r, "id"),
+		UserID:    userID,
+		SessionID: sessionID,
+		Approve:   approve,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Authorization completed",
+		Data: dto.AuthorizationDecisionResponse{
+			RedirectTo: output.RedirectTo,
+		},
+	})
+}
+
+// Token handler - token endpoint (application/x-www-form-urlencoded)
+func (h *OAuthHandler) Token(w http.ResponseWriter, r *http.Request) {
+	credentials, err := parseClientRequest(w, r)
+	if err != nil {
+		respondWithOAuthError(w, err, false)
+		return
+	}
+
+	output, err := h.oauthTokenUseCase.Execute(r.Context(), usecase.OAuthTokenInput{
+		GrantType:    r.PostForm.Get("grant_type"),
+		ClientID:     credentials.clientID,
+		ClientSecret: credentials.clientSecret,
+		Code:         r.PostForm.Get("code"),
+		RedirectURI:  r.PostForm.Get("redirect_uri"),
+		CodeVerifier: r.PostForm.Get("code_verifier"),
+		RefreshToken: r.PostForm.Get("refresh_token"),
+		Scope:        r.PostForm.Get("scope"),
+		IPAddress:    clientIP(r),
+		UserAgent:    userAgent(r),
+	})
+	if err != nil {
+		respondWithOAuthError(w, err, credentials.basicAuth)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	respondWithJSON(w, http.StatusOK, dto.OAuthTokenResponse{
+		AccessToken:  output.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    output.ExpiresIn,
+		RefreshToken: output.RefreshToken,
+		Scope:        output.Scope,
+		IDToken:      output.IDToken,
+	})
+}
+
+// IntrospectToken handler - introspecção de tokens (RFC 7662)
+func (h *OAuthHandler) IntrospectToken(w http.ResponseWriter, r *http.Request) {
+	credentials, err := parseClientRequest(w, r)
+	if err != nil {
+		respondWithOAuthError(w, err, false)
+		return
+	}
+
+	output, err := h.introspectTokenUseCase.Execute(r.Context(), usecase.IntrospectTokenInput{
+		ClientID:      credentials.clientID,
+		ClientSecret:  credentials.clientSecret,
+		Token:         r.PostForm.Get("token"),
+		TokenTypeHint: r.PostForm.Get("token_type_hint"),
+	})
+	if err != nil {
+		respondWithOAuthError(w, err, credentials.basicAuth)
+		return
+	}
+
+	response := dto.IntrospectionResponse{Active: output.Active}
+	if output.Active {
+		response.TokenType = output.TokenType
+		response.SubjectType = output.SubjectType
+		response.Subject = output.Subject
+		response.Username = output.Username
+		response.ClientID = output.ClientID
+		response.Scope = output.Scope
+		response.TokenID = output.TokenID
+		response.ExpiresAt = output.ExpiresAt.Unix()
+		if !output.IssuedAt.IsZero() {
+			response.IssuedAt = output.IssuedAt.Unix()
+		}
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// RevokeToken handler - revogação de tokens (RFC 7009); tokens desconhecidos também recebem 200
+func (h *OAuthHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	credentials, err := parseClientRequest(w, r)
+	if err != nil {
+		respondWithOAuthError(w, err, false)
+		return
+	}
+
+	if err := h.revokeOAuthTokenUseCase.Execute(r.Context(), usecase.RevokeOAuthTokenInput{
+		ClientID:      credentials.clientID,
+		ClientSecret:  credentials.clientSecret,
+		Token:         r.PostForm.Get("token"),
+		TokenTypeHint: r.PostForm.Get("token_type_hint"),
+	}); err != nil {
+		respondWithOAuthError(w, err, credentials.basicAuth)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// clientCredentials são as credenciais apresentadas pelo cliente OAuth
+type clientCredentials struct {
+	clientID     string
+	clientSecret string
+	basicAuth    bool
+}
+
+// parseClientRequest lê o corpo form-urlencoded dos endpoints autenticados por
+// cliente e extrai as credenciais: HTTP Basic (client_secret_basic) ou no corpo
+// (client_secret_post), nunca os dois
+func parseClientRequest(w http.ResponseWriter, r *http.Request) (clientCredentials, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxTokenRequestSize)
+	if err := r.ParseForm(); err != nil {
+		return clientCredentials{}, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidRequest, "corpo da requisição inválido")
+	}
+
+	clientID, clientSecret, basicAuth := r.BasicAuth()
+	if !basicAuth {
+		return clientCredentials{
+			clientID:     r.PostForm.Get("client_id"),
+			clientSecret: r.PostForm.Get("client_secret"),
+		}, nil
+	}
+
+	if r.PostForm.Get("client_secret") != "" {
+		return clientCredentials{}, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidRequest, "use apenas um método de autenticação do cliente")
+	}
+
+	// Credenciais do Basic são codificadas como form-urlencoded (RFC 6749, seção 2.3.1)
+	clientID, _ = url.QueryUnescape(clientID)
+	clientSecret, _ = url.QueryUnescape(clientSecret)
+
+	return clientCredentials{clientID: clientID, clientSecret: clientSecret, basicAuth: true}, nil
+}
+
+// respondWithOAuthError responde no formato de erro do OAuth 2.0. Falhas de
+// autenticação do cliente via Basic exigem 401 com WWW-Authenticate.
+func respondWithOAuthError(w http.ResponseWriter, err error, basicAuth bool) {
+	var oauthErr *pkgerrors.OAuthError
+	if !errors.As(err, &oauthErr) {
+		log.Printf("OAuth request failed: %v", err)
+		w.Header().Set("Cache-Control", "no-store")
+		respondWithJSON(w, http.StatusInternalServerError, dto.OAuthErrorResponse{
+			Error: "server_error",
+		})
+		return
+	}
+
+	status := http.StatusBadRequest
+	if oauthErr.Code == pkgerrors.OAuthInvalidClient {
+		status = http.StatusUnauthorized
+		if basicAuth {
+			w.Header().Set("WWW-Authenticate", `Basic realm="titanwatch"`)
+		}
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	respondWithJSON(w, status, dto.OAuthErrorResponse{
+		Error:            oauthErr.Code,
+		ErrorDescription: oauthErr.Description,
+	})
+}
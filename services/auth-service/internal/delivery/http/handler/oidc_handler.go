@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/delivery/http/dto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/usecase"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type OIDCHandler struct {
+	issuer             string
+	jwtService         *crypto.JWTService
+	getUserInfoUseCase *usecase.GetUserInfoUseCase
+	endSessionUseCase  *usecase.EndSessionUseCase
+}
+
+func NewOIDCHandler(
+	issuer string,
+	jwtService *crypto.JWTService,
+	getUserInfoUseCase *usecase.GetUserInfoUseCase,
+	endSessionUseCase *usecase.EndSessionUseCase,
+) *OIDCHandler {
+	return &OIDCHandler{
+		issuer:             issuer,
+		jwtService:         jwtService,
+		getUserInfoUseCase: getUserInfoUseCase,
+		endSessionUseCase:  endSessionUseCase,
+	}
+}
+
+// Discovery publica o documento de configuração do OpenID Connect
+func (h *OIDCHandler) Discovery(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	respondWithJSON(w, http.StatusOK, dto.OpenIDConfigurationResponse{
+		Issuer:                 h.issuer,
+		AuthorizationEndpoint:  h.issuer + "/oauth/authorize",
+		TokenEndpoint:          h.issuer + "/oauth/token",
+		UserInfoEndpoint:       h.issuer + "/oauth/userinfo",
+		JWKSURI:                h.issuer + "/.well-known/jwks.json",
+		EndSessionEndpoint:     h.issuer + "/oauth/logout",
+		ScopesSupported:        []string{entity.ScopeOpenID, entity.ScopeProfile, entity.ScopeEmail},
+		ResponseTypesSupported: []string{"code"},
+		GrantTypesSupported: []string{
+			entity.GrantTypeAuthorizationCode,
+			entity.GrantTypeRefreshToken,
+			entity.GrantTypeClientCredentials,
+		},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{h.jwtService.KeyRing().Active().Method.Alg()},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{crypto.PKCEMethodS256},
+		ClaimsSupported: []string{
+			"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce", "sid",
+			"name", "email", "role", "updated_at",
+		},
+	})
+}
+
+// UserInfo handler - claims do usuário liberadas pelos escopos do access token
+func (h *OIDCHandler) UserInfo(w http.ResponseWriter, r *http.Request) {
+	// Tokens de serviço (client_credentials) não têm usuário
+	userID, ok := currentUserID(r)
+	if !ok {
+		respondWithBearerError(w, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidToken, "o access token não pertence a um usuário"))
+		return
+	}
+
+	scope, _ := r.Context().Value("scope").(string)
+
+	output, err := h.getUserInfoUseCase.Execute(r.Context(), usecase.GetUserInfoInput{
+		UserID: userID,
+		Scope:  scope,
+	})
+	if err != nil {
+		var oauthErr *pkgerrors.OAuthError
+		if errors.As(err, &oauthErr) {
+			respondWithBearerError(w, oauthErr)
+			return
+		}
+		handleUseCaseError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	respondWithJSON(w, http.StatusOK, dto.UserInfoResponse{
+		Subject:   output.Subject,
+		Name:      output.Name,
+		Email:     output.Email,
+		Role:      output.Role,
+		UpdatedAt: output.UpdatedAt,
+	})
+}
+
+// EndSession handler - end_session_endpoint (GET ou POST form-urlencoded)
+func (h *OIDCHandler) EndSession(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxTokenRequestSize)
+	if err := r.ParseForm(); err != nil {
+		respondWithOAuthError(w, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidRequest, "corpo da requisição inválido"), false)
+		return
+	}
+
+	output, err := h.endSessionUseCase.Execute(r.Context(), usecase.EndSessionInput{
+		IDTokenHint:           r.Form.Get("id_token_hint"),
+		ClientID:              r.Form.Get("client_id"),
+		PostLogoutRedirectURI: r.Form.Get("post_logout_redirect_uri"),
+		State:                 r.Form.Get("state"),
+	})
+	if err != nil {
+		respondWithOAuthError(w, err, false)
+		return
+	}
+
+	if output.RedirectTo != "" {
+		http.Redirect(w, r, output.RedirectTo, http.StatusFound)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Logged out successfully",
+	})
+}
+
+// respondWithBearerError responde erros de recursos protegidos (RFC 6750, seção 3)
+func respondWithBearerError(w http.ResponseWriter, oauthErr *pkgerrors.OAuthError) {
+	status := http.StatusUnauthorized
+	if oauthErr.Code == pkgerrors.OAuthInsufficientScope {
+		status = http.StatusForbidden
+	}
+
+	// A descrição fica só no corpo: o header aceita apenas ASCII
+	w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error=%q`, oauthErr.Code))
+	respondWithJSON(w, status, dto.OAuthErrorResponse{
+		Error:            oauthErr.Code,
+		ErrorDescription: oauthErr.Description,
+	})
+}
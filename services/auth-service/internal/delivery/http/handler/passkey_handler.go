@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/delivery/http/dto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/usecase"
+)
+
+type PasskeyHandler struct {
+	beginRegistrationUseCase  *usecase.BeginPasskeyRegistrationUseCase
+	finishRegistrationUseCase *usecase.FinishPasskeyRegistrationUseCase
+	listPasskeysUseCase       *usecase.ListPasskeysUseCase
+	deletePasskeyUseCase      *usecase.DeletePasskeyUseCase
+	beginLoginUseCase         *usecase.BeginPasskeyLoginUseCase
+	finishLoginUseCase        *usecase.FinishPasskeyLoginUseCase
+}
+
+func NewPasskeyHandler(
+	beginRegistrationUseCase *usecase.BeginPasskeyRegistrationUseCase,
+	finishRegistrationUseCase *usecase.FinishPasskeyRegistrationUseCase,
+	listPasskeysUseCase *usecase.ListPasskeysUseCase,
+	deletePasskeyUseCase *usecase.DeletePasskeyUseCase,
+	beginLoginUseCase *usecase.BeginPasskeyLoginUseCase,
+	finishLoginUseCase *usecase.FinishPasskeyLoginUseCase,
+) *PasskeyHandler {
+	return &PasskeyHandler{
+		beginRegistrationUseCase:  beginRegistrationUseCase,
+		finishRegistrationUseCase: finishRegistrationUseCase,
+		listPasskeysUseCase:       listPasskeysUseCase,
+		deletePasskeyUseCase:      deletePasskeyUseCase,
+		beginLoginUseCase:         beginLoginUseCase,
+		finishLoginUseCase:        finishLoginUseCase,
+	}
+}
+
+// BeginRegistration handler - gera o desafio para registrar uma nova passkey
+func (h *PasskeyHandler) BeginRegistration(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	output, err := h.beginRegistrationUseCase.Execute(r.Context(), usecase.BeginPasskeyRegistrationInput{UserID: userID})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Pass the options to navigator.credentials.create",
+		Data:    dto.PasskeyOptionsResponse{Options: output.Options},
+	})
+}
+
+// FinishRegistration handler - valida a atestação e salva a passkey
+func (h *PasskeyHandler) FinishRegistration(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.PasskeyRegisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Credential) == 0 {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	output, err := h.finishRegistrationUseCase.Execute(r.Context(), usecase.FinishPasskeyRegistrationInput{
+		UserID:     userID,
+		Name:       req.Name,
+		Credential: req.Credential,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, dto.SuccessResponse{
+		Message: "Passkey registered successfully",
+		Data:    toPasskeyDTO(*output),
+	})
+}
+
+// ListPasskeys handler - passkeys do usuário autenticado
+func (h *PasskeyHandler) ListPasskeys(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	passkeys, err := h.listPasskeysUseCase.Execute(r.Context(), usecase.ListPasskeysInput{UserID: userID})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	data := make([]dto.PasskeyDTO, 0, len(passkeys))
+	for _, passkey := range passkeys {
+		data = append(data, toPasskeyDTO(passkey))
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Passkeys retrieved successfully",
+		Data:    data,
+	})
+}
+
+// DeletePasskey handler
+func (h *PasskeyHandler) DeletePasskey(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	passkeyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid passkey ID")
+		return
+	}
+
+	var req dto.PasskeyDeleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.deletePasskeyUseCase.Execute(r.Context(), usecase.DeletePasskeyInput{
+		UserID:    userID,
+		PasskeyID: passkeyID,
+		Password:  req.Password,
+	}); err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Passkey deleted successfully",
+	})
+}
+
+// BeginLogin handler - gera o desafio de login com passkey (corpo opcional)
+func (h *PasskeyHandler) BeginLogin(w http.ResponseWriter, r *http.Request) {
+	var req dto.PasskeyLoginBeginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	output, err := h.beginLoginUseCase.Execute(r.Context(), usecase.BeginPasskeyLoginInput{
+		MFAToken: req.MFAToken,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Pass the options to navigator.credentials.get",
+		Data: dto.PasskeyOptionsResponse{
+			ChallengeToken: output.ChallengeToken,
+			Options:        output.Options,
+		},
+	})
+}
+
+// FinishLogin handler - troca a asserção da passkey por access/refresh tokens
+func (h *PasskeyHandler) FinishLogin(w http.ResponseWriter, r *http.Request) {
+	var req dto.PasskeyLoginFinishRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Credential) == 0 {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	output, err := h.finishLoginUseCase.Execute(r.Context(), usecase.FinishPasskeyLoginInput{
+		ChallengeToken: req.ChallengeToken,
+		MFAToken:       req.MFAToken,
+		Credential:     req.Credential,
+		IPAddress:      clientIP(r),
+		UserAgent:      userAgent(r),
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithLogin(w, output)
+}
+
+func toPasskeyDTO(passkey usecase.PasskeyOutput) dto.PasskeyDTO {
+	transports := passkey.Transports
+	if transports == nil {
+		transports = []string{}
+	}
+
+	return dto.PasskeyDTO{
+		ID:         passkey.ID.String(),
+		Name:       passkey.Name,
+		Transports: transports,
+		Synced:     passkey.Synced,
+		CreatedAt:  passkey.CreatedAt,
+		LastUsedAt: passkey.LastUsedAt,
+	}
+}
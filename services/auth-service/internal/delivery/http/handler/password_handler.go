@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/delivery/http/dto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/usecase"
+)
+
+type PasswordHandler struct {
+	requestPasswordResetUseCase *usecase.RequestPasswordResetUseCase
+	resetPasswordUseCase        *usecase.ResetPasswordUseCase
+	changePasswordUseCase       *usecase.ChangePasswordUseCase
+}
+
+func NewPasswordHandler(
+	requestPasswordResetUseCase *usecase.RequestPasswordResetUseCase,
+	resetPasswordUseCase *usecase.ResetPasswordUseCase,
+	changePasswordUseCase *usecase.ChangePasswordUseCase,
+) *PasswordHandler {
+	return &PasswordHandler{
+		requestPasswordResetUseCase: requestPasswordResetUseCase,
+		resetPasswordUseCase:        resetPasswordUseCase,
+		changePasswordUseCase:       changePasswordUseCase,
+	}
+}
+
+// ForgotPassword handler - sempre responde 202 para não revelar emails cadastrados
+func (h *PasswordHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req dto.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	err := h.requestPasswordResetUseCase.Execute(r.Context(), usecase.RequestPasswordResetInput{
+		Email: req.Email,
+	})
+	if err != nil {
+		log.Printf("Password reset request failed: %v", err)
+	}
+
+	respondWithJSON(w, http.StatusAccepted, dto.SuccessResponse{
+		Message: "If the email is registered, a reset link has been sent",
+	})
+}
+
+// ResetPassword handler
+func (h *PasswordHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req dto.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	err := h.resetPasswordUseCase.Execute(r.Context(), usecase.ResetPasswordInput{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Password reset successfully",
+	})
+}
+
+// ChangePassword handler - troca de senha do usuário autenticado
+func (h *PasswordHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	sessionID, _ := r.Context().Value("session_id").(string)
+
+	output, err := h.changePasswordUseCase.Execute(r.Context(), usecase.ChangePasswordInput{
+		UserID:              userID,
+		CurrentSessionID:    sessionID,
+		CurrentPassword:     req.CurrentPassword,
+		NewPassword:         req.NewPassword,
+		RevokeOtherSessions: req.RevokeOtherSessions,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Password changed successfully",
+		Data: dto.ChangePasswordResponse{
+			RevokedSessions: output.RevokedSessions,
+		},
+	})
+}
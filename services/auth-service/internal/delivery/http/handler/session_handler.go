@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/delivery/http/dto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/usecase"
+)
+
+type SessionHandler struct {
+	listSessionsUseCase  *usecase.ListSessionsUseCase
+	revokeSessionUseCase *usecase.RevokeSessionUseCase
+}
+
+func NewSessionHandler(
+	listSessionsUseCase *usecase.ListSessionsUseCase,
+	revokeSessionUseCase *usecase.RevokeSessionUseCase,
+) *SessionHandler {
+	return &SessionHandler{
+		listSessionsUseCase:  listSessionsUseCase,
+		revokeSessionUseCase: revokeSessionUseCase,
+	}
+}
+
+// ListSessions handler - sessões ativas do usuário autenticado
+func (h *SessionHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	sessionID, _ := r.Context().Value("session_id").(string)
+
+	sessions, err := h.listSessionsUseCase.Execute(r.Context(), usecase.ListSessionsInput{
+		UserID:           userID,
+		CurrentSessionID: sessionID,
+	})
+	if err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	data := make([]dto.SessionDTO, 0, len(sessions))
+	for _, session := range sessions {
+		data = append(data, dto.SessionDTO{
+			ID:         session.ID.String(),
+			IPAddress:  session.IPAddress,
+			UserAgent:  session.UserAgent,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.Current,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Sessions retrieved successfully",
+		Data:    data,
+	})
+}
+
+// RevokeSession handler - revoga uma sessão do usuário autenticado
+func (h *SessionHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(r)
+	if !ok {
+		respondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	sessionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid session ID")
+		return
+	}
+
+	input := usecase.RevokeSessionInput{
+		UserID:    userID,
+		SessionID: sessionID,
+	}
+
+	if err := h.revokeSessionUseCase.Execute(r.Context(), input); err != nil {
+		handleUseCaseError(w, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dto.SuccessResponse{
+		Message: "Session revoked successfully",
+	})
+}
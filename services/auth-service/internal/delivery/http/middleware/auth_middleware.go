@@ -5,16 +5,19 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
 )
 
 type AuthMiddleware struct {
-	jwtService *crypto.JWTService
+	jwtService     *crypto.JWTService
+	revocationList *cache.TokenRevocationList
 }
 
-func NewAuthMiddleware(jwtService *crypto.JWTService) *AuthMiddleware {
+func NewAuthMiddleware(jwtService *crypto.JWTService, revocationList *cache.TokenRevocationList) *AuthMiddleware {
 	return &AuthMiddleware{
-		jwtService: jwtService,
+		jwtService:     jwtService,
+		revocationList: revocationList,
 	}
 }
 
@@ -44,16 +47,57 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 			return
 		}
 
+		// Verificar se o token foi revogado (logout ou revogação administrativa)
+		revoked, err := m.revocationList.IsRevoked(r.Context(), claims)
+		if err != nil {
+			http.Error(w, "Failed to verify token", http.StatusInternalServerError)
+			return
+		}
+		if revoked {
+			http.Error(w, "Token revoked", http.StatusUnauthorized)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), "token_id", claims.ID)
+		ctx = context.WithValue(ctx, "token_expires_at", claims.ExpiresAt.Time)
+		if claims.ClientID != "" {
+			ctx = context.WithValue(ctx, "client_id", claims.ClientID)
+			ctx = context.WithValue(ctx, "scope", claims.Scope)
+		}
+
+		// Tokens de serviço (client_credentials) não têm usuário; rotas que exigem
+		// usuário ou role os recusam por não encontrarem essas informações
+		if claims.IsClient() {
+			ctx = context.WithValue(ctx, "subject_type", crypto.SubjectTypeClient)
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+
 		// Adicionar informações do usuário no contexto
-		ctx := context.WithValue(r.Context(), "user_id", claims.UserID.String())
+		ctx = context.WithValue(ctx, "subject_type", crypto.SubjectTypeUser)
+		ctx = context.WithValue(ctx, "user_id", claims.UserID.String())
 		ctx = context.WithValue(ctx, "user_email", claims.Email)
 		ctx = context.WithValue(ctx, "user_role", claims.Role)
+		ctx = context.WithValue(ctx, "session_id", claims.SessionID)
 
 		// Chamar próximo handler
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// RequireFirstParty rejeita tokens emitidos a clientes OAuth, restringindo a rota
+// às aplicações da própria Titan Watch (ex.: tela de consentimento)
+func (m *AuthMiddleware) RequireFirstParty(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if clientID, ok := r.Context().Value("client_id").(string); ok && clientID != "" {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 // RequireRole verifica se o usuário tem a role necessária
 func (m *AuthMiddleware) RequireRole(roles ...string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+)
+
+// RateLimitKeyFunc extrai da requisição a identidade sujeita ao limite
+type RateLimitKeyFunc func(r *http.Request) string
+
+// RateLimitByIP limita por endereço IP do cliente
+func RateLimitByIP(r *http.Request) string {
+	return "ip:" + ClientIP(r)
+}
+
+// RateLimitByUser limita pelo usuário autenticado; sem autenticação, usa o IP.
+// Deve ser registrado depois de Authenticate.
+func RateLimitByUser(r *http.Request) string {
+	if userID, ok := r.Context().Value("user_id").(string); ok && userID != "" {
+		return "user:" + userID
+	}
+	return RateLimitByIP(r)
+}
+
+// RateLimit permite até limit requisições por window para cada chave retornada por keyFunc.
+// name separa os contadores de rotas diferentes. Com limiter nil, não limita.
+func RateLimit(limiter *cache.RateLimiter, name string, limit int, window time.Duration, keyFunc RateLimitKeyFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limiter == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			result := limiter.Allow(r.Context(), name+":"+keyFunc(r), limit, window)
+
+			// Headers do draft IETF "RateLimit header fields for HTTP"
+			reset := strconv.Itoa(ceilSeconds(result.Reset))
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			w.Header().Set("RateLimit-Reset", reset)
+
+			if !result.Allowed {
+				w.Header().Set("Retry-After", reset)
+				http.Error(w, "Too many requests", http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	seconds := int(math.Ceil(d.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return seconds
+}
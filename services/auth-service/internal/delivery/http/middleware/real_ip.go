@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// TrustedProxies são as redes dos proxies reversos (ex.: nginx) cujos headers
+// X-Forwarded-For e X-Real-IP são aceitos
+type TrustedProxies []netip.Prefix
+
+// ParseTrustedProxies lê uma lista de CIDRs ou IPs isolados
+func ParseTrustedProxies(values []string) (TrustedProxies, error) {
+	proxies := make(TrustedProxies, 0, len(values))
+	for _, value := range values {
+		if strings.Contains(value, "/") {
+			prefix, err := netip.ParsePrefix(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", value, err)
+			}
+			proxies = append(proxies, prefix.Masked())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", value, err)
+		}
+		addr = addr.Unmap()
+		proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return proxies, nil
+}
+
+// Contains indica se o endereço pertence a algum proxy confiável
+func (p TrustedProxies) Contains(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range p {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// RealIP substitui RemoteAddr pelo IP do cliente informado pelo proxy, apenas quando a
+// conexão vem de um proxy confiável. Conexões diretas mantêm o endereço do socket:
+// caso contrário, qualquer um trocaria o header a cada requisição para escapar dos
+// limites por IP.
+func RealIP(trusted TrustedProxies) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ip, ok := forwardedIP(r, trusted); ok {
+				r.RemoteAddr = ip
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// forwardedIP percorre o X-Forwarded-For da direita para a esquerda e retorna o
+// primeiro endereço que não é de um proxy confiável; os anteriores foram informados
+// pelo próprio cliente. Sem X-Forwarded-For, usa o X-Real-IP.
+func forwardedIP(r *http.Request, trusted TrustedProxies) (string, bool) {
+	peer, ok := peerAddr(r.RemoteAddr)
+	if !ok || !trusted.Contains(peer) {
+		return "", false
+	}
+
+	if header := r.Header.Values("X-Forwarded-For"); len(header) > 0 {
+		hops := strings.Split(strings.Join(header, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			addr, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+			if err != nil {
+				return "", false
+			}
+			if !trusted.Contains(addr) || i == 0 {
+				return addr.Unmap().String(), true
+			}
+		}
+	}
+
+	if addr, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get("X-Real-IP"))); err == nil {
+		return addr.Unmap().String(), true
+	}
+
+	return "", false
+}
+
+// ClientIP retorna o IP do cliente, sem a porta. Com RealIP registrado, é o endereço
+// informado por um proxy confiável ou, sem ele, o do socket.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func peerAddr(remoteAddr string) (netip.Addr, bool) {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
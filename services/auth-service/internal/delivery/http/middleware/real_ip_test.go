@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRealIP(t *testing.T) {
+	trusted, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		realIP     string
+		want       string
+	}{
+		{
+			name:       "direct connection ignores forwarded headers",
+			remoteAddr: "203.0.113.7:51000",
+			forwarded:  "198.51.100.1",
+			realIP:     "198.51.100.2",
+			want:       "203.0.113.7:51000",
+		},
+		{
+			name:       "trusted proxy forwards client address",
+			remoteAddr: "10.1.2.3:40000",
+			forwarded:  "198.51.100.1",
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "spoofed entries before the last untrusted hop are ignored",
+			remoteAddr: "10.1.2.3:40000",
+			forwarded:  "1.2.3.4, 198.51.100.1",
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "chained trusted proxies are skipped",
+			remoteAddr: "192.168.1.10:40000",
+			forwarded:  "198.51.100.1, 10.9.9.9",
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "only trusted hops uses the first one",
+			remoteAddr: "10.1.2.3:40000",
+			forwarded:  "10.5.5.5, 10.9.9.9",
+			want:       "10.5.5.5",
+		},
+		{
+			name:       "trusted proxy with X-Real-IP only",
+			remoteAddr: "10.1.2.3:40000",
+			realIP:     "198.51.100.3",
+			want:       "198.51.100.3",
+		},
+		{
+			name:       "invalid forwarded address keeps socket address",
+			remoteAddr: "10.1.2.3:40000",
+			forwarded:  "not-an-ip",
+			want:       "10.1.2.3:40000",
+		},
+		{
+			name:       "trusted proxy without headers keeps socket address",
+			remoteAddr: "10.1.2.3:40000",
+			want:       "10.1.2.3:40000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			if tt.realIP != "" {
+				req.Header.Set("X-Real-IP", tt.realIP)
+			}
+
+			var got string
+			RealIP(trusted)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.RemoteAddr
+			})).ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Fatalf("expected RemoteAddr %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseTrustedProxiesRejectsInvalidEntries(t *testing.T) {
+	for _, value := range []string{"10.0.0.0/33", "nginx", ""} {
+		if _, err := ParseTrustedProxies([]string{value}); err == nil {
+			t.Errorf("expected an error for %q", value)
+		}
+	}
+}
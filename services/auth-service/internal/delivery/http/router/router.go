@@ -2,24 +2,45 @@ package router
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
 
 	"github.com/jvieiradev/titanwatch/auth-service/internal/delivery/http/handler"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/delivery/http/middleware"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
 )
 
 // SetupRoutes configura todas as rotas da aplicação
 func SetupRoutes(
 	authHandler *handler.AuthHandler,
+	sessionHandler *handler.SessionHandler,
+	keyHandler *handler.KeyHandler,
+	adminHandler *handler.AdminHandler,
+	invitationHandler *handler.InvitationHandler,
+	passwordHandler *handler.PasswordHandler,
+	mfaHandler *handler.MFAHandler,
+	passkeyHandler *handler.PasskeyHandler,
+	oauthHandler *handler.OAuthHandler,
+	oauthClientHandler *handler.OAuthClientHandler,
+	oidcHandler *handler.OIDCHandler,
+	federationHandler *handler.FederationHandler,
 	authMiddleware *middleware.AuthMiddleware,
+	rateLimiter *cache.RateLimiter,
+	trustedProxies middleware.TrustedProxies,
 ) *chi.Mux {
 	r := chi.NewRouter()
 
+	// Limites por rota (rateLimiter nil desativa)
+	limit := func(name string, limit int, window time.Duration, key middleware.RateLimitKeyFunc) func(http.Handler) http.Handler {
+		return middleware.RateLimit(rateLimiter, name, limit, window, key)
+	}
+	loginLimit := limit("login", 10, time.Minute, middleware.RateLimitByIP)
+
 	// Middlewares globais
 	r.Use(chimiddleware.RequestID)
-	r.Use(chimiddleware.RealIP)
+	r.Use(middleware.RealIP(trustedProxies))
 	r.Use(chimiddleware.Recoverer)
 	r.Use(middleware.Logger)
 	r.Use(middleware.NewCORS().Handler)
@@ -30,19 +51,120 @@ func SetupRoutes(
 		w.Write([]byte("OK"))
 	})
 
+	// Chaves públicas para verificação de tokens por outros serviços
+	r.Get("/.well-known/jwks.json", keyHandler.JWKS)
+	r.Get("/.well-known/openid-configuration", oidcHandler.Discovery)
+
+	// OAuth 2.0 authorization server
+	r.Route("/oauth", func(r chi.Router) {
+		r.With(limit("oauth-authorize", 30, time.Minute, middleware.RateLimitByIP)).Get("/authorize", oauthHandler.Authorize)
+		// O cliente só é autenticado no handler, então estes limites são por IP
+		r.With(limit("oauth-token", 60, time.Minute, middleware.RateLimitByIP)).Post("/token", oauthHandler.Token)
+		r.With(limit("oauth-introspect", 600, time.Minute, middleware.RateLimitByIP)).Post("/introspect", oauthHandler.IntrospectToken)
+		r.With(limit("oauth-revoke", 60, time.Minute, middleware.RateLimitByIP)).Post("/revoke", oauthHandler.RevokeToken)
+
+		// OpenID Connect
+		logoutLimit := limit("oauth-logout", 30, time.Minute, middleware.RateLimitByIP)
+		r.With(logoutLimit).Get("/logout", oidcHandler.EndSession)
+		r.With(logoutLimit).Post("/logout", oidcHandler.EndSession)
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.Authenticate)
+			r.Use(limit("user", 120, time.Minute, middleware.RateLimitByUser))
+			r.Get("/userinfo", oidcHandler.UserInfo)
+			r.Post("/userinfo", oidcHandler.UserInfo)
+		})
+	})
+
 	// API routes
 	r.Route("/api/v1", func(r chi.Router) {
 		// Rotas públicas de autenticação
 		r.Route("/auth", func(r chi.Router) {
-			r.Post("/register", authHandler.Register)
-			r.Post("/login", authHandler.Login)
-			r.Post("/refresh", authHandler.RefreshToken)
-			r.Get("/verify", authHandler.VerifyToken)
+			r.With(limit("register", 10, time.Hour, middleware.RateLimitByIP)).Post("/register", authHandler.Register)
+			r.With(loginLimit).Post("/login", authHandler.Login)
+			r.With(loginLimit).Post("/login/mfa", mfaHandler.LoginMFA)
+			r.With(loginLimit).Post("/login/passkey/begin", passkeyHandler.BeginLogin)
+			r.With(loginLimit).Post("/login/passkey/finish", passkeyHandler.FinishLogin)
+			r.With(limit("refresh", 30, time.Minute, middleware.RateLimitByIP)).Post("/refresh", authHandler.RefreshToken)
+			r.With(limit("verify", 600, time.Minute, middleware.RateLimitByIP)).Get("/verify", authHandler.VerifyToken)
+			r.With(limit("invitation", 10, 15*time.Minute, middleware.RateLimitByIP)).Post("/invitations/{token}/accept", invitationHandler.AcceptInvitation)
+			r.With(limit("password-forgot", 5, 15*time.Minute, middleware.RateLimitByIP)).Post("/password/forgot", passwordHandler.ForgotPassword)
+			r.With(limit("password-reset", 10, 15*time.Minute, middleware.RateLimitByIP)).Post("/password/reset", passwordHandler.ResetPassword)
 
-			// Rotas protegidas
+			// Login federado por provedores de identidade externos (OpenID Connect)
+			r.Route("/federated", func(r chi.Router) {
+				federatedLimit := limit("federated", 30, time.Minute, middleware.RateLimitByIP)
+				r.Get("/providers", federationHandler.ListProviders)
+				r.With(federatedLimit).Get("/{provider}/start", federationHandler.Start)
+				r.With(federatedLimit).Get("/{provider}/callback", federationHandler.Callback)
+				r.With(loginLimit).Post("/exchange", federationHandler.Exchange)
+			})
+
+			// Rotas protegidas, restritas às aplicações da própria Titan Watch: tokens
+			// emitidos a clientes OAuth não gerenciam senha, MFA, passkeys nem sessões
 			r.Group(func(r chi.Router) {
 				r.Use(authMiddleware.Authenticate)
+				r.Use(authMiddleware.RequireFirstParty)
+				r.Use(limit("user", 120, time.Minute, middleware.RateLimitByUser))
 				r.Post("/logout", authHandler.Logout)
+				r.Post("/logout-all", authHandler.LogoutAll)
+				r.Post("/password", passwordHandler.ChangePassword)
+
+				r.Post("/mfa/totp/setup", mfaHandler.SetupTOTP)
+				r.Post("/mfa/totp/verify", mfaHandler.VerifyTOTP)
+				r.Delete("/mfa/totp", mfaHandler.DisableTOTP)
+
+				r.Get("/passkeys", passkeyHandler.ListPasskeys)
+				r.Post("/passkeys/register/begin", passkeyHandler.BeginRegistration)
+				r.Post("/passkeys/register/finish", passkeyHandler.FinishRegistration)
+				r.Delete("/passkeys/{id}", passkeyHandler.DeletePasskey)
+
+				r.Get("/sessions", sessionHandler.ListSessions)
+				r.Delete("/sessions/{id}", sessionHandler.RevokeSession)
+			})
+		})
+
+		// Login e consentimento OAuth, conduzidos pelo frontend com a sessão do usuário
+		r.Route("/oauth/requests/{id}", func(r chi.Router) {
+			r.Use(authMiddleware.Authenticate)
+			r.Use(authMiddleware.RequireFirstParty)
+			r.Use(limit("user", 120, time.Minute, middleware.RateLimitByUser))
+			r.Get("/", oauthHandler.GetAuthorizationRequest)
+			r.Post("/approve", oauthHandler.ApproveAuthorization)
+			r.Post("/deny", oauthHandler.DenyAuthorization)
+		})
+
+		// Rotas administrativas
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(authMiddleware.Authenticate)
+			r.Use(authMiddleware.RequireFirstParty)
+			r.Use(authMiddleware.RequireRole("admin"))
+			r.Use(limit("admin", 60, time.Minute, middleware.RateLimitByUser))
+
+			r.Get("/keys", keyHandler.ListKeys)
+			r.Post("/keys/rotate", keyHandler.RotateKey)
+
+			r.Route("/users", func(r chi.Router) {
+				r.Get("/", adminHandler.ListUsers)
+				r.Post("/", adminHandler.CreateUser)
+				r.Get("/{id}", adminHandler.GetUser)
+				r.Put("/{id}/role", adminHandler.ChangeRole)
+				r.Post("/{id}/activate", adminHandler.ActivateUser)
+				r.Post("/{id}/deactivate", adminHandler.DeactivateUser)
+				r.Post("/{id}/revoke-tokens", adminHandler.RevokeUserTokens)
+				r.Post("/{id}/unlock", adminHandler.UnlockUser)
+				r.Delete("/{id}", adminHandler.DeleteUser)
+			})
+
+			r.Route("/invitations", func(r chi.Router) {
+				r.Get("/", invitationHandler.ListInvitations)
+				r.Post("/", invitationHandler.CreateInvitation)
+				r.Delete("/{id}", invitationHandler.RevokeInvitation)
+			})
+
+			r.Route("/oauth/clients", func(r chi.Router) {
+				r.Get("/", oauthClientHandler.ListClients)
+				r.Post("/", oauthClientHandler.CreateClient)
+				r.Delete("/{id}", oauthClientHandler.DeleteClient)
 			})
 		})
 	})
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEventType representa os tipos de eventos de auditoria
+type AuditEventType string
+
+const (
+	AuditRefreshTokenReuse AuditEventType = "refresh_token_reuse"
+)
+
+// AuditEvent representa um evento relevante de segurança
+type AuditEvent struct {
+	ID        uuid.UUID
+	Type      AuditEventType
+	UserID    *uuid.UUID
+	Metadata  map[string]string
+	CreatedAt time.Time
+}
+
+// NewAuditEvent cria um novo evento de auditoria
+func NewAuditEvent(eventType AuditEventType, userID *uuid.UUID, metadata map[string]string) *AuditEvent {
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+
+	return &AuditEvent{
+		ID:        uuid.New(),
+		Type:      eventType,
+		UserID:    userID,
+		Metadata:  metadata,
+		CreatedAt: time.Now(),
+	}
+}
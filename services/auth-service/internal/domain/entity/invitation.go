@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InvitationStatus representa a situação de um convite
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationRevoked  InvitationStatus = "revoked"
+	InvitationExpired  InvitationStatus = "expired"
+)
+
+// Invitation representa um convite de cadastro emitido por um admin.
+// O token é de uso único e apenas seu digest é persistido.
+type Invitation struct {
+	ID             uuid.UUID
+	Email          string
+	Role           UserRole
+	TokenHash      string
+	InvitedBy      uuid.UUID
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+	AcceptedAt     *time.Time
+	AcceptedUserID *uuid.UUID
+	RevokedAt      *time.Time
+}
+
+// NewInvitation cria um novo convite pendente
+func NewInvitation(email string, role UserRole, invitedBy uuid.UUID, token string, expiresAt time.Time) *Invitation {
+	return &Invitation{
+		ID:        uuid.New(),
+		Email:     email,
+		Role:      role,
+		TokenHash: HashToken(token),
+		InvitedBy: invitedBy,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Accept marca o convite como utilizado pelo usuário criado
+func (i *Invitation) Accept(userID uuid.UUID) {
+	now := time.Now()
+	i.AcceptedAt = &now
+	i.AcceptedUserID = &userID
+}
+
+// Revoke cancela o convite
+func (i *Invitation) Revoke() {
+	now := time.Now()
+	i.RevokedAt = &now
+}
+
+// Status retorna a situação atual do convite
+func (i *Invitation) Status() InvitationStatus {
+	switch {
+	case i.AcceptedAt != nil:
+		return InvitationAccepted
+	case i.RevokedAt != nil:
+		return InvitationRevoked
+	case time.Now().After(i.ExpiresAt):
+		return InvitationExpired
+	default:
+		return InvitationPending
+	}
+}
+
+// IsPending verifica se o convite ainda pode ser aceito
+func (i *Invitation) IsPending() bool {
+	return i.Status() == InvitationPending
+}
@@ -0,0 +1,155 @@
+package entity
+
+import (
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Grant types que podem ser liberados para um cliente
+const (
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeRefreshToken      = "refresh_token"
+	GrantTypeClientCredentials = "client_credentials"
+)
+
+// Escopos do OpenID Connect: openid habilita o ID token e o userinfo; profile e
+// email liberam as claims correspondentes
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+)
+
+// DefaultGrantTypes são os grant types de clientes registrados sem especificá-los
+var DefaultGrantTypes = []string{GrantTypeAuthorizationCode, GrantTypeRefreshToken}
+
+// OAuthClient representa uma aplicação registrada no authorization server. Clientes
+// confidenciais se autenticam com um segredo, do qual apenas o digest é persistido;
+// clientes públicos (SPAs, apps nativos) não têm segredo e dependem do PKCE.
+// Serviços internos usam client_credentials e recebem tokens em nome próprio.
+type OAuthClient struct {
+	ID           uuid.UUID
+	Name         string
+	SecretHash   string
+	RedirectURIs []string
+	// PostLogoutRedirectURIs são os destinos aceitos após o logout (end_session_endpoint)
+	PostLogoutRedirectURIs []string
+	Scopes                 []string
+	GrantTypes             []string
+	// AccessTokenTTL é a validade dos tokens client_credentials (0 usa a padrão)
+	AccessTokenTTL time.Duration
+	// SkipConsent dispensa a tela de consentimento (aplicações da própria Titan Watch)
+	SkipConsent bool
+	IsActive    bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewOAuthClient cria um novo cliente. secret vazio cria um cliente público e
+// grantTypes vazio libera DefaultGrantTypes.
+func NewOAuthClient(name, secret string, redirectURIs, scopes, grantTypes []string, skipConsent bool) *OAuthClient {
+	if len(grantTypes) == 0 {
+		grantTypes = DefaultGrantTypes
+	}
+
+	now := time.Now()
+	client := &OAuthClient{
+		ID:           uuid.New(),
+		Name:         name,
+		RedirectURIs: redirectURIs,
+		Scopes:       scopes,
+		GrantTypes:   grantTypes,
+		SkipConsent:  skipConsent,
+		IsActive:     true,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if secret != "" {
+		client.SecretHash = HashToken(secret)
+	}
+	return client
+}
+
+// IsConfidential indica se o cliente precisa se autenticar com segredo
+func (c *OAuthClient) IsConfidential() bool {
+	return c.SecretHash != ""
+}
+
+// VerifySecret compara o segredo apresentado com o digest armazenado
+func (c *OAuthClient) VerifySecret(secret string) bool {
+	if !c.IsConfidential() || secret == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(HashToken(secret)), []byte(c.SecretHash)) == 1
+}
+
+// HasRedirectURI verifica se a URI foi registrada. A comparação é exata, sem
+// curingas nem correspondência por prefixo (OAuth 2.0 Security BCP).
+func (c *OAuthClient) HasRedirectURI(redirectURI string) bool {
+	for _, registered := range c.RedirectURIs {
+		if registered == redirectURI {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPostLogoutRedirectURI verifica se a URI foi registrada para o retorno após o
+// logout, com a mesma comparação exata das redirect URIs
+func (c *OAuthClient) HasPostLogoutRedirectURI(redirectURI string) bool {
+	for _, registered := range c.PostLogoutRedirectURIs {
+		if registered == redirectURI {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsGrant verifica se o grant type foi liberado para o cliente
+func (c *OAuthClient) AllowsGrant(grantType string) bool {
+	return containsAllScopes(c.GrantTypes, []string{grantType})
+}
+
+// AllowsScopes verifica se todos os escopos pedidos foram liberados para o cliente
+func (c *OAuthClient) AllowsScopes(scopes []string) bool {
+	return containsAllScopes(c.Scopes, scopes)
+}
+
+// ParseScope separa o parâmetro scope (escopos separados por espaço) sem duplicatas
+func ParseScope(scope string) []string {
+	var scopes []string
+	seen := make(map[string]bool)
+	for _, s := range strings.Fields(scope) {
+		if !seen[s] {
+			seen[s] = true
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+// FormatScope junta os escopos no formato do parâmetro scope
+func FormatScope(scopes []string) string {
+	return strings.Join(scopes, " ")
+}
+
+// HasScope indica se o escopo está entre os concedidos
+func HasScope(scopes []string, scope string) bool {
+	return containsAllScopes(scopes, []string{scope})
+}
+
+func containsAllScopes(granted, requested []string) bool {
+	allowed := make(map[string]bool, len(granted))
+	for _, scope := range granted {
+		allowed[scope] = true
+	}
+	for _, scope := range requested {
+		if !allowed[scope] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OAuthConsent registra os escopos que um usuário já autorizou para um cliente
+type OAuthConsent struct {
+	UserID    uuid.UUID
+	ClientID  uuid.UUID
+	Scopes    []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewOAuthConsent cria um novo consentimento
+func NewOAuthConsent(userID, clientID uuid.UUID, scopes []string) *OAuthConsent {
+	now := time.Now()
+	return &OAuthConsent{
+		UserID:    userID,
+		ClientID:  clientID,
+		Scopes:    scopes,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Covers indica se os escopos pedidos já foram autorizados
+func (c *OAuthConsent) Covers(scopes []string) bool {
+	return containsAllScopes(c.Scopes, scopes)
+}
+
+// Grant acrescenta escopos aos já autorizados
+func (c *OAuthConsent) Grant(scopes []string) {
+	for _, scope := range scopes {
+		if !containsAllScopes(c.Scopes, []string{scope}) {
+			c.Scopes = append(c.Scopes, scope)
+		}
+	}
+	c.UpdatedAt = time.Now()
+}
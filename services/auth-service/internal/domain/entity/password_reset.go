@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordReset representa um token de redefinição de senha de uso único.
+// Apenas o digest do token é persistido.
+type PasswordReset struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    *time.Time
+}
+
+// NewPasswordReset cria um novo token de redefinição
+func NewPasswordReset(userID uuid.UUID, token string, expiresAt time.Time) *PasswordReset {
+	return &PasswordReset{
+		ID:        uuid.New(),
+		UserID:    userID,
+		TokenHash: HashToken(token),
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}
+}
+
+// IsValid verifica se o token ainda pode ser utilizado
+func (p *PasswordReset) IsValid() bool {
+	return p.UsedAt == nil && time.Now().Before(p.ExpiresAt)
+}
@@ -6,28 +6,81 @@ import (
 	"github.com/google/uuid"
 )
 
-// Session representa uma sessão de autenticação
+// Session representa uma sessão de autenticação. CreatedAt marca o login que
+// originou a família; LastUsedAt, o último uso do refresh token. Sessões emitidas
+// pelo fluxo OAuth ficam vinculadas ao cliente (ClientID) e aos escopos concedidos.
 type Session struct {
-	ID           uuid.UUID
-	UserID       uuid.UUID
-	RefreshToken string
-	ExpiresAt    time.Time
-	CreatedAt    time.Time
-	IsRevoked    bool
-	RevokedAt    *time.Time
+	ID               uuid.UUID
+	UserID           uuid.UUID
+	FamilyID         uuid.UUID
+	ClientID         *uuid.UUID
+	Scope            string
+	RefreshTokenHash string
+	IPAddress        string
+	UserAgent        string
+	ExpiresAt        time.Time
+	CreatedAt        time.Time
+	LastUsedAt       time.Time
+	IsRevoked        bool
+	RevokedAt        *time.Time
+	ReplacedBy       *uuid.UUID
 }
 
-// NewSession cria uma nova sessão
-func NewSession(userID uuid.UUID, refreshToken string, expiresAt time.Time) *Session {
+// NewSession cria uma nova sessão, iniciando uma nova família de refresh tokens
+func NewSession(userID uuid.UUID, refreshToken string, expiresAt time.Time, ipAddress, userAgent string) *Session {
+	id := uuid.New()
+	now := time.Now()
 	return &Session{
-		ID:           uuid.New(),
-		UserID:       userID,
-		RefreshToken: refreshToken,
-		ExpiresAt:    expiresAt,
-		CreatedAt:    time.Now(),
-		IsRevoked:    false,
-		RevokedAt:    nil,
+		ID:               id,
+		UserID:           userID,
+		FamilyID:         id,
+		RefreshTokenHash: HashRefreshToken(refreshToken),
+		IPAddress:        ipAddress,
+		UserAgent:        userAgent,
+		ExpiresAt:        expiresAt,
+		CreatedAt:        now,
+		LastUsedAt:       now,
+		IsRevoked:        false,
+		RevokedAt:        nil,
+	}
+}
+
+// Rotate revoga a sessão e cria a sucessora na mesma família
+func (s *Session) Rotate(refreshToken string, expiresAt time.Time, ipAddress, userAgent string) *Session {
+	next := NewSession(s.UserID, refreshToken, expiresAt, ipAddress, userAgent)
+	next.FamilyID = s.FamilyID
+	next.ClientID = s.ClientID
+	next.Scope = s.Scope
+	next.CreatedAt = s.CreatedAt
+
+	s.Revoke()
+	s.ReplacedBy = &next.ID
+
+	return next
+}
+
+// BindToClient vincula a sessão a um cliente OAuth e aos escopos concedidos
+func (s *Session) BindToClient(clientID uuid.UUID, scope string) {
+	s.ClientID = &clientID
+	s.Scope = scope
+}
+
+// IssuedTo indica se a sessão pertence ao cliente informado (nil para login direto na API)
+func (s *Session) IssuedTo(clientID *uuid.UUID) bool {
+	if s.ClientID == nil || clientID == nil {
+		return s.ClientID == nil && clientID == nil
 	}
+	return *s.ClientID == *clientID
+}
+
+// IsRotated indica se o refresh token desta sessão já foi trocado por outro
+func (s *Session) IsRotated() bool {
+	return s.ReplacedBy != nil
+}
+
+// HashRefreshToken calcula o digest SHA-256 persistido no lugar do refresh token
+func HashRefreshToken(refreshToken string) string {
+	return HashToken(refreshToken)
 }
 
 // Revoke revoga a sessão
@@ -0,0 +1,13 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// HashToken calcula o digest SHA-256 de um token opaco. Apenas o digest é
+// persistido, para que um dump do banco não exponha credenciais válidas.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -6,7 +6,8 @@ import (
 	"github.com/google/uuid"
 )
 
-// User representa um usuário do sistema
+// User representa um usuário do sistema. TOTPSecret guarda o segredo TOTP
+// cifrado; TOTPEnabled indica se o segundo fator já foi confirmado.
 type User struct {
 	ID           uuid.UUID
 	Email        string
@@ -14,6 +15,8 @@ type User struct {
 	Name         string
 	Role         UserRole
 	IsActive     bool
+	TOTPSecret   string
+	TOTPEnabled  bool
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
@@ -67,6 +70,38 @@ func (u *User) UpdateProfile(name string) {
 	u.UpdatedAt = time.Now()
 }
 
+// SyncProfile aplica os dados mantidos por um diretório externo e indica se algo mudou
+func (u *User) SyncProfile(email, name string, role UserRole) bool {
+	if u.Email == email && u.Name == name && u.Role == role {
+		return false
+	}
+	u.Email = email
+	u.Name = name
+	u.Role = role
+	u.UpdatedAt = time.Now()
+	return true
+}
+
+// SetTOTPSecret registra um segredo TOTP (cifrado) ainda não confirmado
+func (u *User) SetTOTPSecret(encryptedSecret string) {
+	u.TOTPSecret = encryptedSecret
+	u.TOTPEnabled = false
+	u.UpdatedAt = time.Now()
+}
+
+// EnableTOTP ativa o segundo fator após a confirmação do primeiro código
+func (u *User) EnableTOTP() {
+	u.TOTPEnabled = true
+	u.UpdatedAt = time.Now()
+}
+
+// DisableTOTP remove o segundo fator
+func (u *User) DisableTOTP() {
+	u.TOTPSecret = ""
+	u.TOTPEnabled = false
+	u.UpdatedAt = time.Now()
+}
+
 // HasRole verifica se o usuário tem uma determinada role
 func (u *User) HasRole(role UserRole) bool {
 	return u.Role == role
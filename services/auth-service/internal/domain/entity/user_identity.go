@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserIdentity vincula uma identidade de um provedor externo (OpenID Connect) a um
+// usuário local. A identidade é o par provedor + subject; o email é apenas informativo.
+type UserIdentity struct {
+	Provider    string
+	Subject     string
+	UserID      uuid.UUID
+	Email       string
+	CreatedAt   time.Time
+	LastLoginAt time.Time
+}
+
+// NewUserIdentity cria um novo vínculo
+func NewUserIdentity(provider, subject string, userID uuid.UUID, email string) *UserIdentity {
+	now := time.Now()
+	return &UserIdentity{
+		Provider:    provider,
+		Subject:     subject,
+		UserID:      userID,
+		Email:       email,
+		CreatedAt:   now,
+		LastLoginAt: now,
+	}
+}
+
+// RecordLogin registra um login pela identidade, atualizando o email informado pelo provedor
+func (i *UserIdentity) RecordLogin(email string) {
+	if email != "" {
+		i.Email = email
+	}
+	i.LastLoginAt = time.Now()
+}
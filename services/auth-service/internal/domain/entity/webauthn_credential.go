@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebAuthnCredential representa uma passkey ou chave de segurança registrada por um usuário.
+// Apenas a chave pública é armazenada; a privada nunca deixa o autenticador.
+type WebAuthnCredential struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	CredentialID    []byte
+	PublicKey       []byte
+	AttestationType string
+	Transports      []string
+	AAGUID          []byte
+	SignCount       uint32
+	BackupEligible  bool
+	BackupState     bool
+	Name            string
+	CreatedAt       time.Time
+	LastUsedAt      *time.Time
+}
+
+// RecordUse atualiza o contador de assinaturas após uma asserção válida
+func (c *WebAuthnCredential) RecordUse(signCount uint32, backupState bool) {
+	now := time.Now()
+	c.SignCount = signCount
+	c.BackupState = backupState
+	c.LastUsedAt = &now
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+)
+
+// AuditRepository define o contrato para registro de eventos de auditoria
+type AuditRepository interface {
+	// Create registra um novo evento
+	Create(ctx context.Context, event *entity.AuditEvent) error
+}
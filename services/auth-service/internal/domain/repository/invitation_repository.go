@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+)
+
+// InvitationRepository define o contrato para operações de convite
+type InvitationRepository interface {
+	// Create cria um novo convite
+	Create(ctx context.Context, invitation *entity.Invitation) error
+
+	// GetByID busca convite por ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Invitation, error)
+
+	// GetByToken busca convite pelo digest do token apresentado
+	GetByToken(ctx context.Context, token string) (*entity.Invitation, error)
+
+	// List lista convites com paginação, mais recentes primeiro
+	List(ctx context.Context, limit, offset int) ([]*entity.Invitation, error)
+
+	// Count retorna o total de convites
+	Count(ctx context.Context) (int, error)
+
+	// Accept consome o convite, se ainda estiver pendente, e cria o usuário convidado
+	// na mesma transação; retorna pkgerrors.ErrInvitationNotPending caso contrário
+	Accept(ctx context.Context, invitation *entity.Invitation, user *entity.User) error
+
+	// Revoke revoga o convite se ainda estiver pendente
+	Revoke(ctx context.Context, invitation *entity.Invitation) error
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+)
+
+// OAuthClientRepository define o contrato para persistência de clientes OAuth
+type OAuthClientRepository interface {
+	// Create registra um novo cliente
+	Create(ctx context.Context, client *entity.OAuthClient) error
+
+	// GetByID busca um cliente pelo client_id
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.OAuthClient, error)
+
+	// List lista todos os clientes
+	List(ctx context.Context) ([]*entity.OAuthClient, error)
+
+	// Delete remove um cliente, junto com seus consentimentos e sessões
+	Delete(ctx context.Context, id uuid.UUID) error
+}
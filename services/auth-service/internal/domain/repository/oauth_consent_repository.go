@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+)
+
+// OAuthConsentRepository define o contrato para persistência dos consentimentos OAuth
+type OAuthConsentRepository interface {
+	// Get busca o consentimento de um usuário para um cliente
+	Get(ctx context.Context, userID, clientID uuid.UUID) (*entity.OAuthConsent, error)
+
+	// Save cria ou atualiza o consentimento
+	Save(ctx context.Context, consent *entity.OAuthConsent) error
+}
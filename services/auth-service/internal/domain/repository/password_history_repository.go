@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// PasswordHistoryRepository define o contrato para o histórico de hashes de senha
+type PasswordHistoryRepository interface {
+	// Add registra um hash de senha substituído
+	Add(ctx context.Context, userID uuid.UUID, passwordHash string) error
+
+	// GetRecent retorna os hashes mais recentes de um usuário
+	GetRecent(ctx context.Context, userID uuid.UUID, limit int) ([]string, error)
+
+	// Prune mantém apenas os keep hashes mais recentes de um usuário
+	Prune(ctx context.Context, userID uuid.UUID, keep int) error
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+)
+
+// PasswordResetRepository define o contrato para tokens de redefinição de senha
+type PasswordResetRepository interface {
+	// Create cria um novo token de redefinição
+	Create(ctx context.Context, reset *entity.PasswordReset) error
+
+	// GetByToken busca pelo digest do token apresentado
+	GetByToken(ctx context.Context, token string) (*entity.PasswordReset, error)
+
+	// MarkUsed consome o token se ainda não tiver sido utilizado
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+
+	// InvalidateByUserID consome todos os tokens pendentes de um usuário
+	InvalidateByUserID(ctx context.Context, userID uuid.UUID) error
+
+	// DeleteExpired deleta tokens expirados
+	DeleteExpired(ctx context.Context) error
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// RecoveryCodeRepository define o contrato para códigos de recuperação de MFA
+type RecoveryCodeRepository interface {
+	// Replace substitui todos os códigos do usuário pelos digests informados
+	Replace(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+
+	// Consume marca como usado o código com o digest informado, se ainda não usado
+	Consume(ctx context.Context, userID uuid.UUID, codeHash string) error
+
+	// CountRemaining retorna quantos códigos ainda podem ser usados
+	CountRemaining(ctx context.Context, userID uuid.UUID) (int, error)
+}
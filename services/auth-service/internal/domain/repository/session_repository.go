@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
@@ -12,21 +13,32 @@ type SessionRepository interface {
 	// Create cria uma nova sessão
 	Create(ctx context.Context, session *entity.Session) error
 
-	// GetByRefreshToken busca sessão por refresh token
+	// GetByID busca sessão por ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Session, error)
+
+	// GetByRefreshToken busca sessão pelo digest do refresh token apresentado
 	GetByRefreshToken(ctx context.Context, refreshToken string) (*entity.Session, error)
 
-	// GetByUserID busca todas as sessões de um usuário
+	// GetByUserID busca a sessão vigente (não substituída por rotação) de cada família do usuário
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Session, error)
 
 	// Update atualiza uma sessão
 	Update(ctx context.Context, session *entity.Session) error
 
+	// Rotate substitui a sessão pela sucessora de forma atômica; retorna
+	// pkgerrors.ErrTokenReused se a sessão já foi rotacionada ou revogada
+	Rotate(ctx context.Context, current, next *entity.Session) error
+
 	// Delete deleta uma sessão
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	// RevokeAllByUserID revoga todas as sessões de um usuário
 	RevokeAllByUserID(ctx context.Context, userID uuid.UUID) error
 
-	// DeleteExpired deleta sessões expiradas
-	DeleteExpired(ctx context.Context) error
+	// RevokeFamily revoga todas as sessões de uma família de refresh tokens
+	RevokeFamily(ctx context.Context, familyID uuid.UUID) error
+
+	// DeleteExpired deleta as sessões expiradas e as revogadas (inclusive rotacionadas)
+	// antes de revokedBefore, retornando quantas foram removidas
+	DeleteExpired(ctx context.Context, revokedBefore time.Time) (int64, error)
 }
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+)
+
+// UserIdentityRepository define o contrato para persistência das identidades externas
+type UserIdentityRepository interface {
+	// Create vincula uma identidade a um usuário
+	Create(ctx context.Context, identity *entity.UserIdentity) error
+
+	// Get busca a identidade pelo provedor e subject
+	Get(ctx context.Context, provider, subject string) (*entity.UserIdentity, error)
+
+	// GetByUserID lista as identidades vinculadas ao usuário
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.UserIdentity, error)
+
+	// Update atualiza o email e o último login
+	Update(ctx context.Context, identity *entity.UserIdentity) error
+}
@@ -21,12 +21,22 @@ type UserRepository interface {
 	// Update atualiza um usuário
 	Update(ctx context.Context, user *entity.User) error
 
+	// UpdatePasswordHash troca o hash da senha apenas se o atual ainda for oldHash,
+	// sem tocar nos demais campos; se a senha mudou no meio tempo, nada é alterado
+	UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string) error
+
 	// Delete deleta um usuário
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	// List lista usuários com paginação
 	List(ctx context.Context, limit, offset int) ([]*entity.User, error)
 
+	// Count retorna o total de usuários
+	Count(ctx context.Context) (int, error)
+
+	// CountActiveByRole retorna o total de usuários ativos com a role informada
+	CountActiveByRole(ctx context.Context, role entity.UserRole) (int, error)
+
 	// EmailExists verifica se um email já está cadastrado
 	EmailExists(ctx context.Context, email string) (bool, error)
 }
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+)
+
+// WebAuthnCredentialRepository define o contrato para persistência de credenciais WebAuthn
+type WebAuthnCredentialRepository interface {
+	// Create registra uma nova credencial
+	Create(ctx context.Context, credential *entity.WebAuthnCredential) error
+
+	// GetByCredentialID busca uma credencial pelo ID gerado pelo autenticador
+	GetByCredentialID(ctx context.Context, credentialID []byte) (*entity.WebAuthnCredential, error)
+
+	// GetByUserID lista as credenciais de um usuário
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.WebAuthnCredential, error)
+
+	// UpdateUsage persiste o contador de assinaturas e a data de último uso
+	UpdateUsage(ctx context.Context, credential *entity.WebAuthnCredential) error
+
+	// Delete remove uma credencial do usuário
+	Delete(ctx context.Context, id, userID uuid.UUID) error
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ErrPasswordPersonalInfo = errors.New("senha não pode conter seu email ou nome")
+	ErrPasswordBreached     = errors.New("senha aparece em vazamentos de dados conhecidos")
+)
+
+// Códigos dos motivos de rejeição de senha, devolvidos ao cliente
+const (
+	PasswordReasonTooShort     = "too_short"
+	PasswordReasonTooWeak      = "too_weak"
+	PasswordReasonPersonalInfo = "contains_personal_info"
+	PasswordReasonBreached     = "breached"
+)
+
+// minPersonalTokenLength é o menor trecho de email ou nome proibido dentro da senha
+const minPersonalTokenLength = 4
+
+// BreachedPasswordChecker consulta uma base de senhas vazadas
+type BreachedPasswordChecker interface {
+	IsBreached(password string) (bool, error)
+}
+
+// PasswordPolicy define as regras aplicadas a novas senhas
+type PasswordPolicy struct {
+	// MinLength é o tamanho mínimo em caracteres
+	MinLength int
+	// MinStrength é a pontuação mínima estimada, de 0 a 4 (0 desativa)
+	MinStrength int
+	// Breached é a base de senhas vazadas; nil desativa a verificação
+	Breached BreachedPasswordChecker
+}
+
+// PasswordViolation descreve um motivo de rejeição da senha
+type PasswordViolation struct {
+	Code    string
+	Message string
+	err     error
+}
+
+// PasswordPolicyError reúne todos os motivos pelos quais a senha foi rejeitada
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+	// Strength é a pontuação estimada da senha, de 0 a 4
+	Strength int
+}
+
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		messages = append(messages, violation.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap permite usar errors.Is com os erros de validação de cada motivo
+func (e *PasswordPolicyError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		errs = append(errs, violation.err)
+	}
+	return errs
+}
+
+// Check valida a senha contra a política. email e name são do dono da senha e podem
+// ser vazios. Retorna *PasswordPolicyError com todos os motivos de rejeição.
+func (p PasswordPolicy) Check(password, email, name string) error {
+	userInputs := personalTokens(email, name)
+	strength := estimatePasswordStrength(password, userInputs)
+
+	policyErr := &PasswordPolicyError{Strength: strength}
+	reject := func(code string, err error, message string) {
+		policyErr.Violations = append(policyErr.Violations, PasswordViolation{
+			Code:    code,
+			Message: message,
+			err:     err,
+		})
+	}
+
+	if utf8.RuneCountInString(password) < p.MinLength {
+		reject(PasswordReasonTooShort, ErrPasswordTooShort,
+			fmt.Sprintf("senha deve ter no mínimo %d caracteres", p.MinLength))
+	}
+
+	if containsPersonalInfo(password, userInputs) {
+		reject(PasswordReasonPersonalInfo, ErrPasswordPersonalInfo, ErrPasswordPersonalInfo.Error())
+	}
+
+	if strength < p.MinStrength {
+		reject(PasswordReasonTooWeak, ErrPasswordTooWeak,
+			"senha muito fraca - evite palavras comuns, sequências, datas e repetições")
+	}
+
+	if p.Breached != nil {
+		breached, err := p.Breached.IsBreached(password)
+		if err != nil {
+			return fmt.Errorf("failed to check breached passwords: %w", err)
+		}
+		if breached {
+			reject(PasswordReasonBreached, ErrPasswordBreached, ErrPasswordBreached.Error())
+		}
+	}
+
+	if len(policyErr.Violations) > 0 {
+		return policyErr
+	}
+	return nil
+}
+
+// personalTokens extrai do email e do nome os trechos que não devem aparecer na senha.
+// Também alimentam a estimativa de força como palavras do dicionário.
+func personalTokens(email, name string) []string {
+	var tokens []string
+	add := func(token string) {
+		if token = strings.ToLower(token); utf8.RuneCountInString(token) >= 3 {
+			tokens = append(tokens, token)
+		}
+	}
+
+	email = strings.TrimSpace(email)
+	if local, _, ok := strings.Cut(email, "@"); ok {
+		add(email)
+		add(local)
+		for _, part := range splitWords(local) {
+			add(part)
+		}
+	}
+
+	words := splitWords(name)
+	if len(words) > 1 {
+		add(strings.Join(words, ""))
+	}
+	for _, word := range words {
+		add(word)
+	}
+
+	return tokens
+}
+
+// containsPersonalInfo ignora trechos muito curtos (como o nome "Ana"), que geram falsos
+// positivos; eles ainda reduzem a força estimada
+func containsPersonalInfo(password string, tokens []string) bool {
+	lower := strings.ToLower(password)
+	unleeted := leetSubstitutions.Replace(lower)
+	for _, token := range tokens {
+		if utf8.RuneCountInString(token) < minPersonalTokenLength {
+			continue
+		}
+		if strings.Contains(lower, token) || strings.Contains(unleeted, token) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitWords(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
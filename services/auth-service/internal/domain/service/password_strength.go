@@ -0,0 +1,347 @@
+package service
+
+import (
+	_ "embed"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Pontuação de força da senha, na mesma escala do zxcvbn (0 a 4)
+const (
+	PasswordStrengthVeryWeak = iota
+	PasswordStrengthWeak
+	PasswordStrengthFair
+	PasswordStrengthStrong
+	PasswordStrengthVeryStrong
+)
+
+// maxEstimatedLength limita o trecho analisado; o restante conta como força bruta
+const maxEstimatedLength = 64
+
+//go:embed common_passwords.txt
+var commonPasswordsList string
+
+// commonPasswords mapeia senhas e palavras comuns para sua posição na lista (1 = mais comum)
+var commonPasswords = loadRankedWords(commonPasswordsList)
+
+// keyboardRows são sequências de teclas vizinhas no layout QWERTY
+var keyboardRows = []string{
+	"1234567890",
+	"qwertyuiop",
+	"asdfghjkl",
+	"zxcvbnm",
+	"!@#$%^&*()",
+}
+
+// leetSubstitutions desfaz trocas comuns de letras por números e símbolos
+var leetSubstitutions = strings.NewReplacer(
+	"4", "a", "@", "a",
+	"3", "e",
+	"1", "i", "!", "i",
+	"0", "o",
+	"$", "s", "5", "s",
+	"7", "t", "+", "t",
+)
+
+// strengthMatch é um trecho da senha [start, end) reconhecido como padrão previsível
+type strengthMatch struct {
+	start, end   int
+	log10Guesses float64
+}
+
+// estimatePasswordStrength estima a força da senha no estilo do zxcvbn: a senha é
+// decomposta em padrões previsíveis (palavras comuns, dados do usuário, sequências,
+// teclas vizinhas, repetições e anos) e o restante é tratado como força bruta. A
+// decomposição com menor número estimado de tentativas define a pontuação.
+func estimatePasswordStrength(password string, userInputs []string) int {
+	return strengthScore(estimateLog10Guesses(password, userInputs))
+}
+
+func estimateLog10Guesses(password string, userInputs []string) float64 {
+	runes := []rune(password)
+	if len(runes) == 0 {
+		return 0
+	}
+
+	bruteforce := math.Log10(float64(charsetCardinality(runes)))
+
+	// O excedente além do limite analisado conta apenas como força bruta
+	var extra float64
+	if len(runes) > maxEstimatedLength {
+		extra = float64(len(runes)-maxEstimatedLength) * bruteforce
+		runes = runes[:maxEstimatedLength]
+	}
+
+	matchesByEnd := make([][]strengthMatch, len(runes)+1)
+	for _, match := range findStrengthMatches(runes, userInputs) {
+		matchesByEnd[match.end] = append(matchesByEnd[match.end], match)
+	}
+
+	// best[i] é o menor número de tentativas (log10) para o prefixo de tamanho i
+	best := make([]float64, len(runes)+1)
+	for i := 1; i <= len(runes); i++ {
+		best[i] = best[i-1] + bruteforce
+		for _, match := range matchesByEnd[i] {
+			if guesses := best[match.start] + match.log10Guesses; guesses < best[i] {
+				best[i] = guesses
+			}
+		}
+	}
+
+	return best[len(runes)] + extra
+}
+
+// strengthScore converte tentativas estimadas na pontuação de 0 a 4 (limiares do zxcvbn)
+func strengthScore(log10Guesses float64) int {
+	switch {
+	case log10Guesses < 3:
+		return PasswordStrengthVeryWeak
+	case log10Guesses < 6:
+		return PasswordStrengthWeak
+	case log10Guesses < 8:
+		return PasswordStrengthFair
+	case log10Guesses < 10:
+		return PasswordStrengthStrong
+	default:
+		return PasswordStrengthVeryStrong
+	}
+}
+
+func findStrengthMatches(runes []rune, userInputs []string) []strengthMatch {
+	var matches []strengthMatch
+	matches = append(matches, dictionaryMatches(runes, userInputs)...)
+	matches = append(matches, sequenceMatches(runes)...)
+	matches = append(matches, keyboardMatches(runes)...)
+	matches = append(matches, repeatMatches(runes)...)
+	matches = append(matches, yearMatches(runes)...)
+	return matches
+}
+
+// dictionaryMatches encontra palavras comuns e dados do usuário, inclusive com
+// maiúsculas e substituições leet
+func dictionaryMatches(runes []rune, userInputs []string) []strengthMatch {
+	userWords := make(map[string]int, len(userInputs))
+	for i, input := range userInputs {
+		if _, ok := userWords[input]; !ok {
+			userWords[input] = i + 1
+		}
+	}
+
+	var matches []strengthMatch
+	for start := 0; start < len(runes); start++ {
+		for end := start + 3; end <= len(runes); end++ {
+			token := string(runes[start:end])
+			lower := strings.ToLower(token)
+			unleeted := leetSubstitutions.Replace(lower)
+
+			rank, ok := lookupRank(userWords, lower, unleeted)
+			if !ok {
+				rank, ok = lookupRank(commonPasswords, lower, unleeted)
+			}
+			if !ok {
+				continue
+			}
+
+			guesses := float64(rank) * uppercaseVariations(token)
+			if unleeted != lower {
+				guesses *= 2
+			}
+			matches = append(matches, strengthMatch{start: start, end: end, log10Guesses: math.Log10(guesses)})
+		}
+	}
+	return matches
+}
+
+func lookupRank(words map[string]int, lower, unleeted string) (int, bool) {
+	if rank, ok := words[lower]; ok {
+		return rank, true
+	}
+	rank, ok := words[unleeted]
+	return rank, ok
+}
+
+// uppercaseVariations estima as tentativas extras causadas por maiúsculas
+func uppercaseVariations(token string) float64 {
+	var upper, lower int
+	for _, r := range token {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+
+	switch {
+	case upper == 0:
+		return 1
+	case lower == 0, upper == 1 && unicode.IsUpper([]rune(token)[0]):
+		// Tudo maiúsculo ou só a inicial: as variações mais previsíveis
+		return 2
+	default:
+		return math.Pow(2, float64(min(upper, lower)))
+	}
+}
+
+// sequenceMatches encontra sequências como "abcd", "4321" ou "aceg"
+func sequenceMatches(runes []rune) []strengthMatch {
+	var matches []strengthMatch
+	for start := 0; start+2 < len(runes); {
+		delta := runes[start+1] - runes[start]
+		end := start + 2
+		for end < len(runes) && runes[end]-runes[end-1] == delta {
+			end++
+		}
+
+		if end-start >= 3 && delta != 0 && delta >= -5 && delta <= 5 {
+			base := 26.0
+			switch first := unicode.ToLower(runes[start]); {
+			case strings.ContainsRune("az019", first):
+				base = 4
+			case unicode.IsDigit(first):
+				base = 10
+			}
+			if delta < 0 {
+				base *= 2
+			}
+			matches = append(matches, strengthMatch{
+				start:        start,
+				end:          end,
+				log10Guesses: math.Log10(base * float64(end-start)),
+			})
+		}
+		start = end - 1
+	}
+	return matches
+}
+
+// keyboardMatches encontra teclas vizinhas digitadas em sequência, como "qwerty" ou "lkjh"
+func keyboardMatches(runes []rune) []strengthMatch {
+	var matches []strengthMatch
+	for start := 0; start < len(runes); start++ {
+		end := start + 1
+		for end < len(runes) && keyboardAdjacent(unicode.ToLower(runes[end-1]), unicode.ToLower(runes[end])) {
+			end++
+		}
+
+		if end-start >= 4 {
+			matches = append(matches, strengthMatch{
+				start:        start,
+				end:          end,
+				log10Guesses: math.Log10(40 * float64(end-start)),
+			})
+			start = end - 1
+		}
+	}
+	return matches
+}
+
+func keyboardAdjacent(a, b rune) bool {
+	for _, row := range keyboardRows {
+		i, j := strings.IndexRune(row, a), strings.IndexRune(row, b)
+		if i >= 0 && j >= 0 && (i-j == 1 || j-i == 1) {
+			return true
+		}
+	}
+	return false
+}
+
+// repeatMatches encontra o mesmo caractere repetido, como "aaaa" ou "1111"
+func repeatMatches(runes []rune) []strengthMatch {
+	var matches []strengthMatch
+	for start := 0; start < len(runes); {
+		end := start + 1
+		for end < len(runes) && runes[end] == runes[start] {
+			end++
+		}
+
+		if end-start >= 3 {
+			cardinality := charsetCardinality(runes[start : start+1])
+			matches = append(matches, strengthMatch{
+				start:        start,
+				end:          end,
+				log10Guesses: math.Log10(float64(cardinality * (end - start))),
+			})
+		}
+		start = end
+	}
+	return matches
+}
+
+// yearMatches encontra anos recentes, muito usados como sufixo de senhas
+func yearMatches(runes []rune) []strengthMatch {
+	currentYear := time.Now().Year()
+
+	var matches []strengthMatch
+	for start := 0; start+4 <= len(runes); start++ {
+		year, err := strconv.Atoi(string(runes[start : start+4]))
+		if err != nil || year < 1900 || year > 2099 {
+			continue
+		}
+
+		distance := currentYear - year
+		if distance < 0 {
+			distance = -distance
+		}
+		matches = append(matches, strengthMatch{
+			start:        start,
+			end:          start + 4,
+			log10Guesses: math.Log10(float64(max(distance, 20))),
+		})
+	}
+	return matches
+}
+
+// charsetCardinality estima o tamanho do alfabeto usado na senha
+func charsetCardinality(runes []rune) int {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	cardinality := 0
+	if lower {
+		cardinality += 26
+	}
+	if upper {
+		cardinality += 26
+	}
+	if digit {
+		cardinality += 10
+	}
+	if symbol {
+		cardinality += 33
+	}
+	if other {
+		cardinality += 100
+	}
+	return cardinality
+}
+
+func loadRankedWords(list string) map[string]int {
+	words := make(map[string]int)
+	for _, line := range strings.Split(list, "\n") {
+		word := strings.ToLower(strings.TrimSpace(line))
+		if word == "" {
+			continue
+		}
+		if _, ok := words[word]; !ok {
+			words[word] = len(words) + 1
+		}
+	}
+	return words
+}
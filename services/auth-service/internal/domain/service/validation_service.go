@@ -2,24 +2,58 @@ package service
 
 import (
 	"errors"
+	"net"
+	"net/url"
 	"regexp"
 	"strings"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
 )
 
 var (
 	ErrInvalidEmail            = errors.New("email inválido")
-	ErrPasswordTooShort        = errors.New("senha deve ter no mínimo 8 caracteres")
-	ErrPasswordTooWeak         = errors.New("senha muito fraca - deve conter letras maiúsculas, minúsculas e números")
+	ErrPasswordTooShort        = errors.New("senha muito curta")
+	ErrPasswordTooWeak         = errors.New("senha muito fraca")
 	ErrNameTooShort            = errors.New("nome deve ter no mínimo 2 caracteres")
 	ErrNameTooLong             = errors.New("nome deve ter no máximo 100 caracteres")
+	ErrInvalidRedirectURI      = errors.New("redirect URI inválida - use https (http apenas em localhost) e não inclua fragmento")
+	ErrInvalidScope            = errors.New("escopo inválido")
+	ErrInvalidGrantType        = errors.New("grant type inválido - use authorization_code, refresh_token ou client_credentials")
+	ErrInvalidClientGrants     = errors.New("combinação de grant types inválida para o cliente")
+	ErrInvalidAccessTokenTTL   = errors.New("validade do access token deve ser entre 1 minuto e 24 horas")
 )
 
+// AsValidationError retorna o erro de validação de domínio contido em err, se houver
+func AsValidationError(err error) (error, bool) {
+	for _, validationErr := range []error{
+		ErrInvalidEmail,
+		ErrPasswordTooShort,
+		ErrPasswordTooWeak,
+		ErrPasswordPersonalInfo,
+		ErrPasswordBreached,
+		ErrNameTooShort,
+		ErrNameTooLong,
+		ErrInvalidRedirectURI,
+		ErrInvalidScope,
+		ErrInvalidGrantType,
+		ErrInvalidClientGrants,
+		ErrInvalidAccessTokenTTL,
+	} {
+		if errors.Is(err, validationErr) {
+			return validationErr, true
+		}
+	}
+	return nil, false
+}
+
 // ValidationService fornece validações de domínio
-type ValidationService struct{}
+type ValidationService struct {
+	passwordPolicy PasswordPolicy
+}
 
-// NewValidationService cria uma nova instância
-func NewValidationService() *ValidationService {
-	return &ValidationService{}
+// NewValidationService cria uma nova instância que valida senhas com passwordPolicy
+func NewValidationService(passwordPolicy PasswordPolicy) *ValidationService {
+	return &ValidationService{passwordPolicy: passwordPolicy}
 }
 
 // ValidateEmail valida formato de email
@@ -39,21 +73,10 @@ func (v *ValidationService) ValidateEmail(email string) error {
 	return nil
 }
 
-// ValidatePassword valida força da senha
-func (v *ValidationService) ValidatePassword(password string) error {
-	if len(password) < 8 {
-		return ErrPasswordTooShort
-	}
-
-	hasUpper := regexp.MustCompile(`[A-Z]`).MatchString(password)
-	hasLower := regexp.MustCompile(`[a-z]`).MatchString(password)
-	hasNumber := regexp.MustCompile(`[0-9]`).MatchString(password)
-
-	if !hasUpper || !hasLower || !hasNumber {
-		return ErrPasswordTooWeak
-	}
-
-	return nil
+// ValidatePassword valida a senha contra a política de senhas. email e name são do
+// dono da senha e não podem aparecer nela.
+func (v *ValidationService) ValidatePassword(password, email, name string) error {
+	return v.passwordPolicy.Check(password, email, name)
 }
 
 // ValidateName valida nome do usuário
@@ -71,6 +94,51 @@ func (v *ValidationService) ValidateName(name string) error {
 	return nil
 }
 
+// ValidateRedirectURI valida uma redirect URI de cliente OAuth: absoluta, sem
+// fragmento e com https, exceto em loopback (desenvolvimento e apps nativos)
+func (v *ValidationService) ValidateRedirectURI(redirectURI string) error {
+	u, err := url.Parse(redirectURI)
+	if err != nil || !u.IsAbs() || u.Host == "" || u.Fragment != "" || strings.Contains(redirectURI, "#") {
+		return ErrInvalidRedirectURI
+	}
+
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		host := u.Hostname()
+		if host == "localhost" || net.ParseIP(host).IsLoopback() {
+			return nil
+		}
+	}
+
+	return ErrInvalidRedirectURI
+}
+
+// ValidateScope valida um escopo OAuth (RFC 6749, seção 3.3)
+func (v *ValidationService) ValidateScope(scope string) error {
+	if scope == "" {
+		return ErrInvalidScope
+	}
+
+	for _, c := range scope {
+		if c < 0x21 || c > 0x7e || c == '"' || c == '\\' {
+			return ErrInvalidScope
+		}
+	}
+
+	return nil
+}
+
+// ValidateGrantType valida um grant type que pode ser liberado para um cliente OAuth
+func (v *ValidationService) ValidateGrantType(grantType string) error {
+	switch grantType {
+	case entity.GrantTypeAuthorizationCode, entity.GrantTypeRefreshToken, entity.GrantTypeClientCredentials:
+		return nil
+	}
+	return ErrInvalidGrantType
+}
+
 // NormalizeEmail normaliza email (lowercase, trim)
 func (v *ValidationService) NormalizeEmail(email string) string {
 	return strings.TrimSpace(strings.ToLower(email))
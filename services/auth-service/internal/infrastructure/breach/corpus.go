@@ -0,0 +1,114 @@
+package breach
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// prefixLength é o tamanho do prefixo do SHA-1 usado nas consultas por intervalo
+const prefixLength = 5
+
+// Corpus é uma base offline de senhas vazadas no formato do Have I Been Pwned: uma
+// linha por senha com o SHA-1 em hexadecimal, opcionalmente seguido de ":<ocorrências>".
+//
+// As consultas seguem o modelo k-anonymity da API de intervalos do HIBP: o hash é
+// dividido em um prefixo de 5 caracteres e um sufixo, e apenas os sufixos do
+// intervalo daquele prefixo são comparados.
+type Corpus struct {
+	ranges map[string]map[string]int
+	size   int
+}
+
+// LoadCorpus carrega a base de um arquivo local
+func LoadCorpus(path string) (*Corpus, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached password corpus: %w", err)
+	}
+	defer file.Close()
+
+	corpus := &Corpus{ranges: make(map[string]map[string]int)}
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		hash, count, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid breached password corpus entry at line %d: %w", lineNumber, err)
+		}
+		corpus.add(hash, count)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read breached password corpus: %w", err)
+	}
+
+	return corpus, nil
+}
+
+func parseLine(line string) (string, int, error) {
+	hash, countStr, hasCount := strings.Cut(line, ":")
+	hash = strings.ToUpper(hash)
+
+	if len(hash) != sha1.Size*2 {
+		return "", 0, fmt.Errorf("expected a %d-character SHA-1 hash", sha1.Size*2)
+	}
+	if _, err := hex.DecodeString(hash); err != nil {
+		return "", 0, fmt.Errorf("expected a hexadecimal SHA-1 hash")
+	}
+
+	count := 1
+	if hasCount {
+		var err error
+		if count, err = strconv.Atoi(countStr); err != nil || count < 1 {
+			return "", 0, fmt.Errorf("invalid occurrence count %q", countStr)
+		}
+	}
+
+	return hash, count, nil
+}
+
+func (c *Corpus) add(hash string, count int) {
+	prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+
+	suffixes, ok := c.ranges[prefix]
+	if !ok {
+		suffixes = make(map[string]int)
+		c.ranges[prefix] = suffixes
+	}
+	if _, ok := suffixes[suffix]; !ok {
+		c.size++
+	}
+	suffixes[suffix] += count
+}
+
+// Range retorna os sufixos conhecidos, com o número de ocorrências, para um prefixo de
+// 5 caracteres do SHA-1. O mapa retornado não deve ser modificado.
+func (c *Corpus) Range(prefix string) map[string]int {
+	return c.ranges[strings.ToUpper(prefix)]
+}
+
+// Occurrences retorna quantas vezes a senha aparece na base (0 se não aparece)
+func (c *Corpus) Occurrences(password string) int {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	return c.Range(hash[:prefixLength])[hash[prefixLength:]]
+}
+
+// IsBreached indica se a senha aparece na base
+func (c *Corpus) IsBreached(password string) (bool, error) {
+	return c.Occurrences(password) > 0, nil
+}
+
+// Len retorna o número de hashes distintos na base
+func (c *Corpus) Len() int {
+	return c.size
+}
@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+// FederatedLoginState é um login federado aguardando o retorno do provedor, indexado
+// pelo parâmetro state enviado na autorização
+type FederatedLoginState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+	// BindingHash é o digest do valor guardado em cookie no navegador que iniciou o login
+	BindingHash string `json:"binding_hash"`
+}
+
+// FederatedLogin é um login federado concluído, aguardando a troca pelos tokens
+type FederatedLogin struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Provider string    `json:"provider"`
+}
+
+// FederationStore mantém no Redis os logins federados em andamento e os codes de
+// uso único entregues ao frontend ao final do login
+type FederationStore struct {
+	redis    *RedisClient
+	stateTTL time.Duration
+	codeTTL  time.Duration
+}
+
+// NewFederationStore cria uma nova instância
+func NewFederationStore(redisClient *RedisClient, stateTTL, codeTTL time.Duration) *FederationStore {
+	return &FederationStore{
+		redis:    redisClient,
+		stateTTL: stateTTL,
+		codeTTL:  codeTTL,
+	}
+}
+
+// CreateState guarda o login em andamento e retorna o state enviado ao provedor
+func (s *FederationStore) CreateState(ctx context.Context, state FederatedLoginState) (string, error) {
+	id, err := crypto.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.set(ctx, federationStateKey(id), state, s.stateTTL); err != nil {
+		return "", fmt.Errorf("failed to store federated login state: %w", err)
+	}
+
+	return id, nil
+}
+
+// TakeState consome o login em andamento; cada state só pode retornar uma vez
+func (s *FederationStore) TakeState(ctx context.Context, id string) (*FederatedLoginState, error) {
+	var state FederatedLoginState
+	data, err := s.redis.GetDel(ctx, federationStateKey(id))
+	if err := decodeOAuthValue(data, err, &state, pkgerrors.ErrFederatedLoginInvalid); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// CreateCode guarda o login concluído e retorna o code entregue ao frontend
+func (s *FederationStore) CreateCode(ctx context.Context, login FederatedLogin) (string, error) {
+	code, err := crypto.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.set(ctx, federationCodeKey(code), login, s.codeTTL); err != nil {
+		return "", fmt.Errorf("failed to store federated login code: %w", err)
+	}
+
+	return code, nil
+}
+
+// TakeCode consome o code do login concluído
+func (s *FederationStore) TakeCode(ctx context.Context, code string) (*FederatedLogin, error) {
+	var login FederatedLogin
+	data, err := s.redis.GetDel(ctx, federationCodeKey(code))
+	if err := decodeOAuthValue(data, err, &login, pkgerrors.ErrFederatedLoginInvalid); err != nil {
+		return nil, err
+	}
+	return &login, nil
+}
+
+func (s *FederationStore) set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return s.redis.Set(ctx, key, data, ttl)
+}
+
+func federationStateKey(id string) string {
+	return "federation_state:" + entity.HashToken(id)
+}
+
+func federationCodeKey(code string) string {
+	return "federation_code:" + entity.HashToken(code)
+}
@@ -0,0 +1,145 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+// LoginThrottlePolicy define os limites de falhas de login e a duração dos bloqueios
+type LoginThrottlePolicy struct {
+	// MaxAccountFailures é o número de falhas por conta até o primeiro bloqueio
+	MaxAccountFailures int64
+	// MaxIPFailures é o número de falhas por IP até o primeiro bloqueio
+	MaxIPFailures int64
+	// BaseLockout é a duração do primeiro bloqueio; dobra a cada nova falha
+	BaseLockout time.Duration
+	// MaxLockout limita a duração de um bloqueio
+	MaxLockout time.Duration
+	// FailureWindow é por quanto tempo uma falha continua contando
+	FailureWindow time.Duration
+}
+
+// LoginThrottle contabiliza no Redis as falhas de login por conta e por IP e aplica
+// bloqueios temporários com backoff exponencial
+type LoginThrottle struct {
+	redis  *RedisClient
+	policy LoginThrottlePolicy
+}
+
+// NewLoginThrottle cria uma nova instância
+func NewLoginThrottle(redisClient *RedisClient, policy LoginThrottlePolicy) *LoginThrottle {
+	return &LoginThrottle{
+		redis:  redisClient,
+		policy: policy,
+	}
+}
+
+// Check retorna *pkgerrors.RateLimitError se a conta ou o IP estiverem bloqueados
+func (t *LoginThrottle) Check(ctx context.Context, email, ipAddress string) error {
+	var retryAfter time.Duration
+
+	for _, scope := range t.scopes(email, ipAddress) {
+		ttl, err := t.redis.TTL(ctx, scope.lockKey())
+		if err != nil {
+			return fmt.Errorf("failed to check login lockout: %w", err)
+		}
+		if ttl > retryAfter {
+			retryAfter = ttl
+		}
+	}
+
+	if retryAfter > 0 {
+		return &pkgerrors.RateLimitError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// RecordFailure contabiliza uma falha para a conta e o IP, bloqueando quem atingir o limite.
+// É chamado também para emails não cadastrados, para não revelar quais contas existem.
+func (t *LoginThrottle) RecordFailure(ctx context.Context, email, ipAddress string) error {
+	for _, scope := range t.scopes(email, ipAddress) {
+		failures, err := t.redis.Incr(ctx, scope.failuresKey(), t.policy.FailureWindow)
+		if err != nil {
+			return fmt.Errorf("failed to record login failure: %w", err)
+		}
+
+		// Limite zero desativa o bloqueio para o escopo
+		if scope.threshold <= 0 || failures < scope.threshold {
+			continue
+		}
+
+		lockout := t.lockoutFor(failures - scope.threshold)
+		if err := t.redis.Set(ctx, scope.lockKey(), "1", lockout); err != nil {
+			return fmt.Errorf("failed to lock login: %w", err)
+		}
+
+		// Manter o contador enquanto durar o bloqueio, para que o backoff continue crescendo
+		if err := t.redis.Expire(ctx, scope.failuresKey(), lockout+t.policy.FailureWindow); err != nil {
+			return fmt.Errorf("failed to extend login failures: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Reset zera as falhas e o bloqueio da conta (login bem-sucedido ou desbloqueio administrativo).
+// Os contadores por IP não são zerados, para que um atacante não os limpe com a própria conta.
+func (t *LoginThrottle) Reset(ctx context.Context, email string) error {
+	scope := accountScope(email, 0)
+
+	if err := t.redis.Delete(ctx, scope.failuresKey()); err != nil {
+		return err
+	}
+	return t.redis.Delete(ctx, scope.lockKey())
+}
+
+// lockoutFor calcula BaseLockout * 2^excess, limitado a MaxLockout
+func (t *LoginThrottle) lockoutFor(excess int64) time.Duration {
+	lockout := t.policy.BaseLockout
+	for i := int64(0); i < excess && lockout < t.policy.MaxLockout; i++ {
+		lockout *= 2
+	}
+
+	if lockout > t.policy.MaxLockout {
+		return t.policy.MaxLockout
+	}
+	return lockout
+}
+
+func (t *LoginThrottle) scopes(email, ipAddress string) []throttleScope {
+	scopes := []throttleScope{accountScope(email, t.policy.MaxAccountFailures)}
+	if ipAddress != "" {
+		scopes = append(scopes, throttleScope{
+			name:      "ip:" + ipAddress,
+			threshold: t.policy.MaxIPFailures,
+		})
+	}
+	return scopes
+}
+
+// throttleScope identifica um contador de falhas (conta ou IP) e seu limite
+type throttleScope struct {
+	name      string
+	threshold int64
+}
+
+func accountScope(email string, threshold int64) throttleScope {
+	// O email entra apenas como digest nas chaves do Redis
+	return throttleScope{
+		name:      "account:" + entity.HashToken(email),
+		threshold: threshold,
+	}
+}
+
+func (s throttleScope) failuresKey() string {
+	return "login_failures:" + s.name
+}
+
+func (s throttleScope) lockKey() string {
+	return "login_lock:" + s.name
+}
@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoginThrottleLockoutBackoff(t *testing.T) {
+	throttle := NewLoginThrottle(nil, LoginThrottlePolicy{
+		BaseLockout: time.Minute,
+		MaxLockout:  time.Hour,
+	})
+
+	tests := []struct {
+		excess int64
+		want   time.Duration
+	}{
+		{excess: 0, want: time.Minute},
+		{excess: 1, want: 2 * time.Minute},
+		{excess: 2, want: 4 * time.Minute},
+		{excess: 5, want: 32 * time.Minute},
+		// 64 minutos excede o máximo
+		{excess: 6, want: time.Hour},
+		{excess: 1000, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := throttle.lockoutFor(tt.excess); got != tt.want {
+			t.Errorf("lockoutFor(%d): expected %s, got %s", tt.excess, tt.want, got)
+		}
+	}
+}
+
+func TestLoginThrottleBaseLockoutAboveMax(t *testing.T) {
+	throttle := NewLoginThrottle(nil, LoginThrottlePolicy{
+		BaseLockout: 2 * time.Hour,
+		MaxLockout:  time.Hour,
+	})
+
+	if got := throttle.lockoutFor(0); got != time.Hour {
+		t.Fatalf("expected lockout capped at %s, got %s", time.Hour, got)
+	}
+}
+
+func TestLoginThrottleScopes(t *testing.T) {
+	throttle := NewLoginThrottle(nil, LoginThrottlePolicy{
+		MaxAccountFailures: 5,
+		MaxIPFailures:      20,
+	})
+
+	tests := []struct {
+		name      string
+		ipAddress string
+		want      []int64
+	}{
+		{name: "account and IP", ipAddress: "203.0.113.7", want: []int64{5, 20}},
+		{name: "account only without IP", ipAddress: "", want: []int64{5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scopes := throttle.scopes("user@example.com", tt.ipAddress)
+			if len(scopes) != len(tt.want) {
+				t.Fatalf("expected %d scopes, got %d", len(tt.want), len(scopes))
+			}
+			for i, scope := range scopes {
+				if scope.threshold != tt.want[i] {
+					t.Errorf("scope %s: expected threshold %d, got %d", scope.name, tt.want[i], scope.threshold)
+				}
+			}
+		})
+	}
+}
+
+func TestLoginThrottleAccountKeysHideEmail(t *testing.T) {
+	scope := accountScope("user@example.com", 5)
+
+	for _, key := range []string{scope.failuresKey(), scope.lockKey()} {
+		if strings.Contains(key, "user@example.com") {
+			t.Fatalf("expected email to be hashed in key, got %q", key)
+		}
+	}
+	if accountScope("user@example.com", 0).lockKey() != scope.lockKey() {
+		t.Fatal("expected the same email to map to the same key")
+	}
+}
@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// maxMFAAttempts limita as tentativas de código por desafio
+	maxMFAAttempts = 5
+	// totpReplayWindow cobre a janela de validade de um código TOTP com tolerância de relógio
+	totpReplayWindow = 2 * time.Minute
+)
+
+// MFAChallenge representa um login com senha correta aguardando o segundo fator
+type MFAChallenge struct {
+	UserID    uuid.UUID `json:"user_id"`
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+}
+
+// MFAStore mantém no Redis os desafios de MFA pendentes e os códigos TOTP já usados
+type MFAStore struct {
+	redis        *RedisClient
+	challengeTTL time.Duration
+}
+
+// NewMFAStore cria uma nova instância
+func NewMFAStore(redisClient *RedisClient, challengeTTL time.Duration) *MFAStore {
+	return &MFAStore{
+		redis:        redisClient,
+		challengeTTL: challengeTTL,
+	}
+}
+
+// CreateChallenge registra um desafio e retorna o token opaco entregue ao cliente
+func (s *MFAStore) CreateChallenge(ctx context.Context, challenge MFAChallenge) (string, error) {
+	token, err := crypto.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(challenge)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.redis.Set(ctx, mfaChallengeKey(token), data, s.challengeTTL); err != nil {
+		return "", fmt.Errorf("failed to store MFA challenge: %w", err)
+	}
+
+	return token, nil
+}
+
+// GetChallenge retorna o desafio pendente associado ao token
+func (s *MFAStore) GetChallenge(ctx context.Context, token string) (*MFAChallenge, error) {
+	data, err := s.redis.Get(ctx, mfaChallengeKey(token))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, pkgerrors.ErrMFAChallengeInvalid
+		}
+		return nil, err
+	}
+
+	var challenge MFAChallenge
+	if err := json.Unmarshal([]byte(data), &challenge); err != nil {
+		return nil, pkgerrors.ErrMFAChallengeInvalid
+	}
+
+	return &challenge, nil
+}
+
+// RecordFailedAttempt contabiliza um código inválido e descarta o desafio ao atingir o limite
+func (s *MFAStore) RecordFailedAttempt(ctx context.Context, token string) error {
+	attempts, err := s.redis.Incr(ctx, mfaAttemptsKey(token), s.challengeTTL)
+	if err != nil {
+		return err
+	}
+
+	if attempts >= maxMFAAttempts {
+		return s.DeleteChallenge(ctx, token)
+	}
+
+	return nil
+}
+
+// DeleteChallenge consome o desafio
+func (s *MFAStore) DeleteChallenge(ctx context.Context, token string) error {
+	if err := s.redis.Delete(ctx, mfaChallengeKey(token)); err != nil {
+		return err
+	}
+	return s.redis.Delete(ctx, mfaAttemptsKey(token))
+}
+
+// MarkTOTPUsed registra o contador TOTP aceito; retorna false se já tiver sido usado
+func (s *MFAStore) MarkTOTPUsed(ctx context.Context, userID uuid.UUID, counter int64) (bool, error) {
+	key := fmt.Sprintf("totp_used:%s:%d", userID, counter)
+	return s.redis.SetNX(ctx, key, "1", totpReplayWindow)
+}
+
+func mfaChallengeKey(token string) string {
+	return "mfa_challenge:" + entity.HashToken(token)
+}
+
+func mfaAttemptsKey(token string) string {
+	return "mfa_attempts:" + entity.HashToken(token)
+}
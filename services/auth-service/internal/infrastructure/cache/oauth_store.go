@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+// AuthorizationRequest é um pedido de autorização já validado, aguardando o login
+// e o consentimento do usuário no frontend
+type AuthorizationRequest struct {
+	ClientID            uuid.UUID `json:"client_id"`
+	RedirectURI         string    `json:"redirect_uri"`
+	Scopes              []string  `json:"scopes"`
+	State               string    `json:"state"`
+	Nonce               string    `json:"nonce"`
+	CodeChallenge       string    `json:"code_challenge"`
+	CodeChallengeMethod string    `json:"code_challenge_method"`
+}
+
+// AuthorizationCode é a autorização concedida, trocada por tokens no token endpoint
+type AuthorizationCode struct {
+	ClientID    uuid.UUID `json:"client_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	RedirectURI string    `json:"redirect_uri"`
+	Scopes      []string  `json:"scopes"`
+	Nonce       string    `json:"nonce"`
+	// AuthTime é o momento do login do usuário (claim auth_time do ID token)
+	AuthTime            time.Time `json:"auth_time"`
+	CodeChallenge       string    `json:"code_challenge"`
+	CodeChallengeMethod string    `json:"code_challenge_method"`
+}
+
+// OAuthStore mantém no Redis os pedidos de autorização pendentes e os authorization
+// codes emitidos. Códigos são consumidos na leitura, garantindo uso único.
+type OAuthStore struct {
+	redis      *RedisClient
+	requestTTL time.Duration
+	codeTTL    time.Duration
+}
+
+// NewOAuthStore cria uma nova instância
+func NewOAuthStore(redisClient *RedisClient, requestTTL, codeTTL time.Duration) *OAuthStore {
+	return &OAuthStore{
+		redis:      redisClient,
+		requestTTL: requestTTL,
+		codeTTL:    codeTTL,
+	}
+}
+
+// CreateRequest guarda o pedido e retorna o identificador opaco entregue ao frontend
+func (s *OAuthStore) CreateRequest(ctx context.Context, request AuthorizationRequest) (string, error) {
+	id, err := crypto.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.set(ctx, oauthRequestKey(id), request, s.requestTTL); err != nil {
+		return "", fmt.Errorf("failed to store authorization request: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetRequest retorna o pedido pendente sem consumi-lo
+func (s *OAuthStore) GetRequest(ctx context.Context, id string) (*AuthorizationRequest, error) {
+	var request AuthorizationRequest
+	data, err := s.redis.Get(ctx, oauthRequestKey(id))
+	if err := decodeOAuthValue(data, err, &request, pkgerrors.ErrAuthorizationRequestInvalid); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// TakeRequest consome o pedido pendente
+func (s *OAuthStore) TakeRequest(ctx context.Context, id string) (*AuthorizationRequest, error) {
+	var request AuthorizationRequest
+	data, err := s.redis.GetDel(ctx, oauthRequestKey(id))
+	if err := decodeOAuthValue(data, err, &request, pkgerrors.ErrAuthorizationRequestInvalid); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// CreateCode guarda a autorização concedida e retorna o authorization code
+func (s *OAuthStore) CreateCode(ctx context.Context, code AuthorizationCode) (string, error) {
+	token, err := crypto.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.set(ctx, oauthCodeKey(token), code, s.codeTTL); err != nil {
+		return "", fmt.Errorf("failed to store authorization code: %w", err)
+	}
+
+	return token, nil
+}
+
+// TakeCode consome o authorization code; um código usado ou expirado é invalid_grant
+func (s *OAuthStore) TakeCode(ctx context.Context, code string) (*AuthorizationCode, error) {
+	var grant AuthorizationCode
+	data, err := s.redis.GetDel(ctx, oauthCodeKey(code))
+	invalid := pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidGrant, "authorization code inválido ou expirado")
+	if err := decodeOAuthValue(data, err, &grant, invalid); err != nil {
+		return nil, err
+	}
+	return &grant, nil
+}
+
+func (s *OAuthStore) set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return s.redis.Set(ctx, key, data, ttl)
+}
+
+// decodeOAuthValue interpreta o resultado da leitura no Redis; chave ausente ou
+// conteúdo corrompido resultam em notFound
+func decodeOAuthValue(data string, err error, value interface{}, notFound error) error {
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return notFound
+		}
+		return err
+	}
+
+	if err := json.Unmarshal([]byte(data), value); err != nil {
+		return notFound
+	}
+
+	return nil
+}
+
+func oauthRequestKey(id string) string {
+	return "oauth_request:" + entity.HashToken(id)
+}
+
+func oauthCodeKey(code string) string {
+	return "oauth_code:" + entity.HashToken(code)
+}
@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+func TestOAuthStoreCodeIsSingleUse(t *testing.T) {
+	store := NewOAuthStore(newTestRedisClient(t), time.Minute, time.Minute)
+	ctx := context.Background()
+
+	grant := AuthorizationCode{
+		ClientID:            uuid.New(),
+		UserID:              uuid.New(),
+		RedirectURI:         "https://app.example/callback",
+		Scopes:              []string{"openid"},
+		CodeChallenge:       "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+		CodeChallengeMethod: "S256",
+	}
+	code, err := store.CreateCode(ctx, grant)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		code      string
+		wantGrant bool
+	}{
+		{name: "first exchange returns the grant", code: code, wantGrant: true},
+		{name: "replayed code is invalid_grant", code: code, wantGrant: false},
+		{name: "unknown code is invalid_grant", code: "not-a-code", wantGrant: false},
+	}
+
+	// Os passos dependem da ordem: o primeiro consome o código
+	for _, tt := range tests {
+		got, err := store.TakeCode(ctx, tt.code)
+		if tt.wantGrant {
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.name, err)
+			}
+			if got.ClientID != grant.ClientID || got.UserID != grant.UserID || got.CodeChallenge != grant.CodeChallenge {
+				t.Fatalf("%s: expected %+v, got %+v", tt.name, grant, got)
+			}
+			continue
+		}
+
+		var oauthErr *pkgerrors.OAuthError
+		if !errors.As(err, &oauthErr) || oauthErr.Code != pkgerrors.OAuthInvalidGrant {
+			t.Fatalf("%s: expected invalid_grant, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestOAuthStoreRequestIsConsumedOnce(t *testing.T) {
+	store := NewOAuthStore(newTestRedisClient(t), time.Minute, time.Minute)
+	ctx := context.Background()
+
+	id, err := store.CreateRequest(ctx, AuthorizationRequest{ClientID: uuid.New(), State: "xyz"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// GetRequest não consome o pedido (tela de consentimento)
+	for i := 0; i < 2; i++ {
+		if _, err := store.GetRequest(ctx, id); err != nil {
+			t.Fatalf("expected pending request, got %v", err)
+		}
+	}
+
+	request, err := store.TakeRequest(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if request.State != "xyz" {
+		t.Fatalf("expected state %q, got %q", "xyz", request.State)
+	}
+
+	if _, err := store.TakeRequest(ctx, id); !errors.Is(err, pkgerrors.ErrAuthorizationRequestInvalid) {
+		t.Fatalf("expected ErrAuthorizationRequestInvalid on reuse, got %v", err)
+	}
+	if _, err := store.GetRequest(ctx, id); !errors.Is(err, pkgerrors.ErrAuthorizationRequestInvalid) {
+		t.Fatalf("expected ErrAuthorizationRequestInvalid after consumption, got %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// memorySweepInterval define a cada quantas chamadas as janelas antigas em memória são descartadas
+const memorySweepInterval = 1024
+
+// RateLimitResult é o estado do limite após contabilizar uma requisição
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset é o tempo até o fim da janela atual
+	Reset time.Duration
+}
+
+// RateLimiter aplica limites por janela deslizante. Os contadores ficam no Redis, para
+// valerem entre réplicas; se o Redis estiver indisponível, usa contadores em memória.
+type RateLimiter struct {
+	redis    *RedisClient
+	memory   *memoryWindows
+	degraded atomic.Bool
+}
+
+// NewRateLimiter cria uma nova instância. Com redisClient nil, usa apenas a memória.
+func NewRateLimiter(redisClient *RedisClient) *RateLimiter {
+	return &RateLimiter{
+		redis:  redisClient,
+		memory: &memoryWindows{windows: make(map[string]*memoryWindow)},
+	}
+}
+
+// Allow contabiliza uma requisição para key e informa se ela está dentro de limit por window.
+// A contagem é estimada pela janela atual somada à fração ainda coberta da janela anterior.
+func (l *RateLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) RateLimitResult {
+	now := time.Now()
+	index := now.UnixNano() / int64(window)
+	elapsed := time.Duration(now.UnixNano() % int64(window))
+
+	current, previous := l.count(ctx, key, index, window)
+
+	weight := 1 - float64(elapsed)/float64(window)
+	estimated := int(math.Ceil(float64(previous)*weight)) + int(current)
+
+	remaining := limit - estimated
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return RateLimitResult{
+		Allowed:   estimated <= limit,
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     window - elapsed,
+	}
+}
+
+func (l *RateLimiter) count(ctx context.Context, key string, index int64, window time.Duration) (int64, int64) {
+	if l.redis != nil {
+		current, previous, err := l.redis.CountWindow(ctx,
+			rateLimitKey(key, index),
+			rateLimitKey(key, index-1),
+			2*window,
+		)
+		if err == nil {
+			if l.degraded.CompareAndSwap(true, false) {
+				log.Println("Rate limiter: Redis available again")
+			}
+			return current, previous
+		}
+
+		if l.degraded.CompareAndSwap(false, true) {
+			log.Printf("Rate limiter: Redis unavailable, falling back to in-memory counters: %v", err)
+		}
+	}
+
+	return l.memory.count(key, index, window)
+}
+
+func rateLimitKey(key string, index int64) string {
+	return fmt.Sprintf("ratelimit:%s:%d", key, index)
+}
+
+// memoryWindows mantém os contadores quando o Redis não está disponível (apenas nesta réplica)
+type memoryWindows struct {
+	mu      sync.Mutex
+	windows map[string]*memoryWindow
+	calls   int
+}
+
+type memoryWindow struct {
+	window   time.Duration
+	index    int64
+	current  int64
+	previous int64
+}
+
+func (m *memoryWindows) count(key string, index int64, window time.Duration) (int64, int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls++
+	if m.calls%memorySweepInterval == 0 {
+		m.sweep(time.Now())
+	}
+
+	w, ok := m.windows[key]
+	switch {
+	case !ok:
+		w = &memoryWindow{window: window, index: index}
+		m.windows[key] = w
+	case w.index == index-1:
+		w.previous, w.current, w.index = w.current, 0, index
+	case w.index < index-1:
+		w.previous, w.current, w.index = 0, 0, index
+	}
+
+	w.current++
+	return w.current, w.previous
+}
+
+// sweep descarta janelas que não influenciam mais nenhuma contagem
+func (m *memoryWindows) sweep(now time.Time) {
+	for key, w := range m.windows {
+		if w.index < now.UnixNano()/int64(w.window)-1 {
+			delete(m.windows, key)
+		}
+	}
+}
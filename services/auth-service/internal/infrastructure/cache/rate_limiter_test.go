@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryWindowsSlide(t *testing.T) {
+	const window = time.Minute
+
+	// Cada passo conta uma requisição na janela index e confere os contadores
+	steps := []struct {
+		name         string
+		index        int64
+		wantCurrent  int64
+		wantPrevious int64
+	}{
+		{name: "first request opens the window", index: 10, wantCurrent: 1, wantPrevious: 0},
+		{name: "same window accumulates", index: 10, wantCurrent: 2, wantPrevious: 0},
+		{name: "next window keeps the previous count", index: 11, wantCurrent: 1, wantPrevious: 2},
+		{name: "next window again shifts", index: 12, wantCurrent: 1, wantPrevious: 1},
+		{name: "gap of more than one window resets", index: 15, wantCurrent: 1, wantPrevious: 0},
+	}
+
+	m := &memoryWindows{windows: make(map[string]*memoryWindow)}
+	for _, step := range steps {
+		current, previous := m.count("key", step.index, window)
+		if current != step.wantCurrent || previous != step.wantPrevious {
+			t.Fatalf("%s: expected (%d, %d), got (%d, %d)",
+				step.name, step.wantCurrent, step.wantPrevious, current, previous)
+		}
+	}
+}
+
+func TestMemoryWindowsSweep(t *testing.T) {
+	const window = time.Minute
+	now := time.Now()
+	index := now.UnixNano() / int64(window)
+
+	m := &memoryWindows{windows: make(map[string]*memoryWindow)}
+	m.count("current", index, window)
+	m.count("previous", index-1, window)
+	m.count("stale", index-2, window)
+
+	m.sweep(now)
+
+	for key, want := range map[string]bool{"current": true, "previous": true, "stale": false} {
+		if _, ok := m.windows[key]; ok != want {
+			t.Errorf("key %q: expected kept=%v", key, want)
+		}
+	}
+}
+
+func TestRateLimiterAllowInMemory(t *testing.T) {
+	// Janela longa o bastante para o teste não cruzar uma virada de janela
+	const window = 24 * time.Hour
+
+	tests := []struct {
+		name          string
+		limit         int
+		requests      int
+		wantAllowed   bool
+		wantRemaining int
+	}{
+		{name: "below the limit", limit: 3, requests: 1, wantAllowed: true, wantRemaining: 2},
+		{name: "at the limit", limit: 3, requests: 3, wantAllowed: true, wantRemaining: 0},
+		{name: "over the limit", limit: 3, requests: 4, wantAllowed: false, wantRemaining: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewRateLimiter(nil)
+
+			var result RateLimitResult
+			for i := 0; i < tt.requests; i++ {
+				result = limiter.Allow(context.Background(), "login:203.0.113.7", tt.limit, window)
+			}
+
+			if result.Allowed != tt.wantAllowed {
+				t.Fatalf("expected allowed=%v, got %v", tt.wantAllowed, result.Allowed)
+			}
+			if result.Remaining != tt.wantRemaining {
+				t.Fatalf("expected remaining %d, got %d", tt.wantRemaining, result.Remaining)
+			}
+			if result.Limit != tt.limit {
+				t.Fatalf("expected limit %d, got %d", tt.limit, result.Limit)
+			}
+			if result.Reset <= 0 || result.Reset > window {
+				t.Fatalf("expected reset within the window, got %s", result.Reset)
+			}
+		})
+	}
+}
+
+func TestRateLimiterKeysAreIndependent(t *testing.T) {
+	limiter := NewRateLimiter(nil)
+	ctx := context.Background()
+
+	if !limiter.Allow(ctx, "a", 1, 24*time.Hour).Allowed {
+		t.Fatal("expected first request for key a to be allowed")
+	}
+	if limiter.Allow(ctx, "a", 1, 24*time.Hour).Allowed {
+		t.Fatal("expected second request for key a to be limited")
+	}
+	if !limiter.Allow(ctx, "b", 1, 24*time.Hour).Allowed {
+		t.Fatal("expected key b to have its own counter")
+	}
+}
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -47,6 +48,11 @@ func (r *RedisClient) Get(ctx context.Context, key string) (string, error) {
 	return r.client.Get(ctx, key).Result()
 }
 
+// GetDel recupera um valor e remove a chave atomicamente
+func (r *RedisClient) GetDel(ctx context.Context, key string) (string, error) {
+	return r.client.GetDel(ctx, key).Result()
+}
+
 // Delete remove uma chave
 func (r *RedisClient) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
@@ -66,6 +72,45 @@ func (r *RedisClient) SetNX(ctx context.Context, key string, value interface{},
 	return r.client.SetNX(ctx, key, value, expiration).Result()
 }
 
+// Incr incrementa um contador, definindo o TTL na primeira incrementação
+func (r *RedisClient) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	pipe := r.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.ExpireNX(ctx, key, expiration)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+// Expire redefine o TTL de uma chave existente
+func (r *RedisClient) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	return r.client.Expire(ctx, key, expiration).Err()
+}
+
+// TTL retorna o tempo de vida restante de uma chave (negativo se não existir ou não expirar)
+func (r *RedisClient) TTL(ctx context.Context, key string) (time.Duration, error) {
+	return r.client.TTL(ctx, key).Result()
+}
+
+// CountWindow incrementa o contador da janela atual e lê o da janela anterior
+func (r *RedisClient) CountWindow(ctx context.Context, currentKey, previousKey string, expiration time.Duration) (int64, int64, error) {
+	pipe := r.client.TxPipeline()
+	current := pipe.Incr(ctx, currentKey)
+	pipe.ExpireNX(ctx, currentKey, expiration)
+	previous := pipe.Get(ctx, previousKey)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return 0, 0, err
+	}
+
+	previousCount, err := previous.Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return 0, 0, err
+	}
+
+	return current.Val(), previousCount, nil
+}
+
 // Close fecha a conexão
 func (r *RedisClient) Close() error {
 	return r.client.Close()
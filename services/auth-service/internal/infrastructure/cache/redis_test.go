@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeRedisServer é um servidor RESP2 mínimo, em memória, com os comandos de
+// chave/valor usados pelos stores (SET, GET, GETDEL, DEL). TTLs são ignorados.
+type fakeRedisServer struct {
+	mu   sync.Mutex
+	data map[string]string
+}
+
+// newTestRedisClient inicia um fakeRedisServer e retorna um RedisClient conectado a ele
+func newTestRedisClient(t *testing.T) *RedisClient {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := &fakeRedisServer{data: make(map[string]string)}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+
+	client := redis.NewClient(&redis.Options{
+		Addr:             listener.Addr().String(),
+		DisableIndentity: true,
+	})
+	t.Cleanup(func() {
+		client.Close()
+		listener.Close()
+	})
+
+	return &RedisClient{client: client}
+}
+
+func (s *fakeRedisServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readRESPCommand(reader)
+		if err != nil {
+			return
+		}
+		if _, err := io.WriteString(conn, s.exec(args)); err != nil {
+			return
+		}
+	}
+}
+
+func (s *fakeRedisServer) exec(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "SET":
+		s.data[args[1]] = args[2]
+		return "+OK\r\n"
+	case "GET":
+		return s.bulk(args[1], false)
+	case "GETDEL":
+		return s.bulk(args[1], true)
+	case "DEL":
+		removed := 0
+		for _, key := range args[1:] {
+			if _, ok := s.data[key]; ok {
+				delete(s.data, key)
+				removed++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", removed)
+	default:
+		// Inclui o HELLO: o cliente segue em RESP2
+		return "-ERR unknown command '" + args[0] + "'\r\n"
+	}
+}
+
+func (s *fakeRedisServer) bulk(key string, del bool) string {
+	value, ok := s.data[key]
+	if !ok {
+		return "$-1\r\n"
+	}
+	if del {
+		delete(s.data, key)
+	}
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+}
+
+// readRESPCommand lê um comando no formato de array de bulk strings
+func readRESPCommand(reader *bufio.Reader) ([]string, error) {
+	header, err := readRESPLine(reader, '*')
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, header)
+	for i := range args {
+		size, err := readRESPLine(reader, '$')
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+
+	if len(args) == 0 {
+		return nil, fmt.Errorf("empty command")
+	}
+	return args, nil
+}
+
+func readRESPLine(reader *bufio.Reader, prefix byte) (int, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return 0, err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if len(line) == 0 || line[0] != prefix {
+		return 0, fmt.Errorf("unexpected RESP line %q", line)
+	}
+	return strconv.Atoi(line[1:])
+}
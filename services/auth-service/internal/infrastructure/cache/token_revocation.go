@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	"github.com/redis/go-redis/v9"
+)
+
+// TokenRevocationList mantém no Redis os access tokens revogados antes de expirarem
+type TokenRevocationList struct {
+	redis          *RedisClient
+	accessTokenTTL time.Duration
+}
+
+// NewTokenRevocationList cria uma nova instância. accessTokenTTL define por quanto
+// tempo uma revogação por usuário precisa ser lembrada.
+func NewTokenRevocationList(redisClient *RedisClient, accessTokenTTL time.Duration) *TokenRevocationList {
+	return &TokenRevocationList{
+		redis:          redisClient,
+		accessTokenTTL: accessTokenTTL,
+	}
+}
+
+// RevokeToken revoga um access token específico (jti) pelo tempo de vida restante
+func (t *TokenRevocationList) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if tokenID == "" || ttl <= 0 {
+		return nil
+	}
+	return t.redis.BlacklistToken(ctx, tokenID, ttl)
+}
+
+// legacySecondsCutoff separa revogações gravadas em segundos por versões anteriores
+// (~1,7e9) das gravadas em milissegundos (~1,7e12)
+const legacySecondsCutoff = 1e11
+
+// RevokeUserTokens revoga todos os access tokens do usuário emitidos até o milissegundo
+// atual, comparados pela claim iat_ms
+func (t *TokenRevocationList) RevokeUserTokens(ctx context.Context, userID uuid.UUID) error {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	return t.redis.Set(ctx, userRevokedBeforeKey(userID), now, t.accessTokenTTL)
+}
+
+// RevokeSession revoga todos os access tokens emitidos para uma sessão (sid)
+func (t *TokenRevocationList) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	return t.redis.Set(ctx, sessionRevokedKey(sessionID.String()), "1", t.accessTokenTTL)
+}
+
+// IsRevoked verifica se o token foi revogado individualmente, pela sessão ou por revogação do usuário
+func (t *TokenRevocationList) IsRevoked(ctx context.Context, claims *crypto.Claims) (bool, error) {
+	if claims.ID != "" {
+		blacklisted, err := t.redis.IsTokenBlacklisted(ctx, claims.ID)
+		if err != nil {
+			return false, err
+		}
+		if blacklisted {
+			return true, nil
+		}
+	}
+
+	if claims.SessionID != "" {
+		revoked, err := t.redis.Exists(ctx, sessionRevokedKey(claims.SessionID))
+		if err != nil {
+			return false, err
+		}
+		if revoked {
+			return true, nil
+		}
+	}
+
+	// Tokens de serviço não têm usuário; valem até expirar ou serem revogados pelo jti
+	if claims.IsClient() {
+		return false, nil
+	}
+
+	value, err := t.redis.Get(ctx, userRevokedBeforeKey(claims.UserID))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	revokedAt, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("invalid revocation timestamp: %w", err)
+	}
+	if revokedAt < legacySecondsCutoff {
+		revokedAt = revokedAt*1000 + 999
+	}
+
+	return claims.IssuedAtMs() <= revokedAt, nil
+}
+
+func sessionRevokedKey(sessionID string) string {
+	return fmt.Sprintf("revoked_session:%s", sessionID)
+}
+
+func userRevokedBeforeKey(userID uuid.UUID) string {
+	return fmt.Sprintf("revoked_before:%s", userID)
+}
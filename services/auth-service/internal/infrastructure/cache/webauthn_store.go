@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+// WebAuthnStore mantém no Redis o estado das cerimônias WebAuthn em andamento.
+// Cada estado é consumido na leitura, impedindo a reutilização de um desafio.
+type WebAuthnStore struct {
+	redis *RedisClient
+	ttl   time.Duration
+}
+
+// NewWebAuthnStore cria uma nova instância
+func NewWebAuthnStore(redisClient *RedisClient, ttl time.Duration) *WebAuthnStore {
+	return &WebAuthnStore{
+		redis: redisClient,
+		ttl:   ttl,
+	}
+}
+
+// SaveRegistration guarda o estado do registro de passkey em andamento do usuário
+func (s *WebAuthnStore) SaveRegistration(ctx context.Context, userID uuid.UUID, session []byte) error {
+	if err := s.redis.Set(ctx, webAuthnRegistrationKey(userID), session, s.ttl); err != nil {
+		return fmt.Errorf("failed to store WebAuthn registration: %w", err)
+	}
+	return nil
+}
+
+// TakeRegistration consome o estado do registro em andamento do usuário
+func (s *WebAuthnStore) TakeRegistration(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	return s.take(ctx, webAuthnRegistrationKey(userID))
+}
+
+// CreateLogin guarda o estado de um login e retorna o token opaco entregue ao cliente
+func (s *WebAuthnStore) CreateLogin(ctx context.Context, session []byte) (string, error) {
+	token, err := crypto.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.redis.Set(ctx, webAuthnLoginKey(token), session, s.ttl); err != nil {
+		return "", fmt.Errorf("failed to store WebAuthn login: %w", err)
+	}
+
+	return token, nil
+}
+
+// TakeLogin consome o estado do login associado ao token
+func (s *WebAuthnStore) TakeLogin(ctx context.Context, token string) ([]byte, error) {
+	return s.take(ctx, webAuthnLoginKey(token))
+}
+
+func (s *WebAuthnStore) take(ctx context.Context, key string) ([]byte, error) {
+	data, err := s.redis.GetDel(ctx, key)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, pkgerrors.ErrPasskeyChallengeInvalid
+		}
+		return nil, err
+	}
+
+	return []byte(data), nil
+}
+
+func webAuthnRegistrationKey(userID uuid.UUID) string {
+	return "webauthn_registration:" + userID.String()
+}
+
+func webAuthnLoginKey(token string) string {
+	return "webauthn_login:" + entity.HashToken(token)
+}
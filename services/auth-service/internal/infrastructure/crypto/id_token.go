@@ -0,0 +1,82 @@
+package crypto
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// IDTokenClaims são as claims do ID token do OpenID Connect. As claims de perfil
+// só são preenchidas quando os escopos correspondentes foram concedidos.
+type IDTokenClaims struct {
+	Nonce     string           `json:"nonce,omitempty"`
+	AuthTime  *jwt.NumericDate `json:"auth_time,omitempty"`
+	SessionID string           `json:"sid,omitempty"`
+	Email     string           `json:"email,omitempty"`
+	// EmailVerified indica que o emissor confirmou a posse do email. Este serviço não
+	// confirma os emails cadastrados e não o emite; é usado pelo provedor de testes.
+	EmailVerified bool   `json:"email_verified,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Role          string `json:"role,omitempty"`
+	UpdatedAt     int64  `json:"updated_at,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// IDToken reúne os dados de um ID token a ser emitido
+type IDToken struct {
+	Issuer        string
+	Subject       string
+	Audience      string
+	Nonce         string
+	AuthTime      time.Time
+	SessionID     string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Role          string
+	UpdatedAt     int64
+}
+
+// GenerateIDToken assina o ID token emitido pelo issuer ao cliente (aud) com validade ttl
+func (j *JWTService) GenerateIDToken(idToken IDToken, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := IDTokenClaims{
+		Nonce:         idToken.Nonce,
+		SessionID:     idToken.SessionID,
+		Email:         idToken.Email,
+		EmailVerified: idToken.EmailVerified,
+		Name:          idToken.Name,
+		Role:          idToken.Role,
+		UpdatedAt:     idToken.UpdatedAt,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    idToken.Issuer,
+			Subject:   idToken.Subject,
+			Audience:  jwt.ClaimStrings{idToken.Audience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        uuid.New().String(),
+		},
+	}
+	if !idToken.AuthTime.IsZero() {
+		claims.AuthTime = jwt.NewNumericDate(idToken.AuthTime)
+	}
+
+	return j.sign(claims)
+}
+
+// ParseIDTokenHint valida a assinatura e o issuer de um ID token emitido por este
+// serviço, aceitando tokens já expirados (id_token_hint do end_session_endpoint)
+func (j *JWTService) ParseIDTokenHint(tokenString, issuer string) (*IDTokenClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &IDTokenClaims{}, j.keyFunc, jwt.WithoutClaimsValidation())
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*IDTokenClaims)
+	if !ok || !token.Valid || claims.Issuer != issuer {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
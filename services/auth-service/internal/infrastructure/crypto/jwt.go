@@ -13,47 +13,121 @@ var (
 	ErrExpiredToken = errors.New("token expirado")
 )
 
+// Tipos de sujeito (claim sub_type) dos access tokens
+const (
+	SubjectTypeUser   = "user"
+	SubjectTypeClient = "client"
+)
+
 // Claims customizado para JWT
 type Claims struct {
-	UserID uuid.UUID `json:"user_id"`
-	Email  string    `json:"email"`
-	Role   string    `json:"role"`
+	UserID    uuid.UUID `json:"user_id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	SessionID string    `json:"sid,omitempty"`
+	// ClientID e Scope identificam tokens emitidos a um cliente OAuth
+	ClientID string `json:"client_id,omitempty"`
+	Scope    string `json:"scope,omitempty"`
+	// SubjectType distingue tokens de usuário e de serviço (client_credentials);
+	// tokens sem a claim foram emitidos a usuários
+	SubjectType string `json:"sub_type,omitempty"`
+	// IssuedAtMillis é o iat em milissegundos, usado na revogação por usuário
+	IssuedAtMillis int64 `json:"iat_ms,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// IsClient indica se o token foi emitido ao próprio cliente, sem usuário
+func (c *Claims) IsClient() bool {
+	return c.SubjectType == SubjectTypeClient
+}
+
+// IssuedAtMs retorna o momento de emissão em milissegundos. Tokens emitidos antes da
+// claim iat_ms são tratados como emitidos no fim do segundo do iat.
+func (c *Claims) IssuedAtMs() int64 {
+	if c.IssuedAtMillis > 0 {
+		return c.IssuedAtMillis
+	}
+	if c.IssuedAt == nil {
+		return 0
+	}
+	return c.IssuedAt.Unix()*1000 + 999
+}
+
 // JWTService lida com criação e validação de tokens JWT
 type JWTService struct {
-	secret             []byte
+	keyRing            *KeyRing
 	accessTokenExpiry  time.Duration
 	refreshTokenExpiry time.Duration
 }
 
 // NewJWTService cria uma nova instância
-func NewJWTService(secret string, accessExpiry, refreshExpiry time.Duration) *JWTService {
+func NewJWTService(keyRing *KeyRing, accessExpiry, refreshExpiry time.Duration) *JWTService {
 	return &JWTService{
-		secret:             []byte(secret),
+		keyRing:            keyRing,
 		accessTokenExpiry:  accessExpiry,
 		refreshTokenExpiry: refreshExpiry,
 	}
 }
 
-// GenerateAccessToken gera um access token JWT
-func (j *JWTService) GenerateAccessToken(userID uuid.UUID, email, role string) (string, error) {
+// GenerateAccessToken gera um access token JWT vinculado à sessão (sid)
+func (j *JWTService) GenerateAccessToken(userID uuid.UUID, email, role string, sessionID uuid.UUID) (string, error) {
+	return j.GenerateOAuthAccessToken(userID, email, role, sessionID, "", "")
+}
+
+// GenerateOAuthAccessToken gera um access token vinculado à sessão e emitido ao
+// cliente OAuth clientID com os escopos concedidos (vazios para login direto na API)
+func (j *JWTService) GenerateOAuthAccessToken(userID uuid.UUID, email, role string, sessionID uuid.UUID, clientID, scope string) (string, error) {
 	now := time.Now()
 	claims := Claims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
+		UserID:      userID,
+		Email:       email,
+		Role:        role,
+		SessionID:   sessionID.String(),
+		ClientID:    clientID,
+		Scope:       scope,
+		SubjectType: SubjectTypeUser,
+		// Precisão de milissegundos para que a revogação por usuário alcance tokens
+		// emitidos no mesmo segundo
+		IssuedAtMillis: now.UnixMilli(),
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(j.accessTokenExpiry)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 			Subject:   userID.String(),
+			ID:        uuid.New().String(),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(j.secret)
+	return j.sign(claims)
+}
+
+// GenerateClientAccessToken gera um access token em nome do próprio cliente
+// (client_credentials), com sub igual ao client_id. ttl zero usa a validade padrão.
+func (j *JWTService) GenerateClientAccessToken(clientID uuid.UUID, scope string, ttl time.Duration) (string, time.Duration, error) {
+	if ttl <= 0 {
+		ttl = j.accessTokenExpiry
+	}
+
+	now := time.Now()
+	claims := Claims{
+		ClientID:    clientID.String(),
+		Scope:       scope,
+		SubjectType: SubjectTypeClient,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Subject:   clientID.String(),
+			ID:        uuid.New().String(),
+		},
+	}
+
+	token, err := j.sign(claims)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return token, ttl, nil
 }
 
 // GenerateRefreshToken gera um refresh token
@@ -69,8 +143,7 @@ func (j *JWTService) GenerateRefreshToken(userID uuid.UUID) (string, time.Time,
 		ID:        uuid.New().String(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(j.secret)
+	tokenString, err := j.sign(claims)
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -80,12 +153,7 @@ func (j *JWTService) GenerateRefreshToken(userID uuid.UUID) (string, time.Time,
 
 // ValidateAccessToken valida e extrai claims do access token
 func (j *JWTService) ValidateAccessToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrInvalidToken
-		}
-		return j.secret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, j.keyFunc)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -99,17 +167,17 @@ func (j *JWTService) ValidateAccessToken(tokenString string) (*Claims, error) {
 		return nil, ErrInvalidToken
 	}
 
+	// Tokens de usuário sem user_id (ex.: ID tokens, assinados com a mesma chave) não são access tokens
+	if !claims.IsClient() && claims.UserID == uuid.Nil {
+		return nil, ErrInvalidToken
+	}
+
 	return claims, nil
 }
 
 // ValidateRefreshToken valida refresh token e retorna user ID
 func (j *JWTService) ValidateRefreshToken(tokenString string) (uuid.UUID, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrInvalidToken
-		}
-		return j.secret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, j.keyFunc)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -140,3 +208,47 @@ func (j *JWTService) GetAccessTokenExpiry() time.Duration {
 func (j *JWTService) GetRefreshTokenExpiry() time.Duration {
 	return j.refreshTokenExpiry
 }
+
+// JWKS retorna as chaves públicas usadas para verificar os tokens emitidos,
+// incluindo as chaves anteriores ainda dentro da janela de validade
+func (j *JWTService) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	for _, key := range j.keyRing.Keys() {
+		if jwk, ok := key.JWK(); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
+
+// KeyRing retorna o keyring usado para assinatura
+func (j *JWTService) KeyRing() *KeyRing {
+	return j.keyRing
+}
+
+// sign assina as claims com a chave ativa, incluindo o header kid
+func (j *JWTService) sign(claims jwt.Claims) (string, error) {
+	key := j.keyRing.Active()
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.privateKey)
+}
+
+// keyFunc seleciona a chave de verificação pelo kid e impede troca de algoritmo.
+// Tokens sem kid (emitidos antes do keyring) são verificados com a chave ativa.
+func (j *JWTService) keyFunc(token *jwt.Token) (interface{}, error) {
+	key := j.keyRing.Active()
+	if kid, ok := token.Header["kid"].(string); ok {
+		found, exists := j.keyRing.Lookup(kid)
+		if !exists {
+			return nil, ErrInvalidToken
+		}
+		key = found
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, ErrInvalidToken
+	}
+
+	return key.publicKey, nil
+}
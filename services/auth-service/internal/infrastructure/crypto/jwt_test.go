@@ -0,0 +1,70 @@
+package crypto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func newTestJWTService(t *testing.T) *JWTService {
+	t.Helper()
+	key, err := GenerateSigningKey(DefaultKeyAlgorithm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewJWTService(NewStaticKeyRing(key), 15*time.Minute, time.Hour)
+}
+
+func TestAccessTokenCarriesMillisecondIssuedAt(t *testing.T) {
+	jwtService := newTestJWTService(t)
+
+	before := time.Now().UnixMilli()
+	token, err := jwtService.GenerateAccessToken(uuid.New(), "user@example.com", "viewer", uuid.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := time.Now().UnixMilli()
+
+	claims, err := jwtService.ValidateAccessToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := claims.IssuedAtMs(); got < before || got > after {
+		t.Fatalf("expected iat_ms between %d and %d, got %d", before, after, got)
+	}
+}
+
+func TestIssuedAtMs(t *testing.T) {
+	issuedAt := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name   string
+		claims Claims
+		want   int64
+	}{
+		{
+			name:   "millisecond claim",
+			claims: Claims{IssuedAtMillis: 1700000000123, RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(issuedAt)}},
+			want:   1700000000123,
+		},
+		{
+			name:   "legacy token counts as end of its second",
+			claims: Claims{RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(issuedAt)}},
+			want:   1700000000999,
+		},
+		{
+			name: "no iat",
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.claims.IssuedAtMs(); got != tt.want {
+				t.Fatalf("expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
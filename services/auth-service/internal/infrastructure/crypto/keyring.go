@@ -0,0 +1,264 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ErrKeyNotFound            = errors.New("chave não encontrada")
+	ErrNoActiveKey            = errors.New("nenhuma chave de assinatura ativa")
+	ErrKeyRotationUnsupported = errors.New("rotação de chaves requer JWT_KEYS_DIR")
+	ErrRetireActiveKey        = errors.New("a chave ativa não pode ser aposentada; faça a rotação antes")
+	ErrRetireSigningKey       = errors.New("a chave de assinatura não pode ser aposentada antes da ativação da próxima")
+)
+
+// KeyRing mantém uma chave ativa de assinatura e N chaves apenas de verificação
+type KeyRing struct {
+	mu     sync.RWMutex
+	active *SigningKey
+	keys   map[string]*SigningKey
+	store  *FileKeyStore
+}
+
+// NewStaticKeyRing cria um keyring imutável com uma única chave
+func NewStaticKeyRing(key *SigningKey) *KeyRing {
+	return &KeyRing{
+		active: key,
+		keys:   map[string]*SigningKey{key.ID: key},
+	}
+}
+
+// NewFileKeyRing cria um keyring persistido em disco. Se o diretório estiver
+// vazio, a chave inicial é obtida de bootstrap (importada ou gerada).
+func NewFileKeyRing(store *FileKeyStore, bootstrap func() (*SigningKey, error)) (*KeyRing, error) {
+	k := &KeyRing{store: store}
+
+	state, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Active == nil {
+		key, err := bootstrap()
+		if err != nil {
+			return nil, err
+		}
+		key.NotBefore = time.Now()
+
+		state = &KeyRingState{Active: key, Keys: []*SigningKey{key}}
+		if err := store.Save(state); err != nil {
+			return nil, err
+		}
+	}
+
+	k.apply(state)
+	return k, nil
+}
+
+// Active retorna a chave usada para assinar novos tokens: a mais recente já em vigor.
+// Uma chave recém-rotacionada só assina a partir do not-before.
+func (k *KeyRing) Active() *SigningKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return signingKey(slices.Collect(maps.Values(k.keys)), k.active, time.Now())
+}
+
+// Lookup retorna a chave de verificação com o kid informado, se não estiver aposentada.
+// Chaves ainda antes do not-before são aceitas, para tolerar diferenças de relógio
+// entre as instâncias na ativação.
+func (k *KeyRing) Lookup(kid string) (*SigningKey, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	key, ok := k.keys[kid]
+	if !ok || key.IsRetiredAt(time.Now()) {
+		return nil, false
+	}
+	return key, true
+}
+
+// Keys retorna todas as chaves não aposentadas, ordenadas por not-before
+func (k *KeyRing) Keys() []*SigningKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	now := time.Now()
+	keys := make([]*SigningKey, 0, len(k.keys))
+	for _, key := range k.keys {
+		if !key.IsRetiredAt(now) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].NotBefore.Before(keys[j].NotBefore)
+	})
+	return keys
+}
+
+// Rotate gera uma nova chave, publicada imediatamente e usada para assinar apenas após
+// activateAfter, para que as demais instâncias a carreguem antes de receber tokens
+// assinados com ela. A chave anterior continua válida para verificação até retireAfter
+// depois da ativação, para não invalidar tokens já emitidos.
+func (k *KeyRing) Rotate(alg string, activateAfter, retireAfter time.Duration) (*SigningKey, error) {
+	if k.store == nil {
+		return nil, ErrKeyRotationUnsupported
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	// Partir do estado persistido para não sobrescrever rotações feitas por outra instância
+	state, err := k.store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if alg == "" && state.Active != nil {
+		alg = state.Active.Method.Alg()
+	}
+
+	key, err := GenerateSigningKey(alg)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	key.NotBefore = now.Add(activateAfter)
+
+	// A chave que assina hoje e uma eventual chave ainda pendente deixam de assinar
+	// na ativação da nova
+	retireAt := key.NotBefore.Add(retireAfter)
+	for _, previous := range []*SigningKey{signingKey(state.Keys, state.Active, now), state.Active} {
+		if previous != nil && (previous.RetireAt == nil || previous.RetireAt.After(retireAt)) {
+			previous.RetireAt = &retireAt
+		}
+	}
+
+	state.Active = key
+	state.Keys = append(state.Keys, key)
+
+	if err := k.store.Save(state); err != nil {
+		return nil, err
+	}
+
+	k.applyLocked(state)
+	return key, nil
+}
+
+// Retire aposenta imediatamente uma chave de verificação
+func (k *KeyRing) Retire(kid string) error {
+	if k.store == nil {
+		return ErrKeyRotationUnsupported
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	state, err := k.store.Load()
+	if err != nil {
+		return err
+	}
+
+	if state.Active != nil && state.Active.ID == kid {
+		return fmt.Errorf("%w: %s", ErrRetireActiveKey, kid)
+	}
+	if current := signingKey(state.Keys, state.Active, time.Now()); current != nil && current.ID == kid {
+		return fmt.Errorf("%w: %s", ErrRetireSigningKey, kid)
+	}
+
+	for _, key := range state.Keys {
+		if key.ID == kid {
+			now := time.Now()
+			key.RetireAt = &now
+			if err := k.store.Save(state); err != nil {
+				return err
+			}
+			k.applyLocked(state)
+			return nil
+		}
+	}
+
+	return ErrKeyNotFound
+}
+
+// Reload relê o keyring do disco (ex.: após rotação feita pelo cmd/keys)
+func (k *KeyRing) Reload() error {
+	if k.store == nil {
+		return nil
+	}
+
+	state, err := k.store.Load()
+	if err != nil {
+		return err
+	}
+	if state.Active == nil {
+		return ErrNoActiveKey
+	}
+
+	k.apply(state)
+	return nil
+}
+
+// WatchReload recarrega o keyring periodicamente até o contexto ser cancelado
+func (k *KeyRing) WatchReload(ctx context.Context, interval time.Duration) {
+	if k.store == nil || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := k.Reload(); err != nil {
+				log.Printf("Failed to reload signing keys: %v", err)
+			}
+		}
+	}
+}
+
+func (k *KeyRing) apply(state *KeyRingState) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.applyLocked(state)
+}
+
+func (k *KeyRing) applyLocked(state *KeyRingState) {
+	now := time.Now()
+	keys := make(map[string]*SigningKey, len(state.Keys))
+	for _, key := range state.Keys {
+		if !key.IsRetiredAt(now) {
+			keys[key.ID] = key
+		}
+	}
+
+	k.active = state.Active
+	k.keys = keys
+}
+
+// signingKey escolhe, entre as chaves não aposentadas já em vigor, a de not-before mais
+// recente. Sem nenhuma em vigor, usa fallback (a última chave gerada).
+func signingKey(keys []*SigningKey, fallback *SigningKey, now time.Time) *SigningKey {
+	var current *SigningKey
+	for _, key := range keys {
+		if key.IsActiveAt(now) && (current == nil || key.NotBefore.After(current.NotBefore)) {
+			current = key
+		}
+	}
+	if current == nil {
+		return fallback
+	}
+	return current
+}
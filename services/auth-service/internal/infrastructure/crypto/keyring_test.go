@@ -0,0 +1,175 @@
+package crypto
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestFileKeyRing(t *testing.T, dir string) *KeyRing {
+	t.Helper()
+	keyRing, err := NewFileKeyRing(NewFileKeyStore(dir), func() (*SigningKey, error) {
+		return GenerateSigningKey(DefaultKeyAlgorithm)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyRing
+}
+
+func TestKeyRingRotationWindows(t *testing.T) {
+	tests := []struct {
+		name          string
+		activateAfter time.Duration
+		retireAfter   time.Duration
+		wantNewActive bool
+		wantOldValid  bool
+	}{
+		{name: "new key pending activation", activateAfter: time.Hour, retireAfter: time.Hour, wantNewActive: false, wantOldValid: true},
+		{name: "new key active, old key in grace period", activateAfter: 0, retireAfter: time.Hour, wantNewActive: true, wantOldValid: true},
+		{name: "new key active, grace period over", activateAfter: 0, retireAfter: -time.Second, wantNewActive: true, wantOldValid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyRing := newTestFileKeyRing(t, t.TempDir())
+			old := keyRing.Active()
+
+			key, err := keyRing.Rotate("", tt.activateAfter, tt.retireAfter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if key.Method.Alg() != old.Method.Alg() {
+				t.Fatalf("expected rotation to keep %s, got %s", old.Method.Alg(), key.Method.Alg())
+			}
+
+			// A nova chave é publicada para verificação desde a rotação
+			if _, ok := keyRing.Lookup(key.ID); !ok {
+				t.Fatal("expected new key to be published")
+			}
+
+			wantActive := old.ID
+			if tt.wantNewActive {
+				wantActive = key.ID
+			}
+			if got := keyRing.Active().ID; got != wantActive {
+				t.Fatalf("expected signing key %s, got %s", wantActive, got)
+			}
+
+			if _, ok := keyRing.Lookup(old.ID); ok != tt.wantOldValid {
+				t.Fatalf("expected old key valid=%v", tt.wantOldValid)
+			}
+
+			published := make(map[string]bool)
+			for _, k := range keyRing.Keys() {
+				published[k.ID] = true
+			}
+			if !published[key.ID] || published[old.ID] != tt.wantOldValid {
+				t.Fatalf("unexpected published keys %v", published)
+			}
+		})
+	}
+}
+
+func TestKeyRingRetire(t *testing.T) {
+	tests := []struct {
+		name          string
+		activateAfter time.Duration
+		retire        func(old, rotated *SigningKey) string
+		wantErr       error
+	}{
+		{
+			name:          "latest key cannot be retired",
+			activateAfter: time.Hour,
+			retire:        func(old, rotated *SigningKey) string { return rotated.ID },
+			wantErr:       ErrRetireActiveKey,
+		},
+		{
+			name:          "signing key cannot be retired before the next is active",
+			activateAfter: time.Hour,
+			retire:        func(old, rotated *SigningKey) string { return old.ID },
+			wantErr:       ErrRetireSigningKey,
+		},
+		{
+			name:          "previous key can be retired after activation",
+			activateAfter: 0,
+			retire:        func(old, rotated *SigningKey) string { return old.ID },
+			wantErr:       nil,
+		},
+		{
+			name:          "unknown key",
+			activateAfter: 0,
+			retire:        func(old, rotated *SigningKey) string { return "missing" },
+			wantErr:       ErrKeyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyRing := newTestFileKeyRing(t, t.TempDir())
+			old := keyRing.Active()
+
+			rotated, err := keyRing.Rotate("", tt.activateAfter, time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			kid := tt.retire(old, rotated)
+			err = keyRing.Retire(kid)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+
+			if tt.wantErr == nil {
+				if _, ok := keyRing.Lookup(kid); ok {
+					t.Fatalf("expected key %s to be retired", kid)
+				}
+			}
+		})
+	}
+}
+
+func TestKeyRingPersistsAcrossInstances(t *testing.T) {
+	dir := t.TempDir()
+	first := newTestFileKeyRing(t, dir)
+
+	rotated, err := first.Rotate("", time.Hour, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Outra instância lendo o mesmo diretório vê a rotação sem gerar nova chave inicial
+	second, err := NewFileKeyRing(NewFileKeyStore(dir), func() (*SigningKey, error) {
+		t.Fatal("bootstrap must not run for an existing keyring")
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := second.Active().ID, first.Active().ID; got != want {
+		t.Fatalf("expected signing key %s, got %s", want, got)
+	}
+	if _, ok := second.Lookup(rotated.ID); !ok {
+		t.Fatal("expected rotated key to be loaded")
+	}
+	if got := len(second.Keys()); got != 2 {
+		t.Fatalf("expected 2 published keys, got %d", got)
+	}
+}
+
+func TestStaticKeyRingRejectsRotation(t *testing.T) {
+	key, err := GenerateSigningKey(DefaultKeyAlgorithm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyRing := NewStaticKeyRing(key)
+
+	if _, err := keyRing.Rotate("", 0, time.Hour); !errors.Is(err, ErrKeyRotationUnsupported) {
+		t.Fatalf("expected ErrKeyRotationUnsupported from Rotate, got %v", err)
+	}
+	if err := keyRing.Retire(key.ID); !errors.Is(err, ErrKeyRotationUnsupported) {
+		t.Fatalf("expected ErrKeyRotationUnsupported from Retire, got %v", err)
+	}
+}
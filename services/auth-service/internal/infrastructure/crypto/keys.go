@@ -0,0 +1,338 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// DefaultKeyAlgorithm é usado ao gerar chaves sem algoritmo configurado
+const DefaultKeyAlgorithm = "ES256"
+
+var (
+	ErrUnsupportedKey       = errors.New("tipo de chave não suportado")
+	ErrAlgorithmKeyMismatch = errors.New("algoritmo incompatível com a chave")
+)
+
+// SigningKey representa uma chave usada para assinar e verificar tokens
+type SigningKey struct {
+	ID         string
+	Method     jwt.SigningMethod
+	NotBefore  time.Time
+	RetireAt   *time.Time
+	privateKey interface{}
+	publicKey  interface{}
+}
+
+// NewHMACSigningKey cria uma chave simétrica HS256 a partir de um segredo compartilhado
+func NewHMACSigningKey(kid string, secret []byte) *SigningKey {
+	return &SigningKey{
+		ID:         kid,
+		Method:     jwt.SigningMethodHS256,
+		privateKey: secret,
+		publicKey:  secret,
+	}
+}
+
+// NewAsymmetricSigningKey cria uma chave assimétrica (RSA, ECDSA ou Ed25519).
+// Se alg for vazio, o algoritmo é inferido a partir do tipo da chave.
+// Se kid for vazio, é usado o thumbprint RFC 7638 da chave pública.
+func NewAsymmetricSigningKey(kid, alg string, privateKey crypto.Signer) (*SigningKey, error) {
+	method, err := signingMethodForKey(alg, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &SigningKey{
+		ID:         kid,
+		Method:     method,
+		privateKey: privateKey,
+		publicKey:  privateKey.Public(),
+	}
+
+	if key.ID == "" {
+		jwk, _ := key.JWK()
+		key.ID = jwk.Thumbprint()
+	}
+
+	return key, nil
+}
+
+// LoadSigningKeyFromFile carrega uma chave privada PEM do disco
+func LoadSigningKeyFromFile(path, alg, kid string) (*SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+
+	privateKey, err := ParsePrivateKeyPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key %s: %w", path, err)
+	}
+
+	return NewAsymmetricSigningKey(kid, alg, privateKey)
+}
+
+// GenerateSigningKey gera um novo par de chaves para o algoritmo informado
+func GenerateSigningKey(alg string) (*SigningKey, error) {
+	var (
+		privateKey crypto.Signer
+		err        error
+	)
+
+	switch alg {
+	case "RS256", "RS384", "RS512", "PS256", "PS384", "PS512":
+		privateKey, err = rsa.GenerateKey(rand.Reader, 3072)
+	case "ES256":
+		privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "ES384":
+		privateKey, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case "ES512":
+		privateKey, err = ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	case "EdDSA":
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKey, alg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	return NewAsymmetricSigningKey("", alg, privateKey)
+}
+
+// ParsePrivateKeyPEM decodifica uma chave privada nos formatos PKCS#8, PKCS#1 ou SEC 1
+func ParsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid PEM data")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, ErrUnsupportedKey
+		}
+		return signer, nil
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	return nil, ErrUnsupportedKey
+}
+
+// EncodePrivateKeyPEM codifica uma chave privada em PEM (PKCS#8)
+func EncodePrivateKeyPEM(privateKey crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// IsActiveAt indica se a chave pode ser usada para verificação no instante informado
+func (k *SigningKey) IsActiveAt(t time.Time) bool {
+	if t.Before(k.NotBefore) {
+		return false
+	}
+	return !k.IsRetiredAt(t)
+}
+
+// IsRetiredAt indica se a chave já foi aposentada no instante informado
+func (k *SigningKey) IsRetiredAt(t time.Time) bool {
+	return k.RetireAt != nil && !t.Before(*k.RetireAt)
+}
+
+// IsAsymmetric indica se a chave pode ser publicada no JWKS
+func (k *SigningKey) IsAsymmetric() bool {
+	_, isHMAC := k.Method.(*jwt.SigningMethodHMAC)
+	return !isHMAC
+}
+
+// JWK retorna a representação pública da chave; chaves simétricas não são publicadas
+func (k *SigningKey) JWK() (JWK, bool) {
+	jwk := JWK{
+		Kid: k.ID,
+		Use: "sig",
+		Alg: k.Method.Alg(),
+	}
+
+	switch pub := k.publicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64URL(pub.N.Bytes())
+		jwk.E = base64URL(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		jwk.Kty = "EC"
+		jwk.Crv = pub.Curve.Params().Name
+		jwk.X = base64URL(pub.X.FillBytes(make([]byte, size)))
+		jwk.Y = base64URL(pub.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64URL(pub)
+	default:
+		return JWK{}, false
+	}
+
+	return jwk, true
+}
+
+// JWK representa uma JSON Web Key pública (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// PublicKey decodifica a chave pública representada pelo JWK (RSA, EC ou OKP Ed25519)
+func (j JWK) PublicKey() (crypto.PublicKey, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(j.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(j.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		curve := map[string]elliptic.Curve{
+			"P-256": elliptic.P256(),
+			"P-384": elliptic.P384(),
+			"P-521": elliptic.P521(),
+		}[j.Crv]
+		if curve == nil {
+			return nil, fmt.Errorf("%w: curve %s", ErrUnsupportedKey, j.Crv)
+		}
+		x, errX := base64.RawURLEncoding.DecodeString(j.X)
+		y, errY := base64.RawURLEncoding.DecodeString(j.Y)
+		if errX != nil || errY != nil {
+			return nil, errors.New("invalid EC coordinates")
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, errors.New("EC point is not on curve")
+		}
+		return key, nil
+	case "OKP":
+		if j.Crv != "Ed25519" {
+			return nil, fmt.Errorf("%w: curve %s", ErrUnsupportedKey, j.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedKey, j.Kty)
+}
+
+// JWKSet representa um conjunto de chaves públicas (RFC 7517)
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// Thumbprint calcula o thumbprint SHA-256 da chave (RFC 7638)
+func (j JWK) Thumbprint() string {
+	var members interface{}
+	switch j.Kty {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{j.E, j.Kty, j.N}
+	case "EC":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{j.Crv, j.Kty, j.X, j.Y}
+	default:
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{j.Crv, j.Kty, j.X}
+	}
+
+	data, _ := json.Marshal(members)
+	sum := sha256.Sum256(data)
+	return base64URL(sum[:])
+}
+
+func signingMethodForKey(alg string, key crypto.Signer) (jwt.SigningMethod, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if alg == "" {
+			alg = jwt.SigningMethodRS256.Alg()
+		}
+		switch alg {
+		case "RS256", "RS384", "RS512", "PS256", "PS384", "PS512":
+			return jwt.GetSigningMethod(alg), nil
+		}
+	case *ecdsa.PrivateKey:
+		expected := map[elliptic.Curve]string{
+			elliptic.P256(): "ES256",
+			elliptic.P384(): "ES384",
+			elliptic.P521(): "ES512",
+		}[k.Curve]
+		if expected == "" {
+			return nil, ErrUnsupportedKey
+		}
+		if alg == "" {
+			alg = expected
+		}
+		if alg == expected {
+			return jwt.GetSigningMethod(alg), nil
+		}
+	case ed25519.PrivateKey:
+		if alg == "" || alg == jwt.SigningMethodEdDSA.Alg() {
+			return jwt.SigningMethodEdDSA, nil
+		}
+	default:
+		return nil, ErrUnsupportedKey
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrAlgorithmKeyMismatch, alg)
+}
+
+func base64URL(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
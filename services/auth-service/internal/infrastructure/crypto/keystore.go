@@ -0,0 +1,184 @@
+package crypto
+
+import (
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const keyRingManifestFile = "keyring.json"
+
+// KeyRingState representa o conteúdo persistido do keyring
+type KeyRingState struct {
+	Active *SigningKey
+	Keys   []*SigningKey
+}
+
+type keyRingManifest struct {
+	ActiveKeyID string             `json:"active_kid"`
+	Keys        []keyManifestEntry `json:"keys"`
+}
+
+type keyManifestEntry struct {
+	ID        string     `json:"kid"`
+	Algorithm string     `json:"alg"`
+	NotBefore time.Time  `json:"not_before"`
+	RetireAt  *time.Time `json:"retire_at,omitempty"`
+}
+
+// FileKeyStore persiste o keyring em um diretório: keyring.json + <kid>.pem
+type FileKeyStore struct {
+	dir string
+}
+
+// NewFileKeyStore cria uma nova instância
+func NewFileKeyStore(dir string) *FileKeyStore {
+	return &FileKeyStore{dir: dir}
+}
+
+// Load lê o manifesto e as chaves privadas do diretório
+func (s *FileKeyStore) Load() (*KeyRingState, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, keyRingManifestFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &KeyRingState{}, nil
+		}
+		return nil, fmt.Errorf("falha ao ler o manifesto do keyring: %w", err)
+	}
+
+	var manifest keyRingManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("falha ao interpretar o manifesto do keyring: %w", err)
+	}
+
+	state := &KeyRingState{}
+	for _, entry := range manifest.Keys {
+		key, err := LoadSigningKeyFromFile(s.keyPath(entry.ID), entry.Algorithm, entry.ID)
+		if err != nil {
+			return nil, err
+		}
+		key.NotBefore = entry.NotBefore
+		key.RetireAt = entry.RetireAt
+
+		state.Keys = append(state.Keys, key)
+		if key.ID == manifest.ActiveKeyID {
+			state.Active = key
+		}
+	}
+
+	if manifest.ActiveKeyID != "" && state.Active == nil {
+		return nil, fmt.Errorf("%w: chave ativa %s", ErrKeyNotFound, manifest.ActiveKeyID)
+	}
+
+	return state, nil
+}
+
+// Save grava as chaves novas e substitui o manifesto de forma atômica
+func (s *FileKeyStore) Save(state *KeyRingState) error {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("falha ao criar o diretório de chaves: %w", err)
+	}
+
+	manifest := keyRingManifest{}
+	if state.Active != nil {
+		manifest.ActiveKeyID = state.Active.ID
+	}
+
+	for _, key := range state.Keys {
+		path := s.keyPath(key.ID)
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			signer, ok := key.privateKey.(crypto.Signer)
+			if !ok {
+				return ErrUnsupportedKey
+			}
+			pemData, err := EncodePrivateKeyPEM(signer)
+			if err != nil {
+				return fmt.Errorf("falha ao codificar a chave %s: %w", key.ID, err)
+			}
+			if err := writeFileAtomic(path, pemData, 0o600); err != nil {
+				return err
+			}
+		}
+
+		manifest.Keys = append(manifest.Keys, keyManifestEntry{
+			ID:        key.ID,
+			Algorithm: key.Method.Alg(),
+			NotBefore: key.NotBefore,
+			RetireAt:  key.RetireAt,
+		})
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filepath.Join(s.dir, keyRingManifestFile), data, 0o600)
+}
+
+// Prune remove do disco as chaves já aposentadas
+func (s *FileKeyStore) Prune(now time.Time) ([]string, error) {
+	state, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		kept    []*SigningKey
+		removed []string
+	)
+	for _, key := range state.Keys {
+		if key.IsRetiredAt(now) && key != state.Active {
+			removed = append(removed, key.ID)
+			continue
+		}
+		kept = append(kept, key)
+	}
+
+	if len(removed) == 0 {
+		return nil, nil
+	}
+
+	state.Keys = kept
+	if err := s.Save(state); err != nil {
+		return nil, err
+	}
+
+	for _, kid := range removed {
+		if err := os.Remove(s.keyPath(kid)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return removed, err
+		}
+	}
+
+	return removed, nil
+}
+
+func (s *FileKeyStore) keyPath(kid string) string {
+	return filepath.Join(s.dir, kid+".pem")
+}
+
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("falha ao criar arquivo temporário: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
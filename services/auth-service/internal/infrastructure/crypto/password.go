@@ -1,31 +1,57 @@
 package crypto
 
-import (
-	"golang.org/x/crypto/bcrypt"
-)
+// dummyPassword gera o hash fictício usado quando a conta não existe
+const dummyPassword = "titanwatch-dummy-password"
 
-// PasswordService lida com hashing e verificação de senhas
+// PasswordService lida com hashing e verificação de senhas. Novos hashes usam o
+// hasher configurado; hashes de qualquer algoritmo suportado continuam sendo aceitos.
 type PasswordService struct {
-	cost int
+	hasher    PasswordHasher
+	verifiers []PasswordHasher
+	dummyHash string
 }
 
-// NewPasswordService cria uma nova instância
-func NewPasswordService() *PasswordService {
+// NewPasswordService cria uma nova instância que gera hashes com hasher
+func NewPasswordService(hasher PasswordHasher) *PasswordService {
+	// O hash fictício usa os mesmos parâmetros, para que a comparação leve o mesmo tempo
+	dummyHash, _ := hasher.Hash(dummyPassword)
+
+	// Os parâmetros de verificação vêm do próprio hash, então instâncias padrão bastam
+	argon2Verifier, _ := NewArgon2idHasher(Argon2idParams{})
+	bcryptVerifier, _ := NewBcryptHasher(0)
+
 	return &PasswordService{
-		cost: bcrypt.DefaultCost,
+		hasher:    hasher,
+		verifiers: []PasswordHasher{hasher, argon2Verifier, bcryptVerifier},
+		dummyHash: dummyHash,
 	}
 }
 
 // Hash gera um hash da senha
 func (p *PasswordService) Hash(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), p.cost)
-	if err != nil {
-		return "", err
-	}
-	return string(bytes), nil
+	return p.hasher.Hash(password)
 }
 
 // Compare verifica se a senha corresponde ao hash
 func (p *PasswordService) Compare(hashedPassword, password string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	for _, verifier := range p.verifiers {
+		if verifier.Recognizes(hashedPassword) {
+			return verifier.Verify(hashedPassword, password)
+		}
+	}
+	return errUnknownHashFormat
+}
+
+// NeedsRehash indica se o hash deve ser regerado com o algoritmo e os parâmetros atuais
+func (p *PasswordService) NeedsRehash(hashedPassword string) bool {
+	if !p.hasher.Recognizes(hashedPassword) {
+		return true
+	}
+	return p.hasher.NeedsRehash(hashedPassword)
+}
+
+// CompareDummy executa uma comparação equivalente a Compare contra um hash fictício.
+// Usado quando a conta não existe, para que o tempo de resposta não revele emails cadastrados.
+func (p *PasswordService) CompareDummy(password string) {
+	_ = p.Compare(p.dummyHash, password)
 }
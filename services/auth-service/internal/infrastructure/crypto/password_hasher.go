@@ -0,0 +1,231 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Algoritmos de hash de senha suportados
+const (
+	PasswordAlgorithmArgon2id = "argon2id"
+	PasswordAlgorithmBcrypt   = "bcrypt"
+)
+
+const (
+	argon2idPrefix   = "$argon2id$"
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+
+	// Limites para hashes lidos do banco, evitando custo excessivo com um hash adulterado
+	maxArgon2Memory      = 4 * 1024 * 1024
+	maxArgon2Time        = 64
+	maxArgon2Parallelism = 64
+)
+
+var (
+	errPasswordMismatch  = errors.New("password does not match")
+	errUnknownHashFormat = errors.New("unknown password hash format")
+	errInvalidArgon2Hash = errors.New("invalid argon2id hash")
+
+	argon2Encoding      = base64.RawStdEncoding
+	bcryptPrefixes      = []string{"$2a$", "$2b$", "$2y$"}
+	defaultArgon2Params = Argon2idParams{Memory: 64 * 1024, Time: 3, Parallelism: 2}
+)
+
+// PasswordHasher gera e verifica hashes de senha de um algoritmo.
+// Os hashes são autodescritivos: identificam o algoritmo e os parâmetros usados.
+type PasswordHasher interface {
+	// Hash gera o hash da senha com os parâmetros configurados
+	Hash(password string) (string, error)
+	// Recognizes indica se o hash foi gerado por este algoritmo
+	Recognizes(encoded string) bool
+	// Verify compara a senha com o hash, usando os parâmetros gravados nele
+	Verify(encoded, password string) error
+	// NeedsRehash indica se o hash usa parâmetros diferentes dos configurados
+	NeedsRehash(encoded string) bool
+}
+
+// Argon2idParams são os parâmetros de custo do argon2id
+type Argon2idParams struct {
+	// Memory em KiB
+	Memory      uint32
+	Time        uint32
+	Parallelism uint8
+}
+
+// NewPasswordHasher cria o hasher do algoritmo informado ("argon2id" ou "bcrypt")
+func NewPasswordHasher(algorithm string, argon2Params Argon2idParams, bcryptCost int) (PasswordHasher, error) {
+	switch strings.ToLower(algorithm) {
+	case "", PasswordAlgorithmArgon2id:
+		return NewArgon2idHasher(argon2Params)
+	case PasswordAlgorithmBcrypt:
+		return NewBcryptHasher(bcryptCost)
+	default:
+		return nil, fmt.Errorf("unsupported password hash algorithm: %s", algorithm)
+	}
+}
+
+// Argon2idHasher gera hashes argon2id no formato PHC:
+// $argon2id$v=19$m=<memória>,t=<iterações>,p=<paralelismo>$<salt>$<hash>
+type Argon2idHasher struct {
+	params Argon2idParams
+}
+
+// NewArgon2idHasher cria um hasher argon2id; parâmetros zerados usam os padrões (64 MiB, t=3, p=2)
+func NewArgon2idHasher(params Argon2idParams) (*Argon2idHasher, error) {
+	if params.Memory == 0 {
+		params.Memory = defaultArgon2Params.Memory
+	}
+	if params.Time == 0 {
+		params.Time = defaultArgon2Params.Time
+	}
+	if params.Parallelism == 0 {
+		params.Parallelism = defaultArgon2Params.Parallelism
+	}
+
+	if params.Memory < 8*uint32(params.Parallelism) || params.Memory > maxArgon2Memory {
+		return nil, fmt.Errorf("invalid argon2id memory: %d KiB", params.Memory)
+	}
+
+	return &Argon2idHasher{params: params}, nil
+}
+
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Time, h.params.Memory, h.params.Parallelism, argon2KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		h.params.Memory,
+		h.params.Time,
+		h.params.Parallelism,
+		argon2Encoding.EncodeToString(salt),
+		argon2Encoding.EncodeToString(key),
+	), nil
+}
+
+func (h *Argon2idHasher) Recognizes(encoded string) bool {
+	return strings.HasPrefix(encoded, argon2idPrefix)
+}
+
+func (h *Argon2idHasher) Verify(encoded, password string) error {
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return err
+	}
+
+	candidate := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(candidate, key) != 1 {
+		return errPasswordMismatch
+	}
+
+	return nil
+}
+
+func (h *Argon2idHasher) NeedsRehash(encoded string) bool {
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return true
+	}
+
+	return params != h.params || len(salt) != argon2SaltLength || uint32(len(key)) != argon2KeyLength
+}
+
+// decodeArgon2id extrai parâmetros, salt e hash de um hash PHC argon2id
+func decodeArgon2id(encoded string) (Argon2idParams, []byte, []byte, error) {
+	var params Argon2idParams
+
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, hash
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != PasswordAlgorithmArgon2id {
+		return params, nil, nil, errInvalidArgon2Hash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, errInvalidArgon2Hash
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Parallelism); err != nil {
+		return params, nil, nil, errInvalidArgon2Hash
+	}
+
+	if params.Memory == 0 || params.Memory > maxArgon2Memory ||
+		params.Time == 0 || params.Time > maxArgon2Time ||
+		params.Parallelism == 0 || params.Parallelism > maxArgon2Parallelism {
+		return params, nil, nil, errInvalidArgon2Hash
+	}
+
+	salt, err := argon2Encoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, errInvalidArgon2Hash
+	}
+
+	key, err := argon2Encoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, errInvalidArgon2Hash
+	}
+
+	return params, salt, key, nil
+}
+
+// BcryptHasher gera hashes bcrypt ($2a$<custo>$...). Senhas acima de 72 bytes são
+// recusadas, pois o bcrypt as truncaria silenciosamente.
+type BcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher cria um hasher bcrypt; custo zero usa bcrypt.DefaultCost
+func NewBcryptHasher(cost int) (*BcryptHasher, error) {
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("invalid bcrypt cost: %d", cost)
+	}
+
+	return &BcryptHasher{cost: cost}, nil
+}
+
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+			return "", pkgerrors.ErrPasswordTooLong
+		}
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (h *BcryptHasher) Recognizes(encoded string) bool {
+	for _, prefix := range bcryptPrefixes {
+		if strings.HasPrefix(encoded, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *BcryptHasher) Verify(encoded, password string) error {
+	return bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+}
+
+func (h *BcryptHasher) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err != nil || cost != h.cost
+}
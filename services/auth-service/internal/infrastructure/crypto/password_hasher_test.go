@@ -0,0 +1,115 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Parâmetros baixos mantêm os testes rápidos
+var testArgon2Params = Argon2idParams{Memory: 64, Time: 1, Parallelism: 1}
+
+func newTestArgon2Hasher(t *testing.T, params Argon2idParams) *Argon2idHasher {
+	t.Helper()
+	hasher, err := NewArgon2idHasher(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hasher
+}
+
+func TestArgon2idHashAndVerify(t *testing.T) {
+	hasher := newTestArgon2Hasher(t, testArgon2Params)
+
+	encoded, err := hasher.Hash("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(encoded, "$argon2id$v=19$m=64,t=1,p=1$") {
+		t.Fatalf("unexpected encoding %q", encoded)
+	}
+
+	if err := hasher.Verify(encoded, "correct horse"); err != nil {
+		t.Fatalf("expected password to match, got %v", err)
+	}
+	if err := hasher.Verify(encoded, "wrong horse"); err == nil {
+		t.Fatal("expected wrong password to be rejected")
+	}
+}
+
+func TestArgon2idNeedsRehash(t *testing.T) {
+	current := newTestArgon2Hasher(t, testArgon2Params)
+	encoded, err := current.Hash("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		params  Argon2idParams
+		encoded string
+		want    bool
+	}{
+		{name: "same parameters", params: testArgon2Params, encoded: encoded, want: false},
+		{name: "more memory", params: Argon2idParams{Memory: 128, Time: 1, Parallelism: 1}, encoded: encoded, want: true},
+		{name: "more iterations", params: Argon2idParams{Memory: 64, Time: 2, Parallelism: 1}, encoded: encoded, want: true},
+		{name: "more parallelism", params: Argon2idParams{Memory: 64, Time: 1, Parallelism: 2}, encoded: encoded, want: true},
+		{name: "malformed hash", params: testArgon2Params, encoded: "$argon2id$v=19$m=64$salt$key", want: true},
+		{name: "oversized memory", params: testArgon2Params, encoded: "$argon2id$v=19$m=99999999,t=1,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hasher := newTestArgon2Hasher(t, tt.params)
+			if got := hasher.NeedsRehash(tt.encoded); got != tt.want {
+				t.Fatalf("expected NeedsRehash=%v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPasswordServiceMigratesHashes(t *testing.T) {
+	bcryptHasher, err := NewBcryptHasher(bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy, err := bcryptHasher.Hash("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	weakArgon2, err := newTestArgon2Hasher(t, testArgon2Params).Hash("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := NewPasswordService(newTestArgon2Hasher(t, Argon2idParams{Memory: 128, Time: 1, Parallelism: 1}))
+	current, err := service.Hash("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		encoded     string
+		wantRehash  bool
+		wantCompare bool
+	}{
+		{name: "bcrypt hash is accepted and migrated", encoded: legacy, wantRehash: true, wantCompare: true},
+		{name: "argon2id with old parameters is migrated", encoded: weakArgon2, wantRehash: true, wantCompare: true},
+		{name: "current hash is kept", encoded: current, wantRehash: false, wantCompare: true},
+		{name: "unknown format is rejected", encoded: "plaintext", wantRehash: true, wantCompare: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := service.NeedsRehash(tt.encoded); got != tt.wantRehash {
+				t.Fatalf("expected NeedsRehash=%v, got %v", tt.wantRehash, got)
+			}
+			if got := service.Compare(tt.encoded, "correct horse") == nil; got != tt.wantCompare {
+				t.Fatalf("expected Compare match=%v, got %v", tt.wantCompare, got)
+			}
+		})
+	}
+}
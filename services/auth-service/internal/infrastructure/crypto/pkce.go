@@ -0,0 +1,43 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"regexp"
+)
+
+// PKCEMethodS256 é o único método de PKCE aceito; "plain" não protege contra interceptação
+const PKCEMethodS256 = "S256"
+
+// pkceVerifierPattern segue a RFC 7636, seção 4.1
+var pkceVerifierPattern = regexp.MustCompile(`^[A-Za-z0-9\-._~]{43,128}$`)
+
+// ValidCodeChallenge verifica se o code_challenge é um SHA-256 codificado em base64url
+func ValidCodeChallenge(challenge string) bool {
+	decoded, err := base64.RawURLEncoding.DecodeString(challenge)
+	return err == nil && len(decoded) == sha256.Size
+}
+
+// VerifyPKCE confere o code_verifier apresentado no token endpoint com o
+// code_challenge (S256) recebido no pedido de autorização
+func VerifyPKCE(challenge, verifier string) bool {
+	if !pkceVerifierPattern.MatchString(verifier) {
+		return false
+	}
+
+	computed := PKCEChallengeS256(verifier)
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
+
+// GeneratePKCEVerifier gera um code_verifier aleatório, usado quando este serviço é
+// o cliente (login federado)
+func GeneratePKCEVerifier() (string, error) {
+	return GenerateOpaqueToken()
+}
+
+// PKCEChallengeS256 calcula o code_challenge S256 de um code_verifier
+func PKCEChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
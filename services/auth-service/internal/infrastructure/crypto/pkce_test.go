@@ -0,0 +1,76 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+)
+
+// Vetor do apêndice B da RFC 7636
+const (
+	rfcVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	rfcChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+func TestPKCEChallengeS256(t *testing.T) {
+	if got := PKCEChallengeS256(rfcVerifier); got != rfcChallenge {
+		t.Fatalf("expected %q, got %q", rfcChallenge, got)
+	}
+}
+
+func TestVerifyPKCE(t *testing.T) {
+	tests := []struct {
+		name      string
+		challenge string
+		verifier  string
+		want      bool
+	}{
+		{name: "RFC 7636 example", challenge: rfcChallenge, verifier: rfcVerifier, want: true},
+		{name: "wrong verifier", challenge: rfcChallenge, verifier: strings.Repeat("a", 43), want: false},
+		{name: "plain method is not accepted", challenge: rfcVerifier, verifier: rfcVerifier, want: false},
+		{name: "verifier too short", challenge: PKCEChallengeS256(strings.Repeat("a", 42)), verifier: strings.Repeat("a", 42), want: false},
+		{name: "verifier too long", challenge: PKCEChallengeS256(strings.Repeat("a", 129)), verifier: strings.Repeat("a", 129), want: false},
+		{name: "verifier with invalid characters", challenge: PKCEChallengeS256(strings.Repeat("a", 42) + "+"), verifier: strings.Repeat("a", 42) + "+", want: false},
+		{name: "minimum length verifier", challenge: PKCEChallengeS256(strings.Repeat("a", 43)), verifier: strings.Repeat("a", 43), want: true},
+		{name: "empty verifier", challenge: rfcChallenge, verifier: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyPKCE(tt.challenge, tt.verifier); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidCodeChallenge(t *testing.T) {
+	tests := []struct {
+		name      string
+		challenge string
+		want      bool
+	}{
+		{name: "S256 challenge", challenge: rfcChallenge, want: true},
+		{name: "padded base64", challenge: rfcChallenge + "=", want: false},
+		{name: "too short", challenge: "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw", want: false},
+		{name: "not base64url", challenge: strings.Repeat("*", 43), want: false},
+		{name: "empty", challenge: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidCodeChallenge(tt.challenge); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGeneratePKCEVerifierIsValid(t *testing.T) {
+	verifier, err := GeneratePKCEVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyPKCE(PKCEChallengeS256(verifier), verifier) {
+		t.Fatalf("expected generated verifier %q to satisfy RFC 7636", verifier)
+	}
+}
@@ -0,0 +1,19 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+)
+
+// opaqueTokenSize é o número de bytes aleatórios de um token opaco (256 bits)
+const opaqueTokenSize = 32
+
+// GenerateOpaqueToken gera um token aleatório de uso único, codificado em base64url
+func GenerateOpaqueToken() (string, error) {
+	b := make([]byte, opaqueTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
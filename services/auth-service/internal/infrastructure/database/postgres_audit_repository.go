@@ -0,0 +1,39 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+)
+
+type PostgresAuditRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresAuditRepository(db *sql.DB) *PostgresAuditRepository {
+	return &PostgresAuditRepository{db: db}
+}
+
+func (r *PostgresAuditRepository) Create(ctx context.Context, event *entity.AuditEvent) error {
+	query := `
+		INSERT INTO audit_events (id, event_type, user_id, metadata, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	metadata, err := json.Marshal(event.Metadata)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		event.ID,
+		event.Type,
+		event.UserID,
+		metadata,
+		event.CreatedAt,
+	)
+
+	return err
+}
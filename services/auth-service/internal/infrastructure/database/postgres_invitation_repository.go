@@ -0,0 +1,204 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+const invitationColumns = `id, email, role, token_hash, invited_by, expires_at, created_at, accepted_at, accepted_user_id, revoked_at`
+
+type PostgresInvitationRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresInvitationRepository(db *sql.DB) *PostgresInvitationRepository {
+	return &PostgresInvitationRepository{db: db}
+}
+
+func (r *PostgresInvitationRepository) Create(ctx context.Context, invitation *entity.Invitation) error {
+	query := `
+		INSERT INTO invitations (id, email, role, token_hash, invited_by, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		invitation.ID,
+		invitation.Email,
+		invitation.Role,
+		invitation.TokenHash,
+		invitation.InvitedBy,
+		invitation.ExpiresAt,
+		invitation.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *PostgresInvitationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Invitation, error) {
+	query := `SELECT ` + invitationColumns + ` FROM invitations WHERE id = $1`
+
+	invitation, err := scanInvitation(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkgerrors.ErrInvitationNotFound
+		}
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+func (r *PostgresInvitationRepository) GetByToken(ctx context.Context, token string) (*entity.Invitation, error) {
+	query := `SELECT ` + invitationColumns + ` FROM invitations WHERE token_hash = $1`
+
+	invitation, err := scanInvitation(r.db.QueryRowContext(ctx, query, entity.HashToken(token)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkgerrors.ErrInvitationNotFound
+		}
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+func (r *PostgresInvitationRepository) List(ctx context.Context, limit, offset int) ([]*entity.Invitation, error) {
+	query := `
+		SELECT ` + invitationColumns + `
+		FROM invitations
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations []*entity.Invitation
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+func (r *PostgresInvitationRepository) Count(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM invitations`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *PostgresInvitationRepository) Accept(ctx context.Context, invitation *entity.Invitation, user *entity.User) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Consumir o convite primeiro: a condição garante o uso único mesmo com requisições
+	// concorrentes, e um convite revogado ou já aceito não chega a criar a conta
+	result, err := tx.ExecContext(ctx, `
+		UPDATE invitations
+		SET accepted_at = $2
+		WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+	`, invitation.ID, invitation.AcceptedAt)
+	if err != nil {
+		return err
+	}
+	if err := expectInvitationRow(result); err != nil {
+		return err
+	}
+
+	if err := insertUser(ctx, tx, user); err != nil {
+		return err
+	}
+
+	// accepted_user_id referencia users, por isso só é gravado após a criação do usuário
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE invitations SET accepted_user_id = $2 WHERE id = $1`,
+		invitation.ID, invitation.AcceptedUserID,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *PostgresInvitationRepository) Revoke(ctx context.Context, invitation *entity.Invitation) error {
+	query := `
+		UPDATE invitations
+		SET revoked_at = $2
+		WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, invitation.ID, invitation.RevokedAt)
+	if err != nil {
+		return err
+	}
+
+	return expectInvitationRow(result)
+}
+
+func expectInvitationRow(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return pkgerrors.ErrInvitationNotPending
+	}
+
+	return nil
+}
+
+func scanInvitation(row rowScanner) (*entity.Invitation, error) {
+	invitation := &entity.Invitation{}
+	var invitedBy, acceptedUserID uuid.NullUUID
+
+	err := row.Scan(
+		&invitation.ID,
+		&invitation.Email,
+		&invitation.Role,
+		&invitation.TokenHash,
+		&invitedBy,
+		&invitation.ExpiresAt,
+		&invitation.CreatedAt,
+		&invitation.AcceptedAt,
+		&acceptedUserID,
+		&invitation.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if invitedBy.Valid {
+		invitation.InvitedBy = invitedBy.UUID
+	}
+	if acceptedUserID.Valid {
+		invitation.AcceptedUserID = &acceptedUserID.UUID
+	}
+
+	return invitation, nil
+}
@@ -0,0 +1,143 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+	"github.com/lib/pq"
+)
+
+const oauthClientColumns = `id, name, secret_hash, redirect_uris, post_logout_redirect_uris, scopes, grant_types, access_token_ttl_seconds, skip_consent, is_active, created_at, updated_at`
+
+type PostgresOAuthClientRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresOAuthClientRepository(db *sql.DB) *PostgresOAuthClientRepository {
+	return &PostgresOAuthClientRepository{db: db}
+}
+
+func (r *PostgresOAuthClientRepository) Create(ctx context.Context, client *entity.OAuthClient) error {
+	query := `
+		INSERT INTO oauth_clients (id, name, secret_hash, redirect_uris, post_logout_redirect_uris, scopes, grant_types, access_token_ttl_seconds, skip_consent, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		client.ID,
+		client.Name,
+		sql.NullString{String: client.SecretHash, Valid: client.SecretHash != ""},
+		textArray(client.RedirectURIs),
+		textArray(client.PostLogoutRedirectURIs),
+		textArray(client.Scopes),
+		textArray(client.GrantTypes),
+		int(client.AccessTokenTTL.Seconds()),
+		client.SkipConsent,
+		client.IsActive,
+		client.CreatedAt,
+		client.UpdatedAt,
+	)
+
+	return err
+}
+
+func (r *PostgresOAuthClientRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.OAuthClient, error) {
+	query := `SELECT ` + oauthClientColumns + ` FROM oauth_clients WHERE id = $1`
+
+	client, err := scanOAuthClient(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkgerrors.ErrOAuthClientNotFound
+		}
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (r *PostgresOAuthClientRepository) List(ctx context.Context) ([]*entity.OAuthClient, error) {
+	query := `SELECT ` + oauthClientColumns + ` FROM oauth_clients ORDER BY created_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var clients []*entity.OAuthClient
+	for rows.Next() {
+		client, err := scanOAuthClient(rows)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, client)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return clients, nil
+}
+
+func (r *PostgresOAuthClientRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM oauth_clients WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return pkgerrors.ErrOAuthClientNotFound
+	}
+
+	return nil
+}
+
+func scanOAuthClient(row rowScanner) (*entity.OAuthClient, error) {
+	client := &entity.OAuthClient{}
+	var secretHash sql.NullString
+	var accessTokenTTL int
+
+	err := row.Scan(
+		&client.ID,
+		&client.Name,
+		&secretHash,
+		pq.Array(&client.RedirectURIs),
+		pq.Array(&client.PostLogoutRedirectURIs),
+		pq.Array(&client.Scopes),
+		pq.Array(&client.GrantTypes),
+		&accessTokenTTL,
+		&client.SkipConsent,
+		&client.IsActive,
+		&client.CreatedAt,
+		&client.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	client.SecretHash = secretHash.String
+	client.AccessTokenTTL = time.Duration(accessTokenTTL) * time.Second
+
+	return client, nil
+}
+
+// textArray converte a lista para TEXT[]; nil vira um array vazio, já que as colunas são NOT NULL
+func textArray(values []string) interface{} {
+	if values == nil {
+		values = []string{}
+	}
+	return pq.Array(values)
+}
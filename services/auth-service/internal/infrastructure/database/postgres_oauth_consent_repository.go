@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+	"github.com/lib/pq"
+)
+
+type PostgresOAuthConsentRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresOAuthConsentRepository(db *sql.DB) *PostgresOAuthConsentRepository {
+	return &PostgresOAuthConsentRepository{db: db}
+}
+
+func (r *PostgresOAuthConsentRepository) Get(ctx context.Context, userID, clientID uuid.UUID) (*entity.OAuthConsent, error) {
+	query := `
+		SELECT user_id, client_id, scopes, created_at, updated_at
+		FROM oauth_consents
+		WHERE user_id = $1 AND client_id = $2
+	`
+
+	consent := &entity.OAuthConsent{}
+	err := r.db.QueryRowContext(ctx, query, userID, clientID).Scan(
+		&consent.UserID,
+		&consent.ClientID,
+		pq.Array(&consent.Scopes),
+		&consent.CreatedAt,
+		&consent.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkgerrors.ErrOAuthConsentNotFound
+		}
+		return nil, err
+	}
+
+	return consent, nil
+}
+
+func (r *PostgresOAuthConsentRepository) Save(ctx context.Context, consent *entity.OAuthConsent) error {
+	query := `
+		INSERT INTO oauth_consents (user_id, client_id, scopes, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, client_id) DO UPDATE
+		SET scopes = EXCLUDED.scopes, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		consent.UserID,
+		consent.ClientID,
+		textArray(consent.Scopes),
+		consent.CreatedAt,
+		consent.UpdatedAt,
+	)
+
+	return err
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+type PostgresPasswordHistoryRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresPasswordHistoryRepository(db *sql.DB) *PostgresPasswordHistoryRepository {
+	return &PostgresPasswordHistoryRepository{db: db}
+}
+
+func (r *PostgresPasswordHistoryRepository) Add(ctx context.Context, userID uuid.UUID, passwordHash string) error {
+	query := `INSERT INTO password_history (user_id, password_hash) VALUES ($1, $2)`
+
+	_, err := r.db.ExecContext(ctx, query, userID, passwordHash)
+	return err
+}
+
+func (r *PostgresPasswordHistoryRepository) GetRecent(ctx context.Context, userID uuid.UUID, limit int) ([]string, error) {
+	query := `
+		SELECT password_hash
+		FROM password_history
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return hashes, nil
+}
+
+func (r *PostgresPasswordHistoryRepository) Prune(ctx context.Context, userID uuid.UUID, keep int) error {
+	query := `
+		DELETE FROM password_history
+		WHERE user_id = $1 AND id NOT IN (
+			SELECT id FROM password_history
+			WHERE user_id = $1
+			ORDER BY created_at DESC, id DESC
+			LIMIT $2
+		)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, keep)
+	return err
+}
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type PostgresPasswordResetRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresPasswordResetRepository(db *sql.DB) *PostgresPasswordResetRepository {
+	return &PostgresPasswordResetRepository{db: db}
+}
+
+func (r *PostgresPasswordResetRepository) Create(ctx context.Context, reset *entity.PasswordReset) error {
+	query := `
+		INSERT INTO password_resets (id, user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		reset.ID,
+		reset.UserID,
+		reset.TokenHash,
+		reset.ExpiresAt,
+		reset.CreatedAt,
+	)
+
+	return err
+}
+
+func (r *PostgresPasswordResetRepository) GetByToken(ctx context.Context, token string) (*entity.PasswordReset, error) {
+	query := `
+		SELECT id, user_id, token_hash, expires_at, created_at, used_at
+		FROM password_resets
+		WHERE token_hash = $1
+	`
+
+	reset := &entity.PasswordReset{}
+	err := r.db.QueryRowContext(ctx, query, entity.HashToken(token)).Scan(
+		&reset.ID,
+		&reset.UserID,
+		&reset.TokenHash,
+		&reset.ExpiresAt,
+		&reset.CreatedAt,
+		&reset.UsedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkgerrors.ErrResetTokenInvalid
+		}
+		return nil, err
+	}
+
+	return reset, nil
+}
+
+func (r *PostgresPasswordResetRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	// A condição garante o uso único mesmo com requisições concorrentes
+	query := `
+		UPDATE password_resets
+		SET used_at = NOW()
+		WHERE id = $1 AND used_at IS NULL AND expires_at > NOW()
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return pkgerrors.ErrResetTokenInvalid
+	}
+
+	return nil
+}
+
+func (r *PostgresPasswordResetRepository) InvalidateByUserID(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE password_resets
+		SET used_at = NOW()
+		WHERE user_id = $1 AND used_at IS NULL
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
+
+func (r *PostgresPasswordResetRepository) DeleteExpired(ctx context.Context) error {
+	query := `DELETE FROM password_resets WHERE expires_at < NOW()`
+
+	_, err := r.db.ExecContext(ctx, query)
+	return err
+}
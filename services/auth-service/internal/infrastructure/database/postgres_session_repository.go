@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
@@ -19,42 +20,35 @@ func NewPostgresSessionRepository(db *sql.DB) *PostgresSessionRepository {
 }
 
 func (r *PostgresSessionRepository) Create(ctx context.Context, session *entity.Session) error {
+	return insertSession(ctx, r.db, session)
+}
+
+func (r *PostgresSessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Session, error) {
 	query := `
-		INSERT INTO sessions (id, user_id, refresh_token, expires_at, created_at, is_revoked, revoked_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		SELECT id, user_id, family_id, client_id, scope, refresh_token_hash, ip_address, user_agent, expires_at, created_at, last_used_at, is_revoked, revoked_at, replaced_by
+		FROM sessions
+		WHERE id = $1
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
-		session.ID,
-		session.UserID,
-		session.RefreshToken,
-		session.ExpiresAt,
-		session.CreatedAt,
-		session.IsRevoked,
-		session.RevokedAt,
-	)
+	session, err := scanSession(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkgerrors.ErrSessionNotFound
+		}
+		return nil, err
+	}
 
-	return err
+	return session, nil
 }
 
 func (r *PostgresSessionRepository) GetByRefreshToken(ctx context.Context, refreshToken string) (*entity.Session, error) {
 	query := `
-		SELECT id, user_id, refresh_token, expires_at, created_at, is_revoked, revoked_at
+		SELECT id, user_id, family_id, client_id, scope, refresh_token_hash, ip_address, user_agent, expires_at, created_at, last_used_at, is_revoked, revoked_at, replaced_by
 		FROM sessions
-		WHERE refresh_token = $1
+		WHERE refresh_token_hash = $1
 	`
 
-	session := &entity.Session{}
-	err := r.db.QueryRowContext(ctx, query, refreshToken).Scan(
-		&session.ID,
-		&session.UserID,
-		&session.RefreshToken,
-		&session.ExpiresAt,
-		&session.CreatedAt,
-		&session.IsRevoked,
-		&session.RevokedAt,
-	)
-
+	session, err := scanSession(r.db.QueryRowContext(ctx, query, entity.HashRefreshToken(refreshToken)))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, pkgerrors.ErrSessionNotFound
@@ -67,9 +61,9 @@ func (r *PostgresSessionRepository) GetByRefreshToken(ctx context.Context, refre
 
 func (r *PostgresSessionRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Session, error) {
 	query := `
-		SELECT id, user_id, refresh_token, expires_at, created_at, is_revoked, revoked_at
+		SELECT id, user_id, family_id, client_id, scope, refresh_token_hash, ip_address, user_agent, expires_at, created_at, last_used_at, is_revoked, revoked_at, replaced_by
 		FROM sessions
-		WHERE user_id = $1
+		WHERE user_id = $1 AND replaced_by IS NULL
 		ORDER BY created_at DESC
 	`
 
@@ -81,16 +75,7 @@ func (r *PostgresSessionRepository) GetByUserID(ctx context.Context, userID uuid
 
 	var sessions []*entity.Session
 	for rows.Next() {
-		session := &entity.Session{}
-		err := rows.Scan(
-			&session.ID,
-			&session.UserID,
-			&session.RefreshToken,
-			&session.ExpiresAt,
-			&session.CreatedAt,
-			&session.IsRevoked,
-			&session.RevokedAt,
-		)
+		session, err := scanSession(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -107,7 +92,7 @@ func (r *PostgresSessionRepository) GetByUserID(ctx context.Context, userID uuid
 func (r *PostgresSessionRepository) Update(ctx context.Context, session *entity.Session) error {
 	query := `
 		UPDATE sessions
-		SET is_revoked = $2, revoked_at = $3
+		SET is_revoked = $2, revoked_at = $3, replaced_by = $4
 		WHERE id = $1
 	`
 
@@ -115,6 +100,7 @@ func (r *PostgresSessionRepository) Update(ctx context.Context, session *entity.
 		session.ID,
 		session.IsRevoked,
 		session.RevokedAt,
+		session.ReplacedBy,
 	)
 
 	if err != nil {
@@ -133,6 +119,43 @@ func (r *PostgresSessionRepository) Update(ctx context.Context, session *entity.
 	return nil
 }
 
+// Rotate marca a sessão como substituída e cria a sucessora na mesma transação. A
+// atualização é condicional: se outra requisição já rotacionou (ou revogou) a sessão,
+// nada é gravado e o retorno é pkgerrors.ErrTokenReused.
+func (r *PostgresSessionRepository) Rotate(ctx context.Context, current, next *entity.Session) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE sessions
+		SET is_revoked = true, revoked_at = $2, replaced_by = $3
+		WHERE id = $1 AND replaced_by IS NULL AND is_revoked = false
+	`
+
+	result, err := tx.ExecContext(ctx, query, current.ID, current.RevokedAt, current.ReplacedBy)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return pkgerrors.ErrTokenReused
+	}
+
+	if err := insertSession(ctx, tx, next); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (r *PostgresSessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM sessions WHERE id = $1`
 
@@ -164,9 +187,96 @@ func (r *PostgresSessionRepository) RevokeAllByUserID(ctx context.Context, userI
 	return err
 }
 
-func (r *PostgresSessionRepository) DeleteExpired(ctx context.Context) error {
-	query := `DELETE FROM sessions WHERE expires_at < NOW()`
+func (r *PostgresSessionRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	query := `
+		UPDATE sessions
+		SET is_revoked = true, revoked_at = NOW()
+		WHERE family_id = $1 AND is_revoked = false
+	`
 
-	_, err := r.db.ExecContext(ctx, query)
+	_, err := r.db.ExecContext(ctx, query, familyID)
 	return err
 }
+
+func (r *PostgresSessionRepository) DeleteExpired(ctx context.Context, revokedBefore time.Time) (int64, error) {
+	query := `
+		DELETE FROM sessions
+		WHERE expires_at < NOW() OR (is_revoked = true AND revoked_at < $1)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, revokedBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// execer é satisfeito por *sql.DB e *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func insertSession(ctx context.Context, db execer, session *entity.Session) error {
+	query := `
+		INSERT INTO sessions (id, user_id, family_id, client_id, scope, refresh_token_hash, ip_address, user_agent, expires_at, created_at, last_used_at, is_revoked, revoked_at, replaced_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`
+
+	_, err := db.ExecContext(ctx, query,
+		session.ID,
+		session.UserID,
+		session.FamilyID,
+		session.ClientID,
+		session.Scope,
+		session.RefreshTokenHash,
+		session.IPAddress,
+		session.UserAgent,
+		session.ExpiresAt,
+		session.CreatedAt,
+		session.LastUsedAt,
+		session.IsRevoked,
+		session.RevokedAt,
+		session.ReplacedBy,
+	)
+
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSession(row rowScanner) (*entity.Session, error) {
+	session := &entity.Session{}
+	var clientID, replacedBy uuid.NullUUID
+
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.FamilyID,
+		&clientID,
+		&session.Scope,
+		&session.RefreshTokenHash,
+		&session.IPAddress,
+		&session.UserAgent,
+		&session.ExpiresAt,
+		&session.CreatedAt,
+		&session.LastUsedAt,
+		&session.IsRevoked,
+		&session.RevokedAt,
+		&replacedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if clientID.Valid {
+		session.ClientID = &clientID.UUID
+	}
+	if replacedBy.Valid {
+		session.ReplacedBy = &replacedBy.UUID
+	}
+
+	return session, nil
+}
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type PostgresUserIdentityRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresUserIdentityRepository(db *sql.DB) *PostgresUserIdentityRepository {
+	return &PostgresUserIdentityRepository{db: db}
+}
+
+func (r *PostgresUserIdentityRepository) Create(ctx context.Context, identity *entity.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (provider, subject, user_id, email, created_at, last_login_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		identity.Provider,
+		identity.Subject,
+		identity.UserID,
+		identity.Email,
+		identity.CreatedAt,
+		identity.LastLoginAt,
+	)
+
+	return err
+}
+
+func (r *PostgresUserIdentityRepository) Get(ctx context.Context, provider, subject string) (*entity.UserIdentity, error) {
+	query := `
+		SELECT provider, subject, user_id, email, created_at, last_login_at
+		FROM user_identities
+		WHERE provider = $1 AND subject = $2
+	`
+
+	identity := &entity.UserIdentity{}
+	err := r.db.QueryRowContext(ctx, query, provider, subject).Scan(
+		&identity.Provider,
+		&identity.Subject,
+		&identity.UserID,
+		&identity.Email,
+		&identity.CreatedAt,
+		&identity.LastLoginAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkgerrors.ErrUserIdentityNotFound
+		}
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+func (r *PostgresUserIdentityRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.UserIdentity, error) {
+	query := `
+		SELECT provider, subject, user_id, email, created_at, last_login_at
+		FROM user_identities
+		WHERE user_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var identities []*entity.UserIdentity
+	for rows.Next() {
+		identity := &entity.UserIdentity{}
+		if err := rows.Scan(
+			&identity.Provider,
+			&identity.Subject,
+			&identity.UserID,
+			&identity.Email,
+			&identity.CreatedAt,
+			&identity.LastLoginAt,
+		); err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return identities, nil
+}
+
+func (r *PostgresUserIdentityRepository) Update(ctx context.Context, identity *entity.UserIdentity) error {
+	query := `
+		UPDATE user_identities
+		SET email = $3, last_login_at = $4
+		WHERE provider = $1 AND subject = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+		identity.LastLoginAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return pkgerrors.ErrUserIdentityNotFound
+	}
+
+	return nil
+}
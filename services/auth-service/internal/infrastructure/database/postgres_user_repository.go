@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
 	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+	"github.com/lib/pq"
 )
 
 type PostgresUserRepository struct {
@@ -19,52 +20,17 @@ func NewPostgresUserRepository(db *sql.DB) *PostgresUserRepository {
 }
 
 func (r *PostgresUserRepository) Create(ctx context.Context, user *entity.User) error {
-	query := `
-		INSERT INTO users (id, email, password_hash, name, role, is_active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-	`
-
-	_, err := r.db.ExecContext(ctx, query,
-		user.ID,
-		user.Email,
-		user.PasswordHash,
-		user.Name,
-		user.Role,
-		user.IsActive,
-		user.CreatedAt,
-		user.UpdatedAt,
-	)
-
-	if err != nil {
-		// Check for unique constraint violation
-		if errors.Is(err, sql.ErrNoRows) {
-			return pkgerrors.ErrUserAlreadyExists
-		}
-		return err
-	}
-
-	return nil
+	return insertUser(ctx, r.db, user)
 }
 
 func (r *PostgresUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
 	query := `
-		SELECT id, email, password_hash, name, role, is_active, created_at, updated_at
+		SELECT id, email, password_hash, name, role, is_active, totp_secret, totp_enabled, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
 
-	user := &entity.User{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&user.ID,
-		&user.Email,
-		&user.PasswordHash,
-		&user.Name,
-		&user.Role,
-		&user.IsActive,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
-
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, pkgerrors.ErrUserNotFound
@@ -77,23 +43,12 @@ func (r *PostgresUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*en
 
 func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
 	query := `
-		SELECT id, email, password_hash, name, role, is_active, created_at, updated_at
+		SELECT id, email, password_hash, name, role, is_active, totp_secret, totp_enabled, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
 
-	user := &entity.User{}
-	err := r.db.QueryRowContext(ctx, query, email).Scan(
-		&user.ID,
-		&user.Email,
-		&user.PasswordHash,
-		&user.Name,
-		&user.Role,
-		&user.IsActive,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
-
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, email))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, pkgerrors.ErrUserNotFound
@@ -107,7 +62,8 @@ func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (
 func (r *PostgresUserRepository) Update(ctx context.Context, user *entity.User) error {
 	query := `
 		UPDATE users
-		SET email = $2, password_hash = $3, name = $4, role = $5, is_active = $6, updated_at = $7
+		SET email = $2, password_hash = $3, name = $4, role = $5, is_active = $6,
+		    totp_secret = $7, totp_enabled = $8, updated_at = $9
 		WHERE id = $1
 	`
 
@@ -118,6 +74,8 @@ func (r *PostgresUserRepository) Update(ctx context.Context, user *entity.User)
 		user.Name,
 		user.Role,
 		user.IsActive,
+		nullString(user.TOTPSecret),
+		user.TOTPEnabled,
 		user.UpdatedAt,
 	)
 
@@ -137,6 +95,13 @@ func (r *PostgresUserRepository) Update(ctx context.Context, user *entity.User)
 	return nil
 }
 
+func (r *PostgresUserRepository) UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string) error {
+	query := `UPDATE users SET password_hash = $3, updated_at = NOW() WHERE id = $1 AND password_hash = $2`
+
+	_, err := r.db.ExecContext(ctx, query, id, oldHash, newHash)
+	return err
+}
+
 func (r *PostgresUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM users WHERE id = $1`
 
@@ -159,7 +124,7 @@ func (r *PostgresUserRepository) Delete(ctx context.Context, id uuid.UUID) error
 
 func (r *PostgresUserRepository) List(ctx context.Context, limit, offset int) ([]*entity.User, error) {
 	query := `
-		SELECT id, email, password_hash, name, role, is_active, created_at, updated_at
+		SELECT id, email, password_hash, name, role, is_active, totp_secret, totp_enabled, created_at, updated_at
 		FROM users
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2
@@ -173,17 +138,7 @@ func (r *PostgresUserRepository) List(ctx context.Context, limit, offset int) ([
 
 	var users []*entity.User
 	for rows.Next() {
-		user := &entity.User{}
-		err := rows.Scan(
-			&user.ID,
-			&user.Email,
-			&user.PasswordHash,
-			&user.Name,
-			&user.Role,
-			&user.IsActive,
-			&user.CreatedAt,
-			&user.UpdatedAt,
-		)
+		user, err := scanUser(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -197,6 +152,30 @@ func (r *PostgresUserRepository) List(ctx context.Context, limit, offset int) ([
 	return users, nil
 }
 
+func (r *PostgresUserRepository) Count(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM users`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *PostgresUserRepository) CountActiveByRole(ctx context.Context, role entity.UserRole) (int, error) {
+	query := `SELECT COUNT(*) FROM users WHERE role = $1 AND is_active = TRUE`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, role).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (r *PostgresUserRepository) EmailExists(ctx context.Context, email string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)`
 
@@ -208,3 +187,60 @@ func (r *PostgresUserRepository) EmailExists(ctx context.Context, email string)
 
 	return exists, nil
 }
+
+func scanUser(row rowScanner) (*entity.User, error) {
+	user := &entity.User{}
+	var totpSecret sql.NullString
+
+	err := row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.PasswordHash,
+		&user.Name,
+		&user.Role,
+		&user.IsActive,
+		&totpSecret,
+		&user.TOTPEnabled,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	user.TOTPSecret = totpSecret.String
+	return user, nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func insertUser(ctx context.Context, db execer, user *entity.User) error {
+	query := `
+		INSERT INTO users (id, email, password_hash, name, role, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := db.ExecContext(ctx, query,
+		user.ID,
+		user.Email,
+		user.PasswordHash,
+		user.Name,
+		user.Role,
+		user.IsActive,
+		user.CreatedAt,
+		user.UpdatedAt,
+	)
+
+	if err != nil {
+		// Violação do email único (cadastro concorrente com o mesmo email)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return pkgerrors.ErrUserAlreadyExists
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+const webAuthnCredentialColumns = `id, user_id, credential_id, public_key, attestation_type, transports, aaguid, sign_count, backup_eligible, backup_state, name, created_at, last_used_at`
+
+type PostgresWebAuthnCredentialRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresWebAuthnCredentialRepository(db *sql.DB) *PostgresWebAuthnCredentialRepository {
+	return &PostgresWebAuthnCredentialRepository{db: db}
+}
+
+func (r *PostgresWebAuthnCredentialRepository) Create(ctx context.Context, credential *entity.WebAuthnCredential) error {
+	query := `
+		INSERT INTO webauthn_credentials (id, user_id, credential_id, public_key, attestation_type, transports, aaguid, sign_count, backup_eligible, backup_state, name, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (credential_id) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		credential.ID,
+		credential.UserID,
+		credential.CredentialID,
+		credential.PublicKey,
+		credential.AttestationType,
+		strings.Join(credential.Transports, ","),
+		credential.AAGUID,
+		int64(credential.SignCount),
+		credential.BackupEligible,
+		credential.BackupState,
+		credential.Name,
+		credential.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return pkgerrors.ErrPasskeyAlreadyRegistered
+	}
+
+	return nil
+}
+
+func (r *PostgresWebAuthnCredentialRepository) GetByCredentialID(ctx context.Context, credentialID []byte) (*entity.WebAuthnCredential, error) {
+	query := `SELECT ` + webAuthnCredentialColumns + ` FROM webauthn_credentials WHERE credential_id = $1`
+
+	credential, err := scanWebAuthnCredential(r.db.QueryRowContext(ctx, query, credentialID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkgerrors.ErrPasskeyNotFound
+		}
+		return nil, err
+	}
+
+	return credential, nil
+}
+
+func (r *PostgresWebAuthnCredentialRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.WebAuthnCredential, error) {
+	query := `
+		SELECT ` + webAuthnCredentialColumns + `
+		FROM webauthn_credentials
+		WHERE user_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var credentials []*entity.WebAuthnCredential
+	for rows.Next() {
+		credential, err := scanWebAuthnCredential(rows)
+		if err != nil {
+			return nil, err
+		}
+		credentials = append(credentials, credential)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return credentials, nil
+}
+
+func (r *PostgresWebAuthnCredentialRepository) UpdateUsage(ctx context.Context, credential *entity.WebAuthnCredential) error {
+	// Não permite que o contador retroceda, mesmo com asserções concorrentes
+	query := `
+		UPDATE webauthn_credentials
+		SET sign_count = GREATEST(sign_count, $2), backup_state = $3, last_used_at = $4
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		credential.ID,
+		int64(credential.SignCount),
+		credential.BackupState,
+		credential.LastUsedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return expectWebAuthnCredentialRow(result)
+}
+
+func (r *PostgresWebAuthnCredentialRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	query := `DELETE FROM webauthn_credentials WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	return expectWebAuthnCredentialRow(result)
+}
+
+func expectWebAuthnCredentialRow(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return pkgerrors.ErrPasskeyNotFound
+	}
+
+	return nil
+}
+
+func scanWebAuthnCredential(row rowScanner) (*entity.WebAuthnCredential, error) {
+	credential := &entity.WebAuthnCredential{}
+	var transports string
+	var signCount int64
+
+	err := row.Scan(
+		&credential.ID,
+		&credential.UserID,
+		&credential.CredentialID,
+		&credential.PublicKey,
+		&credential.AttestationType,
+		&transports,
+		&credential.AAGUID,
+		&signCount,
+		&credential.BackupEligible,
+		&credential.BackupState,
+		&credential.Name,
+		&credential.CreatedAt,
+		&credential.LastUsedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	credential.SignCount = uint32(signCount)
+	if transports != "" {
+		credential.Transports = strings.Split(transports, ",")
+	}
+
+	return credential, nil
+}
@@ -0,0 +1,254 @@
+package directory
+
+import (
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-ldap/ldap/v3"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+// ErrUserNotFound indica que o login não corresponde a nenhuma entrada do diretório
+var ErrUserNotFound = errors.New("usuário não encontrado no diretório")
+
+// Config descreve o servidor LDAP (OpenLDAP ou Active Directory) e onde procurar os
+// usuários e grupos. Nos filtros, {login} e {dn} são substituídos já escapados.
+type Config struct {
+	URL                string
+	StartTLS           bool
+	InsecureSkipVerify bool
+	// BindDN e BindPassword são a conta de serviço usada nas buscas (vazio faz bind anônimo)
+	BindDN       string
+	BindPassword string
+	BaseDN       string
+	UserFilter   string
+	// IDAttribute é o identificador estável da entrada (entryUUID, objectGUID); vazio usa o DN
+	IDAttribute    string
+	EmailAttribute string
+	NameAttribute  string
+	// GroupAttribute lista os grupos na própria entrada (memberOf)
+	GroupAttribute string
+	// GroupBaseDN e GroupFilter buscam os grupos pelo membro; GroupBaseDN vazio desativa
+	GroupBaseDN string
+	GroupFilter string
+	Timeout     time.Duration
+}
+
+// Entry é o usuário autenticado no diretório
+type Entry struct {
+	DN     string
+	ID     string
+	Email  string
+	Name   string
+	Groups []string
+}
+
+// MemberOf indica se o usuário pertence ao grupo, comparando os DNs sem diferenciar
+// maiúsculas nem espaços entre os componentes
+func (e *Entry) MemberOf(groupDN string) bool {
+	group, err := ldap.ParseDN(groupDN)
+	if err != nil {
+		return false
+	}
+
+	for _, member := range e.Groups {
+		if dn, err := ldap.ParseDN(member); err == nil && dn.EqualFold(group) {
+			return true
+		}
+	}
+	return false
+}
+
+// Directory autentica usuários por bind LDAP. Cada login abre a própria conexão.
+type Directory struct {
+	config Config
+}
+
+// NewDirectory cria o cliente; nenhuma conexão é aberta até o primeiro login
+func NewDirectory(config Config) *Directory {
+	return &Directory{config: config}
+}
+
+// Authenticate localiza a entrada do login com a conta de serviço e valida a senha
+// com um bind como o próprio usuário. Senha incorreta resulta em
+// pkgerrors.ErrInvalidCredentials e falhas de conexão em pkgerrors.ErrIdentityProviderDown.
+func (d *Directory) Authenticate(login, password string) (*Entry, error) {
+	// Bind com senha vazia é um bind não autenticado e sempre "funciona" (RFC 4513, 5.1.2)
+	if password == "" {
+		return nil, pkgerrors.ErrInvalidCredentials
+	}
+
+	conn, err := d.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := d.serviceBind(conn); err != nil {
+		return nil, err
+	}
+
+	entry, err := d.findUser(conn, login)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, pkgerrors.ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("%w: bind as user: %v", pkgerrors.ErrIdentityProviderDown, err)
+	}
+
+	if d.config.GroupBaseDN != "" {
+		// A busca de grupos usa a conta de serviço; o usuário pode não ter permissão de leitura
+		if err := d.serviceBind(conn); err != nil {
+			return nil, err
+		}
+		groups, err := d.findGroups(conn, entry.DN)
+		if err != nil {
+			return nil, err
+		}
+		entry.Groups = append(entry.Groups, groups...)
+	}
+
+	return entry, nil
+}
+
+func (d *Directory) connect() (*ldap.Conn, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: d.config.InsecureSkipVerify}
+	if u, err := url.Parse(d.config.URL); err == nil {
+		tlsConfig.ServerName = u.Hostname()
+	}
+
+	conn, err := ldap.DialURL(
+		d.config.URL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: d.config.Timeout}),
+		ldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", pkgerrors.ErrIdentityProviderDown, err)
+	}
+	conn.SetTimeout(d.config.Timeout)
+
+	if d.config.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("%w: StartTLS: %v", pkgerrors.ErrIdentityProviderDown, err)
+		}
+	}
+
+	return conn, nil
+}
+
+func (d *Directory) serviceBind(conn *ldap.Conn) error {
+	var err error
+	if d.config.BindDN == "" {
+		err = conn.UnauthenticatedBind("")
+	} else {
+		err = conn.Bind(d.config.BindDN, d.config.BindPassword)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: service bind: %v", pkgerrors.ErrIdentityProviderDown, err)
+	}
+	return nil
+}
+
+// findUser busca a entrada do login; mais de uma entrada é tratada como inexistente
+// para que um filtro amplo demais não autentique a pessoa errada
+func (d *Directory) findUser(conn *ldap.Conn, login string) (*Entry, error) {
+	attributes := []string{d.config.EmailAttribute, d.config.NameAttribute}
+	if d.config.IDAttribute != "" {
+		attributes = append(attributes, d.config.IDAttribute)
+	}
+	if d.config.GroupAttribute != "" {
+		attributes = append(attributes, d.config.GroupAttribute)
+	}
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		d.config.BaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		2,
+		int(d.config.Timeout.Seconds()),
+		false,
+		strings.ReplaceAll(d.config.UserFilter, "{login}", ldap.EscapeFilter(login)),
+		attributes,
+		nil,
+	))
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("%w: user search: %v", pkgerrors.ErrIdentityProviderDown, err)
+	}
+	if result == nil || len(result.Entries) != 1 {
+		return nil, ErrUserNotFound
+	}
+
+	found := result.Entries[0]
+	entry := &Entry{
+		DN:    found.DN,
+		ID:    found.DN,
+		Email: found.GetAttributeValue(d.config.EmailAttribute),
+		Name:  found.GetAttributeValue(d.config.NameAttribute),
+	}
+	if d.config.IDAttribute != "" {
+		raw := found.GetRawAttributeValue(d.config.IDAttribute)
+		if len(raw) == 0 {
+			return nil, fmt.Errorf("directory entry %s has no %s", found.DN, d.config.IDAttribute)
+		}
+		entry.ID = encodeID(raw)
+	}
+	if d.config.GroupAttribute != "" {
+		entry.Groups = found.GetAttributeValues(d.config.GroupAttribute)
+	}
+
+	return entry, nil
+}
+
+func (d *Directory) findGroups(conn *ldap.Conn, userDN string) ([]string, error) {
+	result, err := conn.Search(ldap.NewSearchRequest(
+		d.config.GroupBaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		int(d.config.Timeout.Seconds()),
+		false,
+		strings.ReplaceAll(d.config.GroupFilter, "{dn}", ldap.EscapeFilter(userDN)),
+		[]string{"dn"},
+		nil,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("%w: group search: %v", pkgerrors.ErrIdentityProviderDown, err)
+	}
+
+	groups := make([]string, 0, len(result.Entries))
+	for _, group := range result.Entries {
+		groups = append(groups, group.DN)
+	}
+	return groups, nil
+}
+
+// encodeID mantém identificadores textuais (entryUUID) e codifica em hexadecimal os
+// binários (objectGUID do Active Directory)
+func encodeID(raw []byte) string {
+	if utf8.Valid(raw) {
+		printable := true
+		for _, r := range string(raw) {
+			if !unicode.IsPrint(r) {
+				printable = false
+				break
+			}
+		}
+		if printable {
+			return string(raw)
+		}
+	}
+	return hex.EncodeToString(raw)
+}
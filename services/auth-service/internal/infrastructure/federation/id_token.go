@@ -0,0 +1,211 @@
+package federation
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+)
+
+const (
+	// keyRefreshInterval é o intervalo mínimo entre buscas do JWKS motivadas por um
+	// kid desconhecido, para que tokens forjados não gerem uma busca a cada login
+	keyRefreshInterval = time.Minute
+	// clockSkew é a tolerância de relógio entre este serviço e o provedor
+	clockSkew = time.Minute
+)
+
+// allowedMethods aceita apenas assinaturas assimétricas; HS256 (com o client secret)
+// e "none" são recusados
+var allowedMethods = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+// keySet são as chaves de assinatura publicadas no JWKS do provedor, indexadas por kid
+type keySet struct {
+	keys      map[string]verificationKey
+	fetchedAt time.Time
+}
+
+type verificationKey struct {
+	alg string
+	key interface{}
+}
+
+// idTokenClaims são as claims lidas do ID token do provedor
+type idTokenClaims struct {
+	Nonce             string    `json:"nonce"`
+	Email             string    `json:"email"`
+	EmailVerified     claimBool `json:"email_verified"`
+	Name              string    `json:"name"`
+	PreferredUsername string    `json:"preferred_username"`
+	AuthorizedParty   string    `json:"azp"`
+	jwt.RegisteredClaims
+}
+
+// claimBool aceita email_verified como booleano ou como string, formato usado por
+// alguns provedores
+type claimBool bool
+
+func (b *claimBool) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case bool:
+		*b = claimBool(v)
+	case string:
+		*b = claimBool(v == "true")
+	}
+	return nil
+}
+
+// verifyIDToken valida assinatura, iss, aud, exp e nonce do ID token (OpenID Connect
+// Core, seção 3.1.3.7) e extrai a identidade
+func (p *Provider) verifyIDToken(ctx context.Context, rawToken, nonce string) (*Identity, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &idTokenClaims{}
+	_, err = jwt.ParseWithClaims(
+		rawToken,
+		claims,
+		func(token *jwt.Token) (interface{}, error) {
+			return p.verificationKey(ctx, token)
+		},
+		jwt.WithValidMethods(allowedMethods),
+		jwt.WithIssuer(meta.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing sub", ErrInvalidIDToken)
+	}
+	// Com mais de uma audiência, o azp deve identificar este cliente
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.config.ClientID {
+		return nil, fmt.Errorf("%w: azp does not match client_id", ErrInvalidIDToken)
+	}
+	if nonce == "" || subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+
+	name := claims.Name
+	if name == "" {
+		name = claims.PreferredUsername
+	}
+
+	return &Identity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          name,
+	}, nil
+}
+
+// verificationKey seleciona a chave do JWKS pelo kid e impede troca de algoritmo
+func (p *Provider) verificationKey(ctx context.Context, token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, err := p.lookupKey(ctx, kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.alg != "" && key.alg != token.Method.Alg() {
+		return nil, errors.New("token algorithm does not match key")
+	}
+
+	return key.key, nil
+}
+
+// lookupKey busca a chave em memória e, se o kid for desconhecido, recarrega o JWKS
+// (o provedor pode ter rotacionado as chaves). Sem kid, vale a única chave publicada.
+func (p *Provider) lookupKey(ctx context.Context, kid string) (verificationKey, error) {
+	p.mu.Lock()
+	keys := p.keys
+	p.mu.Unlock()
+
+	if key, ok := keys.find(kid); ok {
+		return key, nil
+	}
+	if keys != nil && time.Since(keys.fetchedAt) < keyRefreshInterval {
+		return verificationKey{}, fmt.Errorf("unknown key %q", kid)
+	}
+
+	keys, err := p.fetchKeys(ctx)
+	if err != nil {
+		return verificationKey{}, err
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.mu.Unlock()
+
+	if key, ok := keys.find(kid); ok {
+		return key, nil
+	}
+	return verificationKey{}, fmt.Errorf("unknown key %q", kid)
+}
+
+func (p *Provider) fetchKeys(ctx context.Context) (*keySet, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwks crypto.JWKSet
+	if err := p.getJSON(ctx, meta.JWKSURI, &jwks); err != nil {
+		return nil, err
+	}
+
+	keys := &keySet{
+		keys:      make(map[string]verificationKey, len(jwks.Keys)),
+		fetchedAt: time.Now(),
+	}
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		// Chaves de tipos não suportados são ignoradas, não invalidam o conjunto
+		publicKey, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys.keys[jwk.Kid] = verificationKey{alg: jwk.Alg, key: publicKey}
+	}
+
+	return keys, nil
+}
+
+func (s *keySet) find(kid string) (verificationKey, bool) {
+	if s == nil {
+		return verificationKey{}, false
+	}
+
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+
+	key, ok := s.keys[kid]
+	return key, ok
+}
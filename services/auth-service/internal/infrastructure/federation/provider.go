@@ -0,0 +1,236 @@
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+// maxResponseSize limita as respostas lidas dos provedores (discovery, JWKS e token)
+const maxResponseSize = 1 << 20
+
+// ErrInvalidIDToken indica um ID token recusado na validação
+var ErrInvalidIDToken = errors.New("ID token do provedor inválido")
+
+// Config descreve um provedor de identidade OpenID Connect externo, no qual este
+// serviço é registrado como cliente confidencial (ou público, sem ClientSecret)
+type Config struct {
+	Name         string
+	DisplayName  string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// DefaultRole é a role dos usuários criados no primeiro login pelo provedor
+	DefaultRole string
+	// TrustEmail permite vincular a uma conta existente pelo email verificado
+	TrustEmail bool
+}
+
+// Identity é a identidade autenticada pelo provedor, extraída do ID token
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// ProviderError é um erro devolvido pelo token endpoint do provedor (RFC 6749, seção 5.2)
+type ProviderError struct {
+	Code        string
+	Description string
+}
+
+func (e *ProviderError) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+// metadata são os campos usados do documento de discovery do provedor
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider é um provedor de identidade configurado. O documento de discovery e as
+// chaves de assinatura são obtidos no primeiro uso e mantidos em memória.
+type Provider struct {
+	config     Config
+	httpClient *http.Client
+
+	mu       sync.Mutex
+	metadata *metadata
+	keys     *keySet
+}
+
+// NewProvider cria um provedor; nenhuma requisição é feita até o primeiro login
+func NewProvider(config Config, httpClient *http.Client) *Provider {
+	return &Provider{
+		config:     config,
+		httpClient: httpClient,
+	}
+}
+
+// Name retorna o identificador do provedor usado nas rotas
+func (p *Provider) Name() string {
+	return p.config.Name
+}
+
+// DisplayName retorna o nome exibido no frontend
+func (p *Provider) DisplayName() string {
+	return p.config.DisplayName
+}
+
+// DefaultRole retorna a role dos usuários provisionados pelo provedor
+func (p *Provider) DefaultRole() string {
+	return p.config.DefaultRole
+}
+
+// TrustEmail indica se o email verificado pelo provedor pode vincular contas existentes
+func (p *Provider) TrustEmail() bool {
+	return p.config.TrustEmail
+}
+
+// AuthCodeURL monta a URL de autorização do provedor com state, nonce e PKCE (S256)
+func (p *Provider) AuthCodeURL(ctx context.Context, redirectURI, state, nonce, codeChallenge string) (string, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.config.ClientID},
+		"redirect_uri":          {redirectURI},
+		"scope":                 {strings.Join(p.config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(meta.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return meta.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+// Exchange troca o authorization code no token endpoint do provedor e valida o ID
+// token recebido, que deve conter o nonce enviado na autorização
+func (p *Provider) Exchange(ctx context.Context, redirectURI, code, codeVerifier, nonce string) (*Identity, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {codeVerifier},
+		"client_id":     {p.config.ClientID},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.config.ClientSecret != "" {
+		// client_secret_basic: credenciais codificadas como form (RFC 6749, seção 2.3.1)
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", pkgerrors.ErrIdentityProviderDown, err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("%w: invalid token response (status %d)", pkgerrors.ErrIdentityProviderDown, resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if body.Error == "" {
+			return nil, fmt.Errorf("%w: token endpoint returned status %d", pkgerrors.ErrIdentityProviderDown, resp.StatusCode)
+		}
+		return nil, &ProviderError{Code: body.Error, Description: body.ErrorDescription}
+	}
+	if body.IDToken == "" {
+		return nil, fmt.Errorf("%w: token response without id_token", ErrInvalidIDToken)
+	}
+
+	return p.verifyIDToken(ctx, body.IDToken, nonce)
+}
+
+// discover obtém o documento de discovery do provedor (OpenID Connect Discovery 1.0).
+// Falhas não são guardadas, para que o provedor volte a funcionar sem reinício.
+func (p *Provider) discover(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	cached := p.metadata
+	p.mu.Unlock()
+	if cached != nil {
+		return cached, nil
+	}
+
+	var meta metadata
+	if err := p.getJSON(ctx, p.config.Issuer+"/.well-known/openid-configuration", &meta); err != nil {
+		return nil, err
+	}
+
+	// O issuer anunciado deve ser exatamente o configurado (Discovery, seção 4.3)
+	if strings.TrimSuffix(meta.Issuer, "/") != p.config.Issuer {
+		return nil, fmt.Errorf("%w: discovery issuer %q does not match %q", pkgerrors.ErrIdentityProviderDown, meta.Issuer, p.config.Issuer)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: incomplete discovery document", pkgerrors.ErrIdentityProviderDown)
+	}
+
+	p.mu.Lock()
+	p.metadata = &meta
+	p.mu.Unlock()
+
+	return &meta, nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, endpoint string, value interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", pkgerrors.ErrIdentityProviderDown, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: GET %s returned status %d", pkgerrors.ErrIdentityProviderDown, endpoint, resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(value); err != nil {
+		return fmt.Errorf("%w: invalid response from %s: %v", pkgerrors.ErrIdentityProviderDown, endpoint, err)
+	}
+
+	return nil
+}
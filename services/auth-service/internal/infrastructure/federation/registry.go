@@ -0,0 +1,39 @@
+package federation
+
+import (
+	"net/http"
+	"time"
+)
+
+// httpTimeout limita as chamadas aos provedores (discovery, JWKS e token endpoint)
+const httpTimeout = 10 * time.Second
+
+// Registry reúne os provedores de identidade configurados, na ordem da configuração
+type Registry struct {
+	providers []*Provider
+	byName    map[string]*Provider
+}
+
+// NewRegistry cria os provedores a partir da configuração
+func NewRegistry(configs []Config) *Registry {
+	httpClient := &http.Client{Timeout: httpTimeout}
+
+	registry := &Registry{byName: make(map[string]*Provider, len(configs))}
+	for _, config := range configs {
+		provider := NewProvider(config, httpClient)
+		registry.providers = append(registry.providers, provider)
+		registry.byName[config.Name] = provider
+	}
+	return registry
+}
+
+// Get busca um provedor pelo nome
+func (r *Registry) Get(name string) (*Provider, bool) {
+	provider, ok := r.byName[name]
+	return provider, ok
+}
+
+// Providers lista os provedores configurados
+func (r *Registry) Providers() []*Provider {
+	return r.providers
+}
@@ -0,0 +1,43 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LogMailer não entrega emails: registra no log e, se configurado, grava um
+// arquivo por mensagem no diretório de saída. Destinado a desenvolvimento.
+type LogMailer struct {
+	outboxDir string
+}
+
+// NewLogMailer cria uma nova instância; outboxDir vazio apenas registra no log
+func NewLogMailer(outboxDir string) *LogMailer {
+	return &LogMailer{outboxDir: outboxDir}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	if m.outboxDir == "" {
+		log.Printf("Email to %s: %s\n%s", msg.To, msg.Subject, msg.Body)
+		return nil
+	}
+
+	if err := os.MkdirAll(m.outboxDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create outbox directory: %w", err)
+	}
+
+	name := fmt.Sprintf("%s-%s.txt", time.Now().Format("20060102T150405.000000000"), msg.To)
+	content := fmt.Sprintf("To: %s\nSubject: %s\n\n%s\n", msg.To, msg.Subject, msg.Body)
+
+	path := filepath.Join(m.outboxDir, filepath.Base(name))
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+
+	log.Printf("Email to %s written to %s", msg.To, path)
+	return nil
+}
@@ -0,0 +1,15 @@
+package mail
+
+import "context"
+
+// Message representa um email de texto simples
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer define o contrato para entrega de emails
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,229 @@
+package passkey
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+// Ceremony contém as opções entregues ao navegador e o estado que deve ficar no servidor
+type Ceremony struct {
+	// Options é o JSON passado a navigator.credentials.create/get
+	Options json.RawMessage
+	// Session é o estado opaco da cerimônia, necessário para validar a resposta
+	Session []byte
+}
+
+// Assertion é o resultado de uma asserção com assinatura válida
+type Assertion struct {
+	CredentialID []byte
+	SignCount    uint32
+	CloneWarning bool
+	UserVerified bool
+	BackupState  bool
+}
+
+// CredentialLookup resolve o usuário dono de uma credencial em logins sem identificação prévia
+type CredentialLookup func(userID uuid.UUID) (*entity.User, []*entity.WebAuthnCredential, error)
+
+// Service executa as cerimônias WebAuthn de registro e autenticação
+type Service struct {
+	webAuthn *webauthn.WebAuthn
+}
+
+// NewService cria o serviço para a Relying Party informada
+func NewService(rpID, rpDisplayName string, rpOrigins []string, timeout time.Duration) (*Service, error) {
+	w, err := webauthn.New(&webauthn.Config{
+		RPID:          rpID,
+		RPDisplayName: rpDisplayName,
+		RPOrigins:     rpOrigins,
+		Timeouts: webauthn.TimeoutsConfig{
+			Login:        webauthn.TimeoutConfig{Enforce: true, Timeout: timeout},
+			Registration: webauthn.TimeoutConfig{Enforce: true, Timeout: timeout},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid WebAuthn configuration: %w", err)
+	}
+
+	return &Service{webAuthn: w}, nil
+}
+
+// BeginRegistration gera as opções de criação de uma nova passkey, excluindo as já registradas
+func (s *Service) BeginRegistration(user *entity.User, existing []*entity.WebAuthnCredential) (*Ceremony, error) {
+	u := newWebAuthnUser(user, existing)
+
+	exclusions := make([]protocol.CredentialDescriptor, 0, len(existing))
+	for _, credential := range u.credentials {
+		exclusions = append(exclusions, credential.Descriptor())
+	}
+
+	creation, session, err := s.webAuthn.BeginRegistration(u,
+		webauthn.WithExclusions(exclusions),
+		webauthn.WithAuthenticatorSelection(protocol.AuthenticatorSelection{
+			ResidentKey:      protocol.ResidentKeyRequirementPreferred,
+			UserVerification: protocol.VerificationPreferred,
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin WebAuthn registration: %w", err)
+	}
+
+	return newCeremony(creation, session)
+}
+
+// FinishRegistration valida a resposta de navigator.credentials.create e retorna a credencial a persistir
+func (s *Service) FinishRegistration(user *entity.User, existing []*entity.WebAuthnCredential, sessionData, response []byte) (*entity.WebAuthnCredential, error) {
+	session, err := decodeSession(sessionData)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBody(bytes.NewReader(response))
+	if err != nil {
+		return nil, pkgerrors.ErrPasskeyVerification
+	}
+
+	credential, err := s.webAuthn.CreateCredential(newWebAuthnUser(user, existing), *session, parsed)
+	if err != nil {
+		return nil, pkgerrors.ErrPasskeyVerification
+	}
+
+	transports := make([]string, 0, len(credential.Transport))
+	for _, transport := range credential.Transport {
+		transports = append(transports, string(transport))
+	}
+
+	return &entity.WebAuthnCredential{
+		ID:              uuid.New(),
+		UserID:          user.ID,
+		CredentialID:    credential.ID,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		Transports:      transports,
+		AAGUID:          credential.Authenticator.AAGUID,
+		SignCount:       credential.Authenticator.SignCount,
+		BackupEligible:  credential.Flags.BackupEligible,
+		BackupState:     credential.Flags.BackupState,
+		CreatedAt:       time.Now(),
+	}, nil
+}
+
+// BeginLogin gera um desafio restrito às credenciais do usuário (segundo fator)
+func (s *Service) BeginLogin(user *entity.User, credentials []*entity.WebAuthnCredential) (*Ceremony, error) {
+	assertion, session, err := s.webAuthn.BeginLogin(newWebAuthnUser(user, credentials))
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin WebAuthn login: %w", err)
+	}
+
+	return newCeremony(assertion, session)
+}
+
+// BeginDiscoverableLogin gera um desafio para login apenas com a passkey. A verificação
+// do usuário (biometria/PIN) é obrigatória, pois a passkey substitui a senha.
+func (s *Service) BeginDiscoverableLogin() (*Ceremony, error) {
+	assertion, session, err := s.webAuthn.BeginDiscoverableLogin(
+		webauthn.WithUserVerification(protocol.VerificationRequired),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin WebAuthn login: %w", err)
+	}
+
+	return newCeremony(assertion, session)
+}
+
+// FinishLogin valida a resposta de navigator.credentials.get contra as credenciais do usuário
+func (s *Service) FinishLogin(user *entity.User, credentials []*entity.WebAuthnCredential, sessionData, response []byte) (*Assertion, error) {
+	session, err := decodeSession(sessionData)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(response))
+	if err != nil {
+		return nil, pkgerrors.ErrPasskeyVerification
+	}
+
+	credential, err := s.webAuthn.ValidateLogin(newWebAuthnUser(user, credentials), *session, parsed)
+	if err != nil {
+		return nil, pkgerrors.ErrPasskeyVerification
+	}
+
+	return newAssertion(credential), nil
+}
+
+// FinishDiscoverableLogin valida a resposta de um login sem identificação prévia,
+// resolvendo o usuário pelo user handle devolvido pelo autenticador
+func (s *Service) FinishDiscoverableLogin(sessionData, response []byte, lookup CredentialLookup) (*entity.User, *Assertion, error) {
+	session, err := decodeSession(sessionData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(response))
+	if err != nil {
+		return nil, nil, pkgerrors.ErrPasskeyVerification
+	}
+
+	var owner *entity.User
+	handler := func(_, userHandle []byte) (webauthn.User, error) {
+		userID, err := uuid.FromBytes(userHandle)
+		if err != nil {
+			return nil, err
+		}
+
+		user, credentials, err := lookup(userID)
+		if err != nil {
+			return nil, err
+		}
+
+		owner = user
+		return newWebAuthnUser(user, credentials), nil
+	}
+
+	credential, err := s.webAuthn.ValidateDiscoverableLogin(handler, *session, parsed)
+	if err != nil {
+		return nil, nil, pkgerrors.ErrPasskeyVerification
+	}
+
+	return owner, newAssertion(credential), nil
+}
+
+func newCeremony(options interface{}, session *webauthn.SessionData) (*Ceremony, error) {
+	optionsJSON, err := json.Marshal(options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode WebAuthn options: %w", err)
+	}
+
+	sessionJSON, err := json.Marshal(session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode WebAuthn session: %w", err)
+	}
+
+	return &Ceremony{Options: optionsJSON, Session: sessionJSON}, nil
+}
+
+func decodeSession(data []byte) (*webauthn.SessionData, error) {
+	var session webauthn.SessionData
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, pkgerrors.ErrPasskeyChallengeInvalid
+	}
+	return &session, nil
+}
+
+func newAssertion(credential *webauthn.Credential) *Assertion {
+	return &Assertion{
+		CredentialID: credential.ID,
+		SignCount:    credential.Authenticator.SignCount,
+		CloneWarning: credential.Authenticator.CloneWarning,
+		UserVerified: credential.Flags.UserVerified,
+		BackupState:  credential.Flags.BackupState,
+	}
+}
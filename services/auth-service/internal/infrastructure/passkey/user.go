@@ -0,0 +1,67 @@
+package passkey
+
+import (
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+)
+
+// webAuthnUser adapta o usuário do domínio à interface webauthn.User.
+// O user handle é o UUID do usuário (16 bytes), sem dados pessoais.
+type webAuthnUser struct {
+	user        *entity.User
+	credentials []webauthn.Credential
+}
+
+func newWebAuthnUser(user *entity.User, credentials []*entity.WebAuthnCredential) *webAuthnUser {
+	u := &webAuthnUser{
+		user:        user,
+		credentials: make([]webauthn.Credential, 0, len(credentials)),
+	}
+
+	for _, credential := range credentials {
+		transports := make([]protocol.AuthenticatorTransport, 0, len(credential.Transports))
+		for _, transport := range credential.Transports {
+			transports = append(transports, protocol.AuthenticatorTransport(transport))
+		}
+
+		u.credentials = append(u.credentials, webauthn.Credential{
+			ID:              credential.CredentialID,
+			PublicKey:       credential.PublicKey,
+			AttestationType: credential.AttestationType,
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				BackupEligible: credential.BackupEligible,
+				BackupState:    credential.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    credential.AAGUID,
+				SignCount: credential.SignCount,
+			},
+		})
+	}
+
+	return u
+}
+
+func (u *webAuthnUser) WebAuthnID() []byte {
+	id := u.user.ID
+	return id[:]
+}
+
+func (u *webAuthnUser) WebAuthnName() string {
+	return u.user.Email
+}
+
+func (u *webAuthnUser) WebAuthnDisplayName() string {
+	return u.user.Name
+}
+
+func (u *webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	return u.credentials
+}
+
+// WebAuthnIcon foi removido da especificação; mantido apenas para satisfazer a interface
+func (u *webAuthnUser) WebAuthnIcon() string {
+	return ""
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type AcceptInvitationInput struct {
+	Token    string
+	Name     string
+	Password string
+}
+
+type AcceptInvitationUseCase struct {
+	invitationRepo  repository.InvitationRepository
+	registerUseCase *RegisterUserUseCase
+}
+
+func NewAcceptInvitationUseCase(
+	invitationRepo repository.InvitationRepository,
+	registerUseCase *RegisterUserUseCase,
+) *AcceptInvitationUseCase {
+	return &AcceptInvitationUseCase{
+		invitationRepo:  invitationRepo,
+		registerUseCase: registerUseCase,
+	}
+}
+
+func (uc *AcceptInvitationUseCase) Execute(ctx context.Context, input AcceptInvitationInput) (*RegisterUserOutput, error) {
+	invitation, err := uc.invitationRepo.GetByToken(ctx, input.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !invitation.IsPending() {
+		return nil, pkgerrors.ErrInvitationNotPending
+	}
+
+	// Email e role vêm do convite; o convidado define apenas nome e senha
+	user, err := uc.registerUseCase.newUser(ctx, RegisterUserInput{
+		Email:    invitation.Email,
+		Password: input.Password,
+		Name:     input.Name,
+		Role:     invitation.Role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// O convite é consumido e o usuário criado na mesma transação: um convite revogado
+	// ou aceito por outra requisição nesse meio-tempo não gera conta
+	invitation.Accept(user.ID)
+	if err := uc.invitationRepo.Accept(ctx, invitation, user); err != nil {
+		if errors.Is(err, pkgerrors.ErrInvitationNotPending) || errors.Is(err, pkgerrors.ErrUserAlreadyExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	return toRegisterUserOutput(user), nil
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+)
+
+type ActivateUserInput struct {
+	UserID uuid.UUID
+}
+
+type ActivateUserUseCase struct {
+	userRepo repository.UserRepository
+}
+
+func NewActivateUserUseCase(userRepo repository.UserRepository) *ActivateUserUseCase {
+	return &ActivateUserUseCase{
+		userRepo: userRepo,
+	}
+}
+
+func (uc *ActivateUserUseCase) Execute(ctx context.Context, input ActivateUserInput) (*UserDetailsOutput, error) {
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Activate()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	output := toUserDetailsOutput(user)
+	return &output, nil
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+// Authenticator valida o email (ou login) e a senha do login e retorna o usuário local.
+// Credenciais incorretas resultam em pkgerrors.ErrInvalidCredentials, contabilizado
+// pelo LoginUseCase como tentativa falha; qualquer outro erro é devolvido como está.
+type Authenticator interface {
+	Authenticate(ctx context.Context, login, password string) (*entity.User, error)
+}
+
+// verifyCurrentPassword confirma a senha do usuário já autenticado pelo backend
+// configurado, já que usuários do diretório não têm senha local válida. Senha errada
+// resulta em pkgerrors.ErrIncorrectPassword.
+func verifyCurrentPassword(ctx context.Context, authenticator Authenticator, user *entity.User, password string) error {
+	verified, err := authenticator.Authenticate(ctx, user.Email, password)
+	if err != nil {
+		if errors.Is(err, pkgerrors.ErrInvalidCredentials) {
+			return pkgerrors.ErrIncorrectPassword
+		}
+		return err
+	}
+
+	// O email pode ter levado a outra conta (ex.: entrada do diretório vinculada a outro usuário)
+	if verified.ID != user.ID {
+		return pkgerrors.ErrIncorrectPassword
+	}
+
+	return nil
+}
+
+// LocalAuthenticator confere a senha com o hash guardado no banco
+type LocalAuthenticator struct {
+	userRepo        repository.UserRepository
+	passwordService *crypto.PasswordService
+}
+
+func NewLocalAuthenticator(
+	userRepo repository.UserRepository,
+	passwordService *crypto.PasswordService,
+) *LocalAuthenticator {
+	return &LocalAuthenticator{
+		userRepo:        userRepo,
+		passwordService: passwordService,
+	}
+}
+
+func (a *LocalAuthenticator) Authenticate(ctx context.Context, email, password string) (*entity.User, error) {
+	// Sem a conta, comparar com um hash fictício para que o tempo de resposta seja o
+	// mesmo de uma senha incorreta
+	user, err := a.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		a.passwordService.CompareDummy(password)
+		return nil, pkgerrors.ErrInvalidCredentials
+	}
+
+	if err := a.passwordService.Compare(user.PasswordHash, password); err != nil {
+		return nil, pkgerrors.ErrInvalidCredentials
+	}
+
+	a.rehashIfNeeded(ctx, user, password)
+
+	return user, nil
+}
+
+// rehashIfNeeded migra o hash para o algoritmo e os parâmetros atuais enquanto a senha
+// em texto claro está disponível. Apenas o hash é gravado, e só se ainda for o lido no
+// login, para não sobrescrever uma troca de senha ou outra alteração concorrente.
+// Falhas não impedem o login.
+func (a *LocalAuthenticator) rehashIfNeeded(ctx context.Context, user *entity.User, password string) {
+	if !a.passwordService.NeedsRehash(user.PasswordHash) {
+		return
+	}
+
+	passwordHash, err := a.passwordService.Hash(password)
+	if err != nil {
+		log.Printf("Failed to rehash password for user %s: %v", user.ID, err)
+		return
+	}
+
+	if err := a.userRepo.UpdatePasswordHash(ctx, user.ID, user.PasswordHash, passwordHash); err != nil {
+		log.Printf("Failed to store rehashed password for user %s: %v", user.ID, err)
+	}
+}
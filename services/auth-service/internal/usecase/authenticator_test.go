@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestLocalAuthenticatorUnknownEmailComparesDummyHash(t *testing.T) {
+	hasher := &countingHasher{}
+	authenticator := NewLocalAuthenticator(newFakeUserRepository(), crypto.NewPasswordService(hasher))
+
+	user, err := authenticator.Authenticate(context.Background(), "nobody@example.com", "some-password")
+	if !errors.Is(err, pkgerrors.ErrInvalidCredentials) {
+		t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+	}
+	if user != nil {
+		t.Fatalf("expected no user, got %+v", user)
+	}
+
+	// Sem a conta, a senha ainda deve ser comparada (com o hash fictício)
+	verified := hasher.verifications()
+	if len(verified) != 1 {
+		t.Fatalf("expected 1 password comparison, got %d", len(verified))
+	}
+	if verified[0] != "fake$titanwatch-dummy-password" {
+		t.Fatalf("expected comparison against the dummy hash, got %q", verified[0])
+	}
+}
+
+func TestLocalAuthenticatorWrongPassword(t *testing.T) {
+	hasher := &countingHasher{}
+	existing := entity.NewUser("user@example.com", "fake$correct-password", "User", entity.RoleViewer)
+	authenticator := NewLocalAuthenticator(newFakeUserRepository(existing), crypto.NewPasswordService(hasher))
+
+	_, err := authenticator.Authenticate(context.Background(), existing.Email, "wrong-password")
+	if !errors.Is(err, pkgerrors.ErrInvalidCredentials) {
+		t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+	}
+	if got := len(hasher.verifications()); got != 1 {
+		t.Fatalf("expected 1 password comparison, got %d", got)
+	}
+}
+
+func TestLocalAuthenticatorValidPassword(t *testing.T) {
+	existing := entity.NewUser("user@example.com", "fake$correct-password", "User", entity.RoleViewer)
+	authenticator := NewLocalAuthenticator(newFakeUserRepository(existing), crypto.NewPasswordService(&countingHasher{}))
+
+	user, err := authenticator.Authenticate(context.Background(), existing.Email, "correct-password")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.ID != existing.ID {
+		t.Fatalf("expected user %s, got %s", existing.ID, user.ID)
+	}
+}
+
+func TestLocalAuthenticatorRehashesOnLogin(t *testing.T) {
+	// Parâmetros baixos mantêm o teste rápido
+	argon2Hasher, err := crypto.NewArgon2idHasher(crypto.Argon2idParams{Memory: 64, Time: 1, Parallelism: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bcryptHasher, err := crypto.NewBcryptHasher(bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		hasher     crypto.PasswordHasher
+		wantRehash bool
+	}{
+		{name: "bcrypt hash migrates to argon2id", hasher: bcryptHasher, wantRehash: true},
+		{name: "current argon2id hash is kept", hasher: argon2Hasher, wantRehash: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored, err := tt.hasher.Hash("correct-password")
+			if err != nil {
+				t.Fatal(err)
+			}
+			existing := entity.NewUser("user@example.com", stored, "User", entity.RoleViewer)
+			repo := newFakeUserRepository(existing)
+			authenticator := NewLocalAuthenticator(repo, crypto.NewPasswordService(argon2Hasher))
+
+			if _, err := authenticator.Authenticate(context.Background(), existing.Email, "correct-password"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := repo.users[existing.Email].PasswordHash
+			if rehashed := got != stored; rehashed != tt.wantRehash {
+				t.Fatalf("expected rehash=%v, stored hash %q", tt.wantRehash, got)
+			}
+			if !strings.HasPrefix(got, "$argon2id$") {
+				t.Fatalf("expected an argon2id hash, got %q", got)
+			}
+			if err := crypto.NewPasswordService(argon2Hasher).Compare(got, "correct-password"); err != nil {
+				t.Fatalf("expected stored hash to verify, got %v", err)
+			}
+		})
+	}
+}
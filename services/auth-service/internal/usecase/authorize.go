@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type AuthorizeInput struct {
+	ResponseType string
+	ClientID     string
+	RedirectURI  string
+	Scope        string
+	State        string
+	// Nonce é devolvido no ID token (OpenID Connect)
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+type AuthorizeOutput struct {
+	// RedirectTo é a página de login e consentimento do frontend, com o request_id
+	RedirectTo string
+}
+
+// AuthorizationRedirectError é um erro devolvido ao cliente por redirecionamento
+// para a redirect URI já validada (RFC 6749, seção 4.1.2.1)
+type AuthorizationRedirectError struct {
+	RedirectURI string
+	State       string
+	Err         *pkgerrors.OAuthError
+}
+
+func (e *AuthorizationRedirectError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *AuthorizationRedirectError) Unwrap() error {
+	return e.Err
+}
+
+// Location retorna a redirect URI com os parâmetros de erro
+func (e *AuthorizationRedirectError) Location() string {
+	params := url.Values{"error": {e.Err.Code}}
+	if e.Err.Description != "" {
+		params.Set("error_description", e.Err.Description)
+	}
+	if e.State != "" {
+		params.Set("state", e.State)
+	}
+	return appendQuery(e.RedirectURI, params)
+}
+
+type AuthorizeUseCase struct {
+	clientRepo repository.OAuthClientRepository
+	oauthStore *cache.OAuthStore
+	loginURL   string
+}
+
+func NewAuthorizeUseCase(
+	clientRepo repository.OAuthClientRepository,
+	oauthStore *cache.OAuthStore,
+	loginURL string,
+) *AuthorizeUseCase {
+	return &AuthorizeUseCase{
+		clientRepo: clientRepo,
+		oauthStore: oauthStore,
+		loginURL:   loginURL,
+	}
+}
+
+// Execute valida o pedido de autorização e o guarda até o usuário fazer login e
+// decidir no frontend. Erros anteriores à validação da redirect URI não podem ser
+// redirecionados e são devolvidos como *pkgerrors.OAuthError.
+func (uc *AuthorizeUseCase) Execute(ctx context.Context, input AuthorizeInput) (*AuthorizeOutput, error) {
+	client, err := uc.lookupClient(ctx, input.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sem redirect_uri, vale a única URI registrada
+	redirectURI := input.RedirectURI
+	if redirectURI == "" && len(client.RedirectURIs) == 1 {
+		redirectURI = client.RedirectURIs[0]
+	}
+	if !client.HasRedirectURI(redirectURI) {
+		return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidRequest, "redirect_uri não registrada para o cliente")
+	}
+
+	// A partir daqui os erros voltam ao cliente pela redirect URI
+	redirectErr := func(code, description string) error {
+		return &AuthorizationRedirectError{
+			RedirectURI: redirectURI,
+			State:       input.State,
+			Err:         pkgerrors.NewOAuthError(code, description),
+		}
+	}
+
+	if input.ResponseType != "code" {
+		return nil, redirectErr(pkgerrors.OAuthUnsupportedResponseType, "apenas response_type=code é suportado")
+	}
+	if !client.AllowsGrant(entity.GrantTypeAuthorizationCode) {
+		return nil, redirectErr(pkgerrors.OAuthUnauthorizedClient, "authorization code não liberado para o cliente")
+	}
+
+	// PKCE obrigatório para todos os clientes, inclusive os confidenciais
+	if input.CodeChallenge == "" {
+		return nil, redirectErr(pkgerrors.OAuthInvalidRequest, "code_challenge obrigatório (PKCE)")
+	}
+	if input.CodeChallengeMethod != crypto.PKCEMethodS256 {
+		return nil, redirectErr(pkgerrors.OAuthInvalidRequest, "code_challenge_method deve ser S256")
+	}
+	if !crypto.ValidCodeChallenge(input.CodeChallenge) {
+		return nil, redirectErr(pkgerrors.OAuthInvalidRequest, "code_challenge inválido")
+	}
+
+	// Sem scope, valem os escopos liberados para o cliente
+	scopes := entity.ParseScope(input.Scope)
+	if len(scopes) == 0 {
+		scopes = client.Scopes
+	}
+	if !client.AllowsScopes(scopes) {
+		return nil, redirectErr(pkgerrors.OAuthInvalidScope, "escopo não permitido para o cliente")
+	}
+
+	requestID, err := uc.oauthStore.CreateRequest(ctx, cache.AuthorizationRequest{
+		ClientID:            client.ID,
+		RedirectURI:         redirectURI,
+		Scopes:              scopes,
+		State:               input.State,
+		Nonce:               input.Nonce,
+		CodeChallenge:       input.CodeChallenge,
+		CodeChallengeMethod: input.CodeChallengeMethod,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthorizeOutput{
+		RedirectTo: appendQuery(uc.loginURL, url.Values{"request_id": {requestID}}),
+	}, nil
+}
+
+func (uc *AuthorizeUseCase) lookupClient(ctx context.Context, clientID string) (*entity.OAuthClient, error) {
+	unknown := pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidRequest, "client_id desconhecido")
+
+	id, err := uuid.Parse(clientID)
+	if err != nil {
+		return nil, unknown
+	}
+
+	client, err := uc.clientRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pkgerrors.ErrOAuthClientNotFound) {
+			return nil, unknown
+		}
+		return nil, err
+	}
+
+	if !client.IsActive {
+		return nil, unknown
+	}
+
+	return client, nil
+}
+
+// appendQuery acrescenta parâmetros à URI, preservando a query existente
+func appendQuery(rawURL string, params url.Values) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	query := u.Query()
+	for key, values := range params {
+		for _, value := range values {
+			query.Add(key, value)
+		}
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
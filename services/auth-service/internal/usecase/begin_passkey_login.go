@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/passkey"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type BeginPasskeyLoginInput struct {
+	// MFAToken, se informado, usa a passkey como segundo fator de um login com senha
+	MFAToken string
+}
+
+type BeginPasskeyLoginOutput struct {
+	ChallengeToken string
+	// Options é repassado a navigator.credentials.get
+	Options json.RawMessage
+}
+
+type BeginPasskeyLoginUseCase struct {
+	userRepo       repository.UserRepository
+	credentialRepo repository.WebAuthnCredentialRepository
+	passkeyService *passkey.Service
+	webAuthnStore  *cache.WebAuthnStore
+	mfaStore       *cache.MFAStore
+}
+
+func NewBeginPasskeyLoginUseCase(
+	userRepo repository.UserRepository,
+	credentialRepo repository.WebAuthnCredentialRepository,
+	passkeyService *passkey.Service,
+	webAuthnStore *cache.WebAuthnStore,
+	mfaStore *cache.MFAStore,
+) *BeginPasskeyLoginUseCase {
+	return &BeginPasskeyLoginUseCase{
+		userRepo:       userRepo,
+		credentialRepo: credentialRepo,
+		passkeyService: passkeyService,
+		webAuthnStore:  webAuthnStore,
+		mfaStore:       mfaStore,
+	}
+}
+
+// Execute gera o desafio de asserção: sem MFAToken, qualquer passkey descoberta pelo
+// navegador é aceita; com MFAToken, apenas as credenciais do usuário do desafio
+func (uc *BeginPasskeyLoginUseCase) Execute(ctx context.Context, input BeginPasskeyLoginInput) (*BeginPasskeyLoginOutput, error) {
+	var ceremony *passkey.Ceremony
+	var err error
+
+	if input.MFAToken == "" {
+		ceremony, err = uc.passkeyService.BeginDiscoverableLogin()
+	} else {
+		ceremony, err = uc.beginSecondFactor(ctx, input.MFAToken)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := uc.webAuthnStore.CreateLogin(ctx, ceremony.Session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BeginPasskeyLoginOutput{
+		ChallengeToken: token,
+		Options:        ceremony.Options,
+	}, nil
+}
+
+func (uc *BeginPasskeyLoginUseCase) beginSecondFactor(ctx context.Context, mfaToken string) (*passkey.Ceremony, error) {
+	challenge, err := uc.mfaStore.GetChallenge(ctx, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, pkgerrors.ErrMFAChallengeInvalid
+	}
+
+	credentials, err := uc.credentialRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list passkeys: %w", err)
+	}
+
+	if len(credentials) == 0 {
+		return nil, pkgerrors.ErrPasskeyNotFound
+	}
+
+	return uc.passkeyService.BeginLogin(user, credentials)
+}
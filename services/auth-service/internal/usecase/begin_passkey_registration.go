@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/passkey"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type BeginPasskeyRegistrationInput struct {
+	UserID uuid.UUID
+}
+
+type BeginPasskeyRegistrationOutput struct {
+	// Options é repassado a navigator.credentials.create
+	Options json.RawMessage
+}
+
+type BeginPasskeyRegistrationUseCase struct {
+	userRepo       repository.UserRepository
+	credentialRepo repository.WebAuthnCredentialRepository
+	passkeyService *passkey.Service
+	webAuthnStore  *cache.WebAuthnStore
+}
+
+func NewBeginPasskeyRegistrationUseCase(
+	userRepo repository.UserRepository,
+	credentialRepo repository.WebAuthnCredentialRepository,
+	passkeyService *passkey.Service,
+	webAuthnStore *cache.WebAuthnStore,
+) *BeginPasskeyRegistrationUseCase {
+	return &BeginPasskeyRegistrationUseCase{
+		userRepo:       userRepo,
+		credentialRepo: credentialRepo,
+		passkeyService: passkeyService,
+		webAuthnStore:  webAuthnStore,
+	}
+}
+
+// Execute gera o desafio de registro; um novo início substitui o registro pendente anterior
+func (uc *BeginPasskeyRegistrationUseCase) Execute(ctx context.Context, input BeginPasskeyRegistrationInput) (*BeginPasskeyRegistrationOutput, error) {
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, pkgerrors.ErrUserInactive
+	}
+
+	existing, err := uc.credentialRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list passkeys: %w", err)
+	}
+
+	ceremony, err := uc.passkeyService.BeginRegistration(user, existing)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.webAuthnStore.SaveRegistration(ctx, user.ID, ceremony.Session); err != nil {
+		return nil, err
+	}
+
+	return &BeginPasskeyRegistrationOutput{Options: ceremony.Options}, nil
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/service"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type ChangePasswordInput struct {
+	UserID              uuid.UUID
+	CurrentSessionID    string
+	CurrentPassword     string
+	NewPassword         string
+	RevokeOtherSessions bool
+}
+
+type ChangePasswordOutput struct {
+	RevokedSessions int
+}
+
+type ChangePasswordUseCase struct {
+	userRepo          repository.UserRepository
+	sessionRepo       repository.SessionRepository
+	identityRepo      repository.UserIdentityRepository
+	authenticator     Authenticator
+	passwordService   *crypto.PasswordService
+	validationService *service.ValidationService
+	revocationList    *cache.TokenRevocationList
+	history           passwordHistoryPolicy
+}
+
+func NewChangePasswordUseCase(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	historyRepo repository.PasswordHistoryRepository,
+	identityRepo repository.UserIdentityRepository,
+	authenticator Authenticator,
+	passwordService *crypto.PasswordService,
+	validationService *service.ValidationService,
+	revocationList *cache.TokenRevocationList,
+	historySize int,
+) *ChangePasswordUseCase {
+	return &ChangePasswordUseCase{
+		userRepo:          userRepo,
+		sessionRepo:       sessionRepo,
+		identityRepo:      identityRepo,
+		authenticator:     authenticator,
+		passwordService:   passwordService,
+		validationService: validationService,
+		revocationList:    revocationList,
+		history: passwordHistoryPolicy{
+			historyRepo:     historyRepo,
+			passwordService: passwordService,
+			size:            historySize,
+		},
+	}
+}
+
+func (uc *ChangePasswordUseCase) Execute(ctx context.Context, input ChangePasswordInput) (*ChangePasswordOutput, error) {
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, pkgerrors.ErrUserInactive
+	}
+
+	// A senha de contas do diretório é gerenciada nele; uma senha local não seria usada
+	directoryAccount, err := isDirectoryAccount(ctx, uc.identityRepo, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if directoryAccount {
+		return nil, pkgerrors.ErrPasswordManagedByDirectory
+	}
+
+	// Exigir a senha atual
+	if err := verifyCurrentPassword(ctx, uc.authenticator, user, input.CurrentPassword); err != nil {
+		return nil, err
+	}
+
+	if err := uc.validationService.ValidatePassword(input.NewPassword, user.Email, user.Name); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	if err := uc.history.check(ctx, user, input.NewPassword); err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := uc.passwordService.Hash(input.NewPassword)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	oldHash := user.PasswordHash
+	user.UpdatePassword(passwordHash)
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update password: %w", err)
+	}
+
+	if err := uc.history.record(ctx, user, oldHash); err != nil {
+		return nil, err
+	}
+
+	output := &ChangePasswordOutput{}
+	if input.RevokeOtherSessions {
+		revoked, err := uc.revokeOtherSessions(ctx, input.UserID, input.CurrentSessionID)
+		if err != nil {
+			return nil, err
+		}
+		output.RevokedSessions = revoked
+	}
+
+	return output, nil
+}
+
+// revokeOtherSessions encerra todas as famílias de sessão exceto a atual
+func (uc *ChangePasswordUseCase) revokeOtherSessions(ctx context.Context, userID uuid.UUID, currentSessionID string) (int, error) {
+	sessions, err := uc.sessionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	revoked := 0
+	for _, session := range sessions {
+		if !session.IsValid() || session.FamilyID.String() == currentSessionID {
+			continue
+		}
+
+		if err := uc.sessionRepo.RevokeFamily(ctx, session.FamilyID); err != nil {
+			return revoked, fmt.Errorf("failed to revoke session: %w", err)
+		}
+
+		if err := uc.revocationList.RevokeSession(ctx, session.FamilyID); err != nil {
+			return revoked, fmt.Errorf("failed to revoke session access tokens: %w", err)
+		}
+
+		revoked++
+	}
+
+	return revoked, nil
+}
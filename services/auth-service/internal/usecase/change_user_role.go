@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type ChangeUserRoleInput struct {
+	ActorID uuid.UUID
+	UserID  uuid.UUID
+	Role    entity.UserRole
+}
+
+type ChangeUserRoleUseCase struct {
+	userRepo       repository.UserRepository
+	revocationList *cache.TokenRevocationList
+}
+
+func NewChangeUserRoleUseCase(
+	userRepo repository.UserRepository,
+	revocationList *cache.TokenRevocationList,
+) *ChangeUserRoleUseCase {
+	return &ChangeUserRoleUseCase{
+		userRepo:       userRepo,
+		revocationList: revocationList,
+	}
+}
+
+func (uc *ChangeUserRoleUseCase) Execute(ctx context.Context, input ChangeUserRoleInput) (*UserDetailsOutput, error) {
+	if !entity.IsValidRole(input.Role) {
+		return nil, pkgerrors.ErrInvalidRole
+	}
+
+	// Evitar que um admin remova o próprio acesso por engano
+	if input.ActorID == input.UserID {
+		return nil, pkgerrors.ErrSelfModification
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Role = input.Role
+	user.UpdatedAt = time.Now()
+
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	// Access tokens carregam a role: forçar renovação para refletir a mudança
+	if err := uc.revocationList.RevokeUserTokens(ctx, user.ID); err != nil {
+		return nil, fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+
+	output := toUserDetailsOutput(user)
+	return &output, nil
+}
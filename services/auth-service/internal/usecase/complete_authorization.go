@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type CompleteAuthorizationInput struct {
+	RequestID string
+	UserID    uuid.UUID
+	// SessionID é a sessão (sid) do usuário no frontend, usada para o auth_time
+	SessionID string
+	// Approve é a decisão do usuário; aprovar também registra o consentimento
+	Approve bool
+}
+
+type CompleteAuthorizationOutput struct {
+	// RedirectTo é a redirect URI do cliente com o code (ou o erro access_denied) e o state
+	RedirectTo string
+}
+
+type CompleteAuthorizationUseCase struct {
+	userRepo    repository.UserRepository
+	sessionRepo repository.SessionRepository
+	clientRepo  repository.OAuthClientRepository
+	consentRepo repository.OAuthConsentRepository
+	oauthStore  *cache.OAuthStore
+}
+
+func NewCompleteAuthorizationUseCase(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	clientRepo repository.OAuthClientRepository,
+	consentRepo repository.OAuthConsentRepository,
+	oauthStore *cache.OAuthStore,
+) *CompleteAuthorizationUseCase {
+	return &CompleteAuthorizationUseCase{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		clientRepo:  clientRepo,
+		consentRepo: consentRepo,
+		oauthStore:  oauthStore,
+	}
+}
+
+// Execute conclui o pedido de autorização com a decisão do usuário autenticado.
+// O pedido é consumido, então cada request_id só pode ser decidido uma vez.
+func (uc *CompleteAuthorizationUseCase) Execute(ctx context.Context, input CompleteAuthorizationInput) (*CompleteAuthorizationOutput, error) {
+	request, err := uc.oauthStore.TakeRequest(ctx, input.RequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := uc.clientRepo.GetByID(ctx, request.ClientID)
+	if err != nil {
+		if errors.Is(err, pkgerrors.ErrOAuthClientNotFound) {
+			return nil, pkgerrors.ErrAuthorizationRequestInvalid
+		}
+		return nil, err
+	}
+	if !client.IsActive {
+		return nil, pkgerrors.ErrAuthorizationRequestInvalid
+	}
+
+	if !input.Approve {
+		denied := &AuthorizationRedirectError{
+			RedirectURI: request.RedirectURI,
+			State:       request.State,
+			Err:         pkgerrors.NewOAuthError(pkgerrors.OAuthAccessDenied, "autorização negada pelo usuário"),
+		}
+		return &CompleteAuthorizationOutput{RedirectTo: denied.Location()}, nil
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, pkgerrors.ErrUserInactive
+	}
+
+	if !client.SkipConsent {
+		if err := uc.grantConsent(ctx, user.ID, client.ID, request.Scopes); err != nil {
+			return nil, err
+		}
+	}
+
+	code, err := uc.oauthStore.CreateCode(ctx, cache.AuthorizationCode{
+		ClientID:            client.ID,
+		UserID:              user.ID,
+		RedirectURI:         request.RedirectURI,
+		Scopes:              request.Scopes,
+		Nonce:               request.Nonce,
+		AuthTime:            uc.authTime(ctx, user.ID, input.SessionID),
+		CodeChallenge:       request.CodeChallenge,
+		CodeChallengeMethod: request.CodeChallengeMethod,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{"code": {code}}
+	if request.State != "" {
+		params.Set("state", request.State)
+	}
+
+	return &CompleteAuthorizationOutput{
+		RedirectTo: appendQuery(request.RedirectURI, params),
+	}, nil
+}
+
+// authTime retorna o momento do login que originou a sessão do usuário no frontend.
+// Sem sessão identificável, considera o login como recente.
+func (uc *CompleteAuthorizationUseCase) authTime(ctx context.Context, userID uuid.UUID, sessionID string) time.Time {
+	familyID, err := uuid.Parse(sessionID)
+	if err != nil {
+		return time.Now()
+	}
+
+	session, err := uc.sessionRepo.GetByID(ctx, familyID)
+	if err != nil || session.UserID != userID {
+		return time.Now()
+	}
+
+	return session.CreatedAt
+}
+
+// grantConsent acrescenta os escopos ao consentimento do usuário para o cliente
+func (uc *CompleteAuthorizationUseCase) grantConsent(ctx context.Context, userID, clientID uuid.UUID, scopes []string) error {
+	consent, err := uc.consentRepo.Get(ctx, userID, clientID)
+	if err != nil {
+		if !errors.Is(err, pkgerrors.ErrOAuthConsentNotFound) {
+			return err
+		}
+		consent = entity.NewOAuthConsent(userID, clientID, nil)
+	}
+
+	consent.Grant(scopes)
+	if err := uc.consentRepo.Save(ctx, consent); err != nil {
+		return fmt.Errorf("failed to save consent: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/service"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/federation"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+// Erros do login federado entregues ao frontend no parâmetro error
+const (
+	FederatedErrorAccessDenied    = "access_denied"
+	FederatedErrorInvalidState    = "invalid_state"
+	FederatedErrorProvider        = "provider_error"
+	FederatedErrorAccountConflict = "account_conflict"
+	FederatedErrorAccountInactive = "account_inactive"
+)
+
+var (
+	errFederatedEmailMissing    = errors.New("identity provider did not return a valid email")
+	errFederatedAccountConflict = errors.New("email already belongs to an unlinked local account")
+)
+
+type CompleteFederatedLoginInput struct {
+	Provider string
+	State    string
+	Code     string
+	// Error é o erro devolvido pelo provedor no lugar do code (ex.: access_denied)
+	Error string
+	// Binding é o valor guardado em cookie pelo navegador que iniciou o login
+	Binding string
+}
+
+type CompleteFederatedLoginOutput struct {
+	// RedirectTo é a página de login federado do frontend, com o code ou o error
+	RedirectTo string
+}
+
+type CompleteFederatedLoginUseCase struct {
+	registry          *federation.Registry
+	federationStore   *cache.FederationStore
+	userRepo          repository.UserRepository
+	identityRepo      repository.UserIdentityRepository
+	passwordService   *crypto.PasswordService
+	validationService *service.ValidationService
+	callbackBaseURL   string
+	loginURL          string
+}
+
+func NewCompleteFederatedLoginUseCase(
+	registry *federation.Registry,
+	federationStore *cache.FederationStore,
+	userRepo repository.UserRepository,
+	identityRepo repository.UserIdentityRepository,
+	passwordService *crypto.PasswordService,
+	validationService *service.ValidationService,
+	callbackBaseURL string,
+	loginURL string,
+) *CompleteFederatedLoginUseCase {
+	return &CompleteFederatedLoginUseCase{
+		registry:          registry,
+		federationStore:   federationStore,
+		userRepo:          userRepo,
+		identityRepo:      identityRepo,
+		passwordService:   passwordService,
+		validationService: validationService,
+		callbackBaseURL:   callbackBaseURL,
+		loginURL:          loginURL,
+	}
+}
+
+// Execute trata o retorno do provedor: valida o state, troca o code pelo ID token e
+// resolve o usuário local, vinculando ou criando a conta. O frontend recebe um code
+// de uso único, trocado pelos tokens em /auth/federated/exchange; falhas esperadas
+// voltam ao frontend no parâmetro error.
+func (uc *CompleteFederatedLoginUseCase) Execute(ctx context.Context, input CompleteFederatedLoginInput) (*CompleteFederatedLoginOutput, error) {
+	provider, ok := uc.registry.Get(input.Provider)
+	if !ok {
+		return nil, pkgerrors.ErrFederatedProviderNotFound
+	}
+
+	state, err := uc.federationStore.TakeState(ctx, input.State)
+	if err != nil {
+		if errors.Is(err, pkgerrors.ErrFederatedLoginInvalid) {
+			return uc.fail(FederatedErrorInvalidState), nil
+		}
+		return nil, err
+	}
+	if state.Provider != provider.Name() || !uc.sameBrowser(state, input.Binding) {
+		return uc.fail(FederatedErrorInvalidState), nil
+	}
+
+	if input.Error != "" {
+		log.Printf("Federated login with %s returned error: %s", provider.Name(), input.Error)
+		return uc.fail(FederatedErrorAccessDenied), nil
+	}
+
+	identity, err := provider.Exchange(ctx, federatedCallbackURL(uc.callbackBaseURL, provider.Name()), input.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		log.Printf("Federated login with %s failed: %v", provider.Name(), err)
+		return uc.fail(FederatedErrorProvider), nil
+	}
+
+	user, err := uc.resolveUser(ctx, provider, identity)
+	if err != nil {
+		switch {
+		case errors.Is(err, errFederatedEmailMissing):
+			log.Printf("Federated login with %s rejected for subject %s: %v", provider.Name(), identity.Subject, err)
+			return uc.fail(FederatedErrorProvider), nil
+		case errors.Is(err, errFederatedAccountConflict):
+			return uc.fail(FederatedErrorAccountConflict), nil
+		}
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return uc.fail(FederatedErrorAccountInactive), nil
+	}
+
+	code, err := uc.federationStore.CreateCode(ctx, cache.FederatedLogin{
+		UserID:   user.ID,
+		Provider: provider.Name(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CompleteFederatedLoginOutput{
+		RedirectTo: appendQuery(uc.loginURL, url.Values{"code": {code}}),
+	}, nil
+}
+
+// resolveUser encontra o usuário vinculado à identidade. Sem vínculo, uma conta local
+// com o mesmo email só é vinculada se o provedor for confiável e tiver verificado o
+// email; sem conta, o usuário é criado com a role padrão do provedor.
+func (uc *CompleteFederatedLoginUseCase) resolveUser(ctx context.Context, provider *federation.Provider, identity *federation.Identity) (*entity.User, error) {
+	email := uc.validationService.NormalizeEmail(identity.Email)
+
+	linked, err := uc.identityRepo.Get(ctx, provider.Name(), identity.Subject)
+	if err == nil {
+		user, err := uc.userRepo.GetByID(ctx, linked.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		linked.RecordLogin(email)
+		if err := uc.identityRepo.Update(ctx, linked); err != nil {
+			log.Printf("Failed to record federated login for user %s: %v", user.ID, err)
+		}
+		return user, nil
+	}
+	if !errors.Is(err, pkgerrors.ErrUserIdentityNotFound) {
+		return nil, err
+	}
+
+	if err := uc.validationService.ValidateEmail(email); err != nil {
+		return nil, errFederatedEmailMissing
+	}
+
+	user, err := uc.userRepo.GetByEmail(ctx, email)
+	switch {
+	case err == nil:
+		if !provider.TrustEmail() || !identity.EmailVerified {
+			return nil, errFederatedAccountConflict
+		}
+	case errors.Is(err, pkgerrors.ErrUserNotFound):
+		if user, err = uc.provision(ctx, provider, identity, email); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	if err := uc.identityRepo.Create(ctx, entity.NewUserIdentity(provider.Name(), identity.Subject, user.ID, email)); err != nil {
+		return nil, fmt.Errorf("failed to link identity: %w", err)
+	}
+	log.Printf("Linked %s identity %s to user %s", provider.Name(), identity.Subject, user.ID)
+
+	return user, nil
+}
+
+// provision cria o usuário no primeiro login (just-in-time). A senha é aleatória e
+// descartada: a conta entra pelo provedor até o usuário redefinir a senha.
+func (uc *CompleteFederatedLoginUseCase) provision(ctx context.Context, provider *federation.Provider, identity *federation.Identity, email string) (*entity.User, error) {
+	name := profileName(uc.validationService, identity.Name, email)
+
+	password, err := crypto.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	passwordHash, err := uc.passwordService.Hash(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user := entity.NewUser(email, passwordHash, name, entity.UserRole(provider.DefaultRole()))
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	log.Printf("Provisioned user %s (%s) from %s", user.ID, user.Role, provider.Name())
+
+	return user, nil
+}
+
+// sameBrowser confere o cookie de vínculo, impedindo que um callback iniciado por
+// outra pessoa conclua o login neste navegador (login CSRF)
+func (uc *CompleteFederatedLoginUseCase) sameBrowser(state *cache.FederatedLoginState, binding string) bool {
+	if binding == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(entity.HashToken(binding)), []byte(state.BindingHash)) == 1
+}
+
+func (uc *CompleteFederatedLoginUseCase) fail(code string) *CompleteFederatedLoginOutput {
+	return &CompleteFederatedLoginOutput{
+		RedirectTo: appendQuery(uc.loginURL, url.Values{"error": {code}}),
+	}
+}
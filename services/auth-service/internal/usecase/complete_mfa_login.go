@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type CompleteMFALoginInput struct {
+	MFAToken     string
+	Code         string
+	RecoveryCode string
+	IPAddress    string
+	UserAgent    string
+}
+
+type CompleteMFALoginUseCase struct {
+	userRepo         repository.UserRepository
+	recoveryCodeRepo repository.RecoveryCodeRepository
+	mfaStore         *cache.MFAStore
+	loginThrottle    *cache.LoginThrottle
+	verifier         totpVerifier
+	issuer           sessionIssuer
+}
+
+func NewCompleteMFALoginUseCase(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	recoveryCodeRepo repository.RecoveryCodeRepository,
+	jwtService *crypto.JWTService,
+	secretBox *crypto.SecretBox,
+	mfaStore *cache.MFAStore,
+	loginThrottle *cache.LoginThrottle,
+) *CompleteMFALoginUseCase {
+	return &CompleteMFALoginUseCase{
+		userRepo:         userRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		mfaStore:         mfaStore,
+		loginThrottle:    loginThrottle,
+		verifier: totpVerifier{
+			secretBox: secretBox,
+			mfaStore:  mfaStore,
+		},
+		issuer: sessionIssuer{
+			sessionRepo: sessionRepo,
+			jwtService:  jwtService,
+		},
+	}
+}
+
+// Execute troca o desafio de MFA e um código válido (TOTP ou de recuperação) pelos tokens
+func (uc *CompleteMFALoginUseCase) Execute(ctx context.Context, input CompleteMFALoginInput) (*LoginOutput, error) {
+	challenge, err := uc.mfaStore.GetChallenge(ctx, input.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, pkgerrors.ErrMFAChallengeInvalid
+	}
+
+	if !user.IsActive {
+		return nil, pkgerrors.ErrUserInactive
+	}
+
+	// O MFA pode ter sido desativado depois da emissão do desafio
+	if !user.TOTPEnabled {
+		return nil, pkgerrors.ErrMFAChallengeInvalid
+	}
+
+	// O limite de tentativas do desafio não basta: quem sabe a senha pode pedir novos
+	// desafios. Códigos errados contam como falhas de login da conta e do IP.
+	if err := uc.loginThrottle.Check(ctx, user.Email, input.IPAddress); err != nil {
+		return nil, err
+	}
+
+	if err := uc.verifySecondFactor(ctx, user, input); err != nil {
+		if errors.Is(err, pkgerrors.ErrInvalidMFACode) {
+			if recordErr := uc.mfaStore.RecordFailedAttempt(ctx, input.MFAToken); recordErr != nil {
+				return nil, recordErr
+			}
+			if recordErr := uc.loginThrottle.RecordFailure(ctx, user.Email, input.IPAddress); recordErr != nil {
+				return nil, recordErr
+			}
+		}
+		return nil, err
+	}
+
+	if err := uc.mfaStore.DeleteChallenge(ctx, input.MFAToken); err != nil {
+		return nil, err
+	}
+
+	output, err := uc.issuer.issue(ctx, user, input.IPAddress, input.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	// Login concluído: zerar as falhas da conta
+	if err := uc.loginThrottle.Reset(ctx, user.Email); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (uc *CompleteMFALoginUseCase) verifySecondFactor(ctx context.Context, user *entity.User, input CompleteMFALoginInput) error {
+	if strings.TrimSpace(input.RecoveryCode) != "" {
+		codeHash := entity.HashToken(crypto.NormalizeRecoveryCode(input.RecoveryCode))
+		return uc.recoveryCodeRepo.Consume(ctx, user.ID, codeHash)
+	}
+
+	return uc.verifier.verify(ctx, user, input.Code)
+}
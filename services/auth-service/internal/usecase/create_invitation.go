@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/service"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+// maxInvitationExpiry limita a validade que um admin pode definir para um convite
+const maxInvitationExpiry = 30 * 24 * time.Hour
+
+type CreateInvitationInput struct {
+	InvitedBy uuid.UUID
+	Email     string
+	Role      entity.UserRole
+	ExpiresIn time.Duration
+}
+
+type CreateInvitationOutput struct {
+	Invitation InvitationOutput
+	Token      string
+}
+
+type CreateInvitationUseCase struct {
+	invitationRepo    repository.InvitationRepository
+	userRepo          repository.UserRepository
+	validationService *service.ValidationService
+	defaultExpiry     time.Duration
+}
+
+func NewCreateInvitationUseCase(
+	invitationRepo repository.InvitationRepository,
+	userRepo repository.UserRepository,
+	validationService *service.ValidationService,
+	defaultExpiry time.Duration,
+) *CreateInvitationUseCase {
+	return &CreateInvitationUseCase{
+		invitationRepo:    invitationRepo,
+		userRepo:          userRepo,
+		validationService: validationService,
+		defaultExpiry:     defaultExpiry,
+	}
+}
+
+func (uc *CreateInvitationUseCase) Execute(ctx context.Context, input CreateInvitationInput) (*CreateInvitationOutput, error) {
+	// Normalizar email
+	input.Email = uc.validationService.NormalizeEmail(input.Email)
+
+	if err := uc.validationService.ValidateEmail(input.Email); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	if !entity.IsValidRole(input.Role) {
+		return nil, pkgerrors.ErrInvalidRole
+	}
+
+	// Normalizar validade
+	if input.ExpiresIn <= 0 {
+		input.ExpiresIn = uc.defaultExpiry
+	}
+	if input.ExpiresIn > maxInvitationExpiry {
+		input.ExpiresIn = maxInvitationExpiry
+	}
+
+	// Não convidar quem já tem conta
+	exists, err := uc.userRepo.EmailExists(ctx, input.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check email existence: %w", err)
+	}
+	if exists {
+		return nil, pkgerrors.ErrUserAlreadyExists
+	}
+
+	token, err := crypto.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := entity.NewInvitation(input.Email, input.Role, input.InvitedBy, token, time.Now().Add(input.ExpiresIn))
+	if err := uc.invitationRepo.Create(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	// O token só é devolvido nesta resposta; apenas o digest fica no banco
+	return &CreateInvitationOutput{
+		Invitation: toInvitationOutput(invitation),
+		Token:      token,
+	}, nil
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/service"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+)
+
+type CreateOAuthClientInput struct {
+	Name         string
+	RedirectURIs []string
+	// PostLogoutRedirectURIs são os destinos aceitos pelo end_session_endpoint
+	PostLogoutRedirectURIs []string
+	Scopes                 []string
+	// GrantTypes vazio libera entity.DefaultGrantTypes
+	GrantTypes []string
+	// AccessTokenTTL é a validade dos tokens client_credentials (0 usa a padrão)
+	AccessTokenTTL time.Duration
+	// Confidential gera um client_secret; clientes públicos (SPAs, apps nativos) não têm segredo
+	Confidential bool
+	SkipConsent  bool
+}
+
+type CreateOAuthClientOutput struct {
+	Client       OAuthClientOutput
+	ClientSecret string
+}
+
+// Limites da validade configurável dos tokens client_credentials
+const (
+	minClientTokenTTL = time.Minute
+	maxClientTokenTTL = 24 * time.Hour
+)
+
+type CreateOAuthClientUseCase struct {
+	clientRepo        repository.OAuthClientRepository
+	validationService *service.ValidationService
+}
+
+func NewCreateOAuthClientUseCase(
+	clientRepo repository.OAuthClientRepository,
+	validationService *service.ValidationService,
+) *CreateOAuthClientUseCase {
+	return &CreateOAuthClientUseCase{
+		clientRepo:        clientRepo,
+		validationService: validationService,
+	}
+}
+
+func (uc *CreateOAuthClientUseCase) Execute(ctx context.Context, input CreateOAuthClientInput) (*CreateOAuthClientOutput, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	if err := uc.validationService.ValidateName(input.Name); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	grantTypes := entity.ParseScope(strings.Join(input.GrantTypes, " "))
+	if len(grantTypes) == 0 {
+		grantTypes = entity.DefaultGrantTypes
+	}
+	if err := uc.validateGrantTypes(grantTypes, input.Confidential); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	if input.AccessTokenTTL != 0 && (input.AccessTokenTTL < minClientTokenTTL || input.AccessTokenTTL > maxClientTokenTTL) {
+		return nil, fmt.Errorf("validation error: %w", service.ErrInvalidAccessTokenTTL)
+	}
+
+	// O fluxo authorization code exige ao menos uma redirect URI registrada
+	if hasGrantType(grantTypes, entity.GrantTypeAuthorizationCode) && len(input.RedirectURIs) == 0 {
+		return nil, fmt.Errorf("validation error: %w", service.ErrInvalidRedirectURI)
+	}
+	for _, redirectURI := range input.RedirectURIs {
+		if err := uc.validationService.ValidateRedirectURI(redirectURI); err != nil {
+			return nil, fmt.Errorf("validation error: %w", err)
+		}
+	}
+
+	for _, redirectURI := range input.PostLogoutRedirectURIs {
+		if err := uc.validationService.ValidateRedirectURI(redirectURI); err != nil {
+			return nil, fmt.Errorf("validation error: %w", err)
+		}
+	}
+
+	scopes := entity.ParseScope(strings.Join(input.Scopes, " "))
+	for _, scope := range scopes {
+		if err := uc.validationService.ValidateScope(scope); err != nil {
+			return nil, fmt.Errorf("validation error: %w", err)
+		}
+	}
+
+	var secret string
+	if input.Confidential {
+		var err error
+		if secret, err = crypto.GenerateOpaqueToken(); err != nil {
+			return nil, err
+		}
+	}
+
+	client := entity.NewOAuthClient(input.Name, secret, input.RedirectURIs, scopes, grantTypes, input.SkipConsent)
+	client.PostLogoutRedirectURIs = input.PostLogoutRedirectURIs
+	client.AccessTokenTTL = input.AccessTokenTTL
+	if err := uc.clientRepo.Create(ctx, client); err != nil {
+		return nil, fmt.Errorf("failed to create OAuth client: %w", err)
+	}
+
+	// O segredo só é devolvido nesta resposta; apenas o digest fica no banco
+	return &CreateOAuthClientOutput{
+		Client:       toOAuthClientOutput(client),
+		ClientSecret: secret,
+	}, nil
+}
+
+// validateGrantTypes verifica cada grant type e as combinações: refresh_token só
+// acompanha authorization_code e client_credentials exige cliente confidencial
+func (uc *CreateOAuthClientUseCase) validateGrantTypes(grantTypes []string, confidential bool) error {
+	for _, grantType := range grantTypes {
+		if err := uc.validationService.ValidateGrantType(grantType); err != nil {
+			return err
+		}
+	}
+
+	if hasGrantType(grantTypes, entity.GrantTypeRefreshToken) && !hasGrantType(grantTypes, entity.GrantTypeAuthorizationCode) {
+		return service.ErrInvalidClientGrants
+	}
+	if hasGrantType(grantTypes, entity.GrantTypeClientCredentials) && !confidential {
+		return service.ErrInvalidClientGrants
+	}
+
+	return nil
+}
+
+func hasGrantType(grantTypes []string, grantType string) bool {
+	for _, g := range grantTypes {
+		if g == grantType {
+			return true
+		}
+	}
+	return false
+}
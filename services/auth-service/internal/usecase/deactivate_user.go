@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type DeactivateUserInput struct {
+	ActorID uuid.UUID
+	UserID  uuid.UUID
+}
+
+type DeactivateUserUseCase struct {
+	userRepo       repository.UserRepository
+	sessionRepo    repository.SessionRepository
+	revocationList *cache.TokenRevocationList
+}
+
+func NewDeactivateUserUseCase(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	revocationList *cache.TokenRevocationList,
+) *DeactivateUserUseCase {
+	return &DeactivateUserUseCase{
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		revocationList: revocationList,
+	}
+}
+
+func (uc *DeactivateUserUseCase) Execute(ctx context.Context, input DeactivateUserInput) (*UserDetailsOutput, error) {
+	if input.ActorID == input.UserID {
+		return nil, pkgerrors.ErrSelfModification
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Deactivate()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	// Encerrar todas as sessões e access tokens do usuário
+	if err := uc.sessionRepo.RevokeAllByUserID(ctx, user.ID); err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	if err := uc.revocationList.RevokeUserTokens(ctx, user.ID); err != nil {
+		return nil, fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+
+	output := toUserDetailsOutput(user)
+	return &output, nil
+}
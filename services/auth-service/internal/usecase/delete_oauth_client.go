@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+)
+
+type DeleteOAuthClientInput struct {
+	ClientID uuid.UUID
+}
+
+type DeleteOAuthClientUseCase struct {
+	clientRepo repository.OAuthClientRepository
+}
+
+func NewDeleteOAuthClientUseCase(clientRepo repository.OAuthClientRepository) *DeleteOAuthClientUseCase {
+	return &DeleteOAuthClientUseCase{
+		clientRepo: clientRepo,
+	}
+}
+
+// Execute remove o cliente. Consentimentos e sessões (refresh tokens) emitidos a ele
+// são removidos em cascata; access tokens já emitidos valem até expirar.
+func (uc *DeleteOAuthClientUseCase) Execute(ctx context.Context, input DeleteOAuthClientInput) error {
+	return uc.clientRepo.Delete(ctx, input.ClientID)
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+)
+
+type DeletePasskeyInput struct {
+	UserID    uuid.UUID
+	PasskeyID uuid.UUID
+	Password  string
+}
+
+type DeletePasskeyUseCase struct {
+	userRepo       repository.UserRepository
+	credentialRepo repository.WebAuthnCredentialRepository
+	authenticator  Authenticator
+}
+
+func NewDeletePasskeyUseCase(
+	userRepo repository.UserRepository,
+	credentialRepo repository.WebAuthnCredentialRepository,
+	authenticator Authenticator,
+) *DeletePasskeyUseCase {
+	return &DeletePasskeyUseCase{
+		userRepo:       userRepo,
+		credentialRepo: credentialRepo,
+		authenticator:  authenticator,
+	}
+}
+
+func (uc *DeletePasskeyUseCase) Execute(ctx context.Context, input DeletePasskeyInput) error {
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return err
+	}
+
+	// Assim como no TOTP, remover um fator de autenticação exige a senha atual
+	if err := verifyCurrentPassword(ctx, uc.authenticator, user, input.Password); err != nil {
+		return err
+	}
+
+	return uc.credentialRepo.Delete(ctx, input.PasskeyID, user.ID)
+}
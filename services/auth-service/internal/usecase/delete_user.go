@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type DeleteUserInput struct {
+	ActorID uuid.UUID
+	UserID  uuid.UUID
+}
+
+type DeleteUserUseCase struct {
+	userRepo       repository.UserRepository
+	revocationList *cache.TokenRevocationList
+}
+
+func NewDeleteUserUseCase(
+	userRepo repository.UserRepository,
+	revocationList *cache.TokenRevocationList,
+) *DeleteUserUseCase {
+	return &DeleteUserUseCase{
+		userRepo:       userRepo,
+		revocationList: revocationList,
+	}
+}
+
+func (uc *DeleteUserUseCase) Execute(ctx context.Context, input DeleteUserInput) error {
+	if input.ActorID == input.UserID {
+		return pkgerrors.ErrSelfModification
+	}
+
+	// Sessões são removidas em cascata pela FK
+	if err := uc.userRepo.Delete(ctx, input.UserID); err != nil {
+		return err
+	}
+
+	// Access tokens ainda não expirados deixam de valer
+	if err := uc.revocationList.RevokeUserTokens(ctx, input.UserID); err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+
+	return nil
+}
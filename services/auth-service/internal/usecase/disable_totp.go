@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type DisableTOTPInput struct {
+	UserID   uuid.UUID
+	Password string
+}
+
+type DisableTOTPUseCase struct {
+	userRepo         repository.UserRepository
+	recoveryCodeRepo repository.RecoveryCodeRepository
+	authenticator    Authenticator
+}
+
+func NewDisableTOTPUseCase(
+	userRepo repository.UserRepository,
+	recoveryCodeRepo repository.RecoveryCodeRepository,
+	authenticator Authenticator,
+) *DisableTOTPUseCase {
+	return &DisableTOTPUseCase{
+		userRepo:         userRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		authenticator:    authenticator,
+	}
+}
+
+func (uc *DisableTOTPUseCase) Execute(ctx context.Context, input DisableTOTPInput) error {
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return err
+	}
+
+	// Exigir a senha atual para remover o segundo fator
+	if err := verifyCurrentPassword(ctx, uc.authenticator, user, input.Password); err != nil {
+		return err
+	}
+
+	if !user.TOTPEnabled {
+		return pkgerrors.ErrMFANotEnabled
+	}
+
+	user.DisableTOTP()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to disable TOTP: %w", err)
+	}
+
+	if err := uc.recoveryCodeRepo.Replace(ctx, user.ID, nil); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	return nil
+}
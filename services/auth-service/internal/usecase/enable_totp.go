@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+// recoveryCodeCount é a quantidade de códigos de recuperação emitidos ao ativar o MFA
+const recoveryCodeCount = 10
+
+type EnableTOTPInput struct {
+	UserID uuid.UUID
+	Code   string
+}
+
+type EnableTOTPOutput struct {
+	RecoveryCodes []string
+}
+
+type EnableTOTPUseCase struct {
+	userRepo         repository.UserRepository
+	recoveryCodeRepo repository.RecoveryCodeRepository
+	verifier         totpVerifier
+}
+
+func NewEnableTOTPUseCase(
+	userRepo repository.UserRepository,
+	recoveryCodeRepo repository.RecoveryCodeRepository,
+	secretBox *crypto.SecretBox,
+	mfaStore *cache.MFAStore,
+) *EnableTOTPUseCase {
+	return &EnableTOTPUseCase{
+		userRepo:         userRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		verifier: totpVerifier{
+			secretBox: secretBox,
+			mfaStore:  mfaStore,
+		},
+	}
+}
+
+// Execute confirma o segredo pendente com um código válido e emite os códigos de recuperação
+func (uc *EnableTOTPUseCase) Execute(ctx context.Context, input EnableTOTPInput) (*EnableTOTPOutput, error) {
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabled {
+		return nil, pkgerrors.ErrMFAAlreadyEnabled
+	}
+
+	if err := uc.verifier.verify(ctx, user, input.Code); err != nil {
+		return nil, err
+	}
+
+	codes, err := crypto.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, entity.HashToken(crypto.NormalizeRecoveryCode(code)))
+	}
+
+	if err := uc.recoveryCodeRepo.Replace(ctx, user.ID, hashes); err != nil {
+		return nil, fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+
+	user.EnableTOTP()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to enable TOTP: %w", err)
+	}
+
+	// Os códigos só são exibidos nesta resposta
+	return &EnableTOTPOutput{RecoveryCodes: codes}, nil
+}
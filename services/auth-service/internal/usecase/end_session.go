@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type EndSessionInput struct {
+	IDTokenHint string
+	// ClientID é opcional; se informado, precisa ser o aud do id_token_hint
+	ClientID              string
+	PostLogoutRedirectURI string
+	State                 string
+}
+
+type EndSessionOutput struct {
+	// RedirectTo é a post_logout_redirect_uri com o state (vazio sem redirecionamento)
+	RedirectTo string
+}
+
+type EndSessionUseCase struct {
+	clientRepo     repository.OAuthClientRepository
+	sessionRepo    repository.SessionRepository
+	jwtService     *crypto.JWTService
+	revocationList *cache.TokenRevocationList
+	oidcIssuer     string
+}
+
+func NewEndSessionUseCase(
+	clientRepo repository.OAuthClientRepository,
+	sessionRepo repository.SessionRepository,
+	jwtService *crypto.JWTService,
+	revocationList *cache.TokenRevocationList,
+	oidcIssuer string,
+) *EndSessionUseCase {
+	return &EndSessionUseCase{
+		clientRepo:     clientRepo,
+		sessionRepo:    sessionRepo,
+		jwtService:     jwtService,
+		revocationList: revocationList,
+		oidcIssuer:     oidcIssuer,
+	}
+}
+
+// Execute implementa o logout iniciado pelo cliente (OpenID Connect RP-Initiated
+// Logout). A sessão OAuth identificada pelo sid do id_token_hint é revogada junto
+// com seus refresh e access tokens. O hint pode estar expirado.
+func (uc *EndSessionUseCase) Execute(ctx context.Context, input EndSessionInput) (*EndSessionOutput, error) {
+	if input.IDTokenHint == "" {
+		return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidRequest, "id_token_hint obrigatório")
+	}
+
+	claims, err := uc.jwtService.ParseIDTokenHint(input.IDTokenHint, uc.oidcIssuer)
+	if err != nil || len(claims.Audience) != 1 {
+		return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidRequest, "id_token_hint inválido")
+	}
+
+	clientID := claims.Audience[0]
+	if input.ClientID != "" && input.ClientID != clientID {
+		return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidRequest, "client_id não corresponde ao id_token_hint")
+	}
+
+	// A redirect URI é validada antes da revogação para não encerrar a sessão de um pedido inválido
+	var redirectTo string
+	if input.PostLogoutRedirectURI != "" {
+		client, err := uc.lookupClient(ctx, clientID)
+		if err != nil {
+			return nil, err
+		}
+		if !client.HasPostLogoutRedirectURI(input.PostLogoutRedirectURI) {
+			return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidRequest, "post_logout_redirect_uri não registrada para o cliente")
+		}
+
+		redirectTo = input.PostLogoutRedirectURI
+		if input.State != "" {
+			redirectTo = appendQuery(redirectTo, url.Values{"state": {input.State}})
+		}
+	}
+
+	if err := uc.revokeSession(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	return &EndSessionOutput{RedirectTo: redirectTo}, nil
+}
+
+// revokeSession revoga a sessão do sid, se ainda pertencer ao sub do ID token
+func (uc *EndSessionUseCase) revokeSession(ctx context.Context, claims *crypto.IDTokenClaims) error {
+	familyID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidRequest, "id_token_hint sem sessão")
+	}
+
+	session, err := uc.sessionRepo.GetByID(ctx, familyID)
+	if err != nil {
+		// Sessão já removida: nada a revogar
+		if errors.Is(err, pkgerrors.ErrSessionNotFound) {
+			return nil
+		}
+		return err
+	}
+	if session.UserID.String() != claims.Subject {
+		return nil
+	}
+
+	if err := uc.sessionRepo.RevokeFamily(ctx, familyID); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	if err := uc.revocationList.RevokeSession(ctx, familyID); err != nil {
+		return fmt.Errorf("failed to revoke session access tokens: %w", err)
+	}
+
+	return nil
+}
+
+func (uc *EndSessionUseCase) lookupClient(ctx context.Context, clientID string) (*entity.OAuthClient, error) {
+	unknown := pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidRequest, "cliente do id_token_hint desconhecido")
+
+	id, err := uuid.Parse(clientID)
+	if err != nil {
+		return nil, unknown
+	}
+
+	client, err := uc.clientRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pkgerrors.ErrOAuthClientNotFound) {
+			return nil, unknown
+		}
+		return nil, err
+	}
+
+	return client, nil
+}
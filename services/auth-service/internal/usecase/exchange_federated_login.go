@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type ExchangeFederatedLoginInput struct {
+	Code      string
+	IPAddress string
+	UserAgent string
+}
+
+type ExchangeFederatedLoginUseCase struct {
+	userRepo        repository.UserRepository
+	federationStore *cache.FederationStore
+	mfa             mfaGate
+	issuer          sessionIssuer
+}
+
+func NewExchangeFederatedLoginUseCase(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	credentialRepo repository.WebAuthnCredentialRepository,
+	jwtService *crypto.JWTService,
+	mfaStore *cache.MFAStore,
+	federationStore *cache.FederationStore,
+) *ExchangeFederatedLoginUseCase {
+	return &ExchangeFederatedLoginUseCase{
+		userRepo:        userRepo,
+		federationStore: federationStore,
+		mfa: mfaGate{
+			credentialRepo: credentialRepo,
+			mfaStore:       mfaStore,
+		},
+		issuer: sessionIssuer{
+			sessionRepo: sessionRepo,
+			jwtService:  jwtService,
+		},
+	}
+}
+
+// Execute troca o code do login federado pelos tokens, com a mesma resposta do login
+// com senha. O provedor substitui apenas a senha: o segundo fator continua exigido.
+func (uc *ExchangeFederatedLoginUseCase) Execute(ctx context.Context, input ExchangeFederatedLoginInput) (*LoginOutput, error) {
+	login, err := uc.federationStore.TakeCode(ctx, input.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, login.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, pkgerrors.ErrUserInactive
+	}
+
+	challenge, err := uc.mfa.challenge(ctx, user, input.IPAddress, input.UserAgent)
+	if err != nil || challenge != nil {
+		return challenge, err
+	}
+
+	return uc.issuer.issue(ctx, user, input.IPAddress, input.UserAgent)
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/mail"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+// fakeUserRepository guarda usuários em memória, indexados por email. Métodos não
+// implementados causam panic pela interface embutida.
+type fakeUserRepository struct {
+	repository.UserRepository
+	users map[string]*entity.User
+}
+
+func newFakeUserRepository(users ...*entity.User) *fakeUserRepository {
+	repo := &fakeUserRepository{users: make(map[string]*entity.User)}
+	for _, user := range users {
+		repo.users[user.Email] = user
+	}
+	return repo
+}
+
+func (r *fakeUserRepository) Create(ctx context.Context, user *entity.User) error {
+	if _, ok := r.users[user.Email]; ok {
+		return pkgerrors.ErrUserAlreadyExists
+	}
+	r.users[user.Email] = user
+	return nil
+}
+
+func (r *fakeUserRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	user, ok := r.users[email]
+	if !ok {
+		return nil, pkgerrors.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (r *fakeUserRepository) Update(ctx context.Context, user *entity.User) error {
+	r.users[user.Email] = user
+	return nil
+}
+
+func (r *fakeUserRepository) UpdatePasswordHash(ctx context.Context, id uuid.UUID, oldHash, newHash string) error {
+	for _, user := range r.users {
+		if user.ID == id && user.PasswordHash == oldHash {
+			user.PasswordHash = newHash
+		}
+	}
+	return nil
+}
+
+func (r *fakeUserRepository) EmailExists(ctx context.Context, email string) (bool, error) {
+	_, ok := r.users[email]
+	return ok, nil
+}
+
+// countingHasher é um PasswordHasher trivial que conta as verificações feitas
+type countingHasher struct {
+	mu       sync.Mutex
+	verified []string
+}
+
+func (h *countingHasher) Hash(password string) (string, error) {
+	return "fake$" + password, nil
+}
+
+func (h *countingHasher) Recognizes(encoded string) bool {
+	return strings.HasPrefix(encoded, "fake$")
+}
+
+func (h *countingHasher) Verify(encoded, password string) error {
+	h.mu.Lock()
+	h.verified = append(h.verified, encoded)
+	h.mu.Unlock()
+
+	if encoded != "fake$"+password {
+		return pkgerrors.ErrInvalidCredentials
+	}
+	return nil
+}
+
+func (h *countingHasher) NeedsRehash(encoded string) bool {
+	return false
+}
+
+func (h *countingHasher) verifications() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.verified...)
+}
+
+// recordingMailer entrega as mensagens em um canal, já que o envio é assíncrono
+type recordingMailer struct {
+	sent chan mail.Message
+}
+
+func newRecordingMailer() *recordingMailer {
+	return &recordingMailer{sent: make(chan mail.Message, 10)}
+}
+
+func (m *recordingMailer) Send(ctx context.Context, msg mail.Message) error {
+	m.sent <- msg
+	return nil
+}
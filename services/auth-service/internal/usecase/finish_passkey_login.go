@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/passkey"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type FinishPasskeyLoginInput struct {
+	ChallengeToken string
+	MFAToken       string
+	// Credential é o JSON da PublicKeyCredential retornada por navigator.credentials.get
+	Credential []byte
+	IPAddress  string
+	UserAgent  string
+}
+
+type FinishPasskeyLoginUseCase struct {
+	userRepo       repository.UserRepository
+	credentialRepo repository.WebAuthnCredentialRepository
+	passkeyService *passkey.Service
+	webAuthnStore  *cache.WebAuthnStore
+	mfaStore       *cache.MFAStore
+	issuer         sessionIssuer
+}
+
+func NewFinishPasskeyLoginUseCase(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	credentialRepo repository.WebAuthnCredentialRepository,
+	jwtService *crypto.JWTService,
+	passkeyService *passkey.Service,
+	webAuthnStore *cache.WebAuthnStore,
+	mfaStore *cache.MFAStore,
+) *FinishPasskeyLoginUseCase {
+	return &FinishPasskeyLoginUseCase{
+		userRepo:       userRepo,
+		credentialRepo: credentialRepo,
+		passkeyService: passkeyService,
+		webAuthnStore:  webAuthnStore,
+		mfaStore:       mfaStore,
+		issuer: sessionIssuer{
+			sessionRepo: sessionRepo,
+			jwtService:  jwtService,
+		},
+	}
+}
+
+// Execute valida a asserção e emite os tokens. O desafio é de uso único, mesmo em caso de falha.
+func (uc *FinishPasskeyLoginUseCase) Execute(ctx context.Context, input FinishPasskeyLoginInput) (*LoginOutput, error) {
+	session, err := uc.webAuthnStore.TakeLogin(ctx, input.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.MFAToken != "" {
+		return uc.finishSecondFactor(ctx, session, input)
+	}
+
+	user, assertion, err := uc.passkeyService.FinishDiscoverableLogin(session, input.Credential, func(userID uuid.UUID) (*entity.User, []*entity.WebAuthnCredential, error) {
+		user, err := uc.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		credentials, err := uc.credentialRepo.GetByUserID(ctx, user.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return user, credentials, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, pkgerrors.ErrUserInactive
+	}
+
+	// A passkey substitui senha e segundo fator apenas com verificação do usuário
+	if !assertion.UserVerified {
+		return nil, pkgerrors.ErrPasskeyVerification
+	}
+
+	if err := uc.recordAssertion(ctx, user.ID, assertion); err != nil {
+		return nil, err
+	}
+
+	return uc.issuer.issue(ctx, user, input.IPAddress, input.UserAgent)
+}
+
+func (uc *FinishPasskeyLoginUseCase) finishSecondFactor(ctx context.Context, session []byte, input FinishPasskeyLoginInput) (*LoginOutput, error) {
+	challenge, err := uc.mfaStore.GetChallenge(ctx, input.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, pkgerrors.ErrMFAChallengeInvalid
+	}
+
+	if !user.IsActive {
+		return nil, pkgerrors.ErrUserInactive
+	}
+
+	credentials, err := uc.credentialRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list passkeys: %w", err)
+	}
+
+	// O desafio WebAuthn está vinculado ao usuário do desafio MFA que o originou
+	assertion, err := uc.passkeyService.FinishLogin(user, credentials, session, input.Credential)
+	if err == nil {
+		err = uc.recordAssertion(ctx, user.ID, assertion)
+	}
+	if err != nil {
+		if errors.Is(err, pkgerrors.ErrPasskeyVerification) || errors.Is(err, pkgerrors.ErrPasskeyCloned) {
+			if recordErr := uc.mfaStore.RecordFailedAttempt(ctx, input.MFAToken); recordErr != nil {
+				return nil, recordErr
+			}
+		}
+		return nil, err
+	}
+
+	if err := uc.mfaStore.DeleteChallenge(ctx, input.MFAToken); err != nil {
+		return nil, err
+	}
+
+	return uc.issuer.issue(ctx, user, input.IPAddress, input.UserAgent)
+}
+
+// recordAssertion aplica a verificação do contador de assinaturas e persiste o uso da credencial
+func (uc *FinishPasskeyLoginUseCase) recordAssertion(ctx context.Context, userID uuid.UUID, assertion *passkey.Assertion) error {
+	credential, err := uc.credentialRepo.GetByCredentialID(ctx, assertion.CredentialID)
+	if err != nil {
+		return err
+	}
+
+	if credential.UserID != userID {
+		return pkgerrors.ErrPasskeyVerification
+	}
+
+	// Contador que não avança indica uma possível cópia da chave privada
+	if assertion.CloneWarning {
+		return pkgerrors.ErrPasskeyCloned
+	}
+
+	credential.RecordUse(assertion.SignCount, assertion.BackupState)
+	if err := uc.credentialRepo.UpdateUsage(ctx, credential); err != nil {
+		return fmt.Errorf("failed to update passkey usage: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/service"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/passkey"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+// defaultPasskeyName é usado quando o usuário não nomeia a passkey
+const defaultPasskeyName = "Passkey"
+
+type FinishPasskeyRegistrationInput struct {
+	UserID uuid.UUID
+	Name   string
+	// Credential é o JSON da PublicKeyCredential retornada por navigator.credentials.create
+	Credential []byte
+}
+
+type FinishPasskeyRegistrationUseCase struct {
+	userRepo          repository.UserRepository
+	credentialRepo    repository.WebAuthnCredentialRepository
+	passkeyService    *passkey.Service
+	webAuthnStore     *cache.WebAuthnStore
+	validationService *service.ValidationService
+}
+
+func NewFinishPasskeyRegistrationUseCase(
+	userRepo repository.UserRepository,
+	credentialRepo repository.WebAuthnCredentialRepository,
+	passkeyService *passkey.Service,
+	webAuthnStore *cache.WebAuthnStore,
+	validationService *service.ValidationService,
+) *FinishPasskeyRegistrationUseCase {
+	return &FinishPasskeyRegistrationUseCase{
+		userRepo:          userRepo,
+		credentialRepo:    credentialRepo,
+		passkeyService:    passkeyService,
+		webAuthnStore:     webAuthnStore,
+		validationService: validationService,
+	}
+}
+
+// Execute valida a atestação contra o desafio pendente e persiste a nova credencial
+func (uc *FinishPasskeyRegistrationUseCase) Execute(ctx context.Context, input FinishPasskeyRegistrationInput) (*PasskeyOutput, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		name = defaultPasskeyName
+	}
+	if err := uc.validationService.ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	session, err := uc.webAuthnStore.TakeRegistration(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, pkgerrors.ErrUserInactive
+	}
+
+	existing, err := uc.credentialRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list passkeys: %w", err)
+	}
+
+	credential, err := uc.passkeyService.FinishRegistration(user, existing, session, input.Credential)
+	if err != nil {
+		return nil, err
+	}
+
+	credential.Name = name
+	if err := uc.credentialRepo.Create(ctx, credential); err != nil {
+		return nil, err
+	}
+
+	output := toPasskeyOutput(credential)
+	return &output, nil
+}
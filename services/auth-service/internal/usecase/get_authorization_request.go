@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type GetAuthorizationRequestInput struct {
+	RequestID string
+	UserID    uuid.UUID
+}
+
+type GetAuthorizationRequestOutput struct {
+	ClientID   string
+	ClientName string
+	Scopes     []string
+	// ConsentRequired indica que o usuário ainda não autorizou todos os escopos pedidos
+	ConsentRequired bool
+}
+
+type GetAuthorizationRequestUseCase struct {
+	clientRepo  repository.OAuthClientRepository
+	consentRepo repository.OAuthConsentRepository
+	oauthStore  *cache.OAuthStore
+}
+
+func NewGetAuthorizationRequestUseCase(
+	clientRepo repository.OAuthClientRepository,
+	consentRepo repository.OAuthConsentRepository,
+	oauthStore *cache.OAuthStore,
+) *GetAuthorizationRequestUseCase {
+	return &GetAuthorizationRequestUseCase{
+		clientRepo:  clientRepo,
+		consentRepo: consentRepo,
+		oauthStore:  oauthStore,
+	}
+}
+
+// Execute retorna os dados exibidos na tela de consentimento
+func (uc *GetAuthorizationRequestUseCase) Execute(ctx context.Context, input GetAuthorizationRequestInput) (*GetAuthorizationRequestOutput, error) {
+	request, err := uc.oauthStore.GetRequest(ctx, input.RequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := uc.clientRepo.GetByID(ctx, request.ClientID)
+	if err != nil {
+		if errors.Is(err, pkgerrors.ErrOAuthClientNotFound) {
+			return nil, pkgerrors.ErrAuthorizationRequestInvalid
+		}
+		return nil, err
+	}
+
+	consentRequired, err := consentRequired(ctx, uc.consentRepo, client, input.UserID, request.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetAuthorizationRequestOutput{
+		ClientID:        client.ID.String(),
+		ClientName:      client.Name,
+		Scopes:          request.Scopes,
+		ConsentRequired: consentRequired,
+	}, nil
+}
+
+// consentRequired indica se o usuário precisa autorizar os escopos para o cliente
+func consentRequired(ctx context.Context, consentRepo repository.OAuthConsentRepository, client *entity.OAuthClient, userID uuid.UUID, scopes []string) (bool, error) {
+	if client.SkipConsent {
+		return false, nil
+	}
+
+	consent, err := consentRepo.Get(ctx, userID, client.ID)
+	if err != nil {
+		if errors.Is(err, pkgerrors.ErrOAuthConsentNotFound) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return !consent.Covers(scopes), nil
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+)
+
+type GetUserInput struct {
+	UserID uuid.UUID
+}
+
+type UserDetailsOutput struct {
+	ID        string
+	Email     string
+	Name      string
+	Role      string
+	IsActive  bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type GetUserUseCase struct {
+	userRepo repository.UserRepository
+}
+
+func NewGetUserUseCase(userRepo repository.UserRepository) *GetUserUseCase {
+	return &GetUserUseCase{
+		userRepo: userRepo,
+	}
+}
+
+func (uc *GetUserUseCase) Execute(ctx context.Context, input GetUserInput) (*UserDetailsOutput, error) {
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := toUserDetailsOutput(user)
+	return &output, nil
+}
+
+func toUserDetailsOutput(user *entity.User) UserDetailsOutput {
+	return UserDetailsOutput{
+		ID:        user.ID.String(),
+		Email:     user.Email,
+		Name:      user.Name,
+		Role:      string(user.Role),
+		IsActive:  user.IsActive,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type GetUserInfoInput struct {
+	UserID uuid.UUID
+	// Scope são os escopos concedidos ao access token apresentado
+	Scope string
+}
+
+// UserInfoOutput são as claims do usuário liberadas pelos escopos concedidos.
+// Campos vazios não foram autorizados.
+type UserInfoOutput struct {
+	Subject   string
+	Name      string
+	Email     string
+	Role      string
+	UpdatedAt int64
+}
+
+type GetUserInfoUseCase struct {
+	userRepo repository.UserRepository
+}
+
+func NewGetUserInfoUseCase(userRepo repository.UserRepository) *GetUserInfoUseCase {
+	return &GetUserInfoUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute implementa o userinfo endpoint do OpenID Connect. Exige um access token
+// emitido a um cliente OAuth com o escopo openid.
+func (uc *GetUserInfoUseCase) Execute(ctx context.Context, input GetUserInfoInput) (*UserInfoOutput, error) {
+	scopes := entity.ParseScope(input.Scope)
+	if !entity.HasScope(scopes, entity.ScopeOpenID) {
+		return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthInsufficientScope, "o access token não tem o escopo openid")
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		if errors.Is(err, pkgerrors.ErrUserNotFound) {
+			return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidToken, err.Error())
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.IsActive {
+		return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidToken, pkgerrors.ErrUserInactive.Error())
+	}
+
+	info := userInfoClaims(user, scopes)
+	return &info, nil
+}
+
+// userInfoClaims monta as claims do usuário para o userinfo e o ID token:
+// profile libera name, role e updated_at; email libera email
+func userInfoClaims(user *entity.User, scopes []string) UserInfoOutput {
+	info := UserInfoOutput{Subject: user.ID.String()}
+
+	if entity.HasScope(scopes, entity.ScopeProfile) {
+		info.Name = user.Name
+		info.Role = string(user.Role)
+		info.UpdatedAt = user.UpdatedAt.Unix()
+	}
+	if entity.HasScope(scopes, entity.ScopeEmail) {
+		info.Email = user.Email
+	}
+
+	return info
+}
@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+// Valores de token_type_hint (RFC 7009, seção 2.1)
+const (
+	TokenTypeHintAccessToken  = "access_token"
+	TokenTypeHintRefreshToken = "refresh_token"
+)
+
+type IntrospectTokenInput struct {
+	ClientID      string
+	ClientSecret  string
+	Token         string
+	TokenTypeHint string
+}
+
+// IntrospectTokenOutput descreve o token; com Active false, os demais campos ficam vazios
+type IntrospectTokenOutput struct {
+	Active bool
+	// TokenType é TokenTypeHintAccessToken ou TokenTypeHintRefreshToken
+	TokenType   string
+	SubjectType string
+	Subject     string
+	Username    string
+	ClientID    string
+	Scope       string
+	TokenID     string
+	ExpiresAt   time.Time
+	IssuedAt    time.Time
+}
+
+type IntrospectTokenUseCase struct {
+	clientAuth     oauthClientAuthenticator
+	clientRepo     repository.OAuthClientRepository
+	userRepo       repository.UserRepository
+	sessionRepo    repository.SessionRepository
+	jwtService     *crypto.JWTService
+	revocationList *cache.TokenRevocationList
+}
+
+func NewIntrospectTokenUseCase(
+	clientRepo repository.OAuthClientRepository,
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	jwtService *crypto.JWTService,
+	revocationList *cache.TokenRevocationList,
+) *IntrospectTokenUseCase {
+	return &IntrospectTokenUseCase{
+		clientAuth:     oauthClientAuthenticator{clientRepo: clientRepo},
+		clientRepo:     clientRepo,
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		jwtService:     jwtService,
+		revocationList: revocationList,
+	}
+}
+
+// Execute implementa a introspecção de tokens (RFC 7662). Apenas clientes
+// confidenciais podem consultar; tokens desconhecidos, expirados ou revogados
+// resultam em Active false. Refresh tokens só são descritos ao próprio cliente.
+func (uc *IntrospectTokenUseCase) Execute(ctx context.Context, input IntrospectTokenInput) (*IntrospectTokenOutput, error) {
+	client, err := uc.clientAuth.authenticate(ctx, input.ClientID, input.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+	if !client.IsConfidential() {
+		return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidClient, "a introspecção exige um cliente confidencial")
+	}
+
+	if input.Token == "" {
+		return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidRequest, "token obrigatório")
+	}
+
+	lookups := []func(context.Context, *entity.OAuthClient, string) (*IntrospectTokenOutput, error){
+		uc.introspectAccessToken,
+		uc.introspectRefreshToken,
+	}
+	if input.TokenTypeHint == TokenTypeHintRefreshToken {
+		lookups[0], lookups[1] = lookups[1], lookups[0]
+	}
+
+	for _, lookup := range lookups {
+		output, err := lookup(ctx, client, input.Token)
+		if err != nil {
+			return nil, err
+		}
+		if output != nil {
+			return output, nil
+		}
+	}
+
+	return &IntrospectTokenOutput{Active: false}, nil
+}
+
+// introspectAccessToken retorna nil quando o token não é um access token válido
+func (uc *IntrospectTokenUseCase) introspectAccessToken(ctx context.Context, _ *entity.OAuthClient, token string) (*IntrospectTokenOutput, error) {
+	claims, err := uc.jwtService.ValidateAccessToken(token)
+	if err != nil {
+		return nil, nil
+	}
+
+	inactive := &IntrospectTokenOutput{Active: false}
+
+	revoked, err := uc.revocationList.IsRevoked(ctx, claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	if revoked {
+		return inactive, nil
+	}
+
+	output := &IntrospectTokenOutput{
+		Active:      true,
+		TokenType:   TokenTypeHintAccessToken,
+		SubjectType: crypto.SubjectTypeUser,
+		Subject:     claims.Subject,
+		ClientID:    claims.ClientID,
+		Scope:       claims.Scope,
+		TokenID:     claims.ID,
+		ExpiresAt:   claims.ExpiresAt.Time,
+		IssuedAt:    claims.IssuedAt.Time,
+	}
+
+	if claims.IsClient() {
+		output.SubjectType = crypto.SubjectTypeClient
+		if active, err := uc.clientActive(ctx, claims.ClientID); err != nil || !active {
+			return inactive, err
+		}
+		return output, nil
+	}
+
+	username, active, err := uc.userActive(ctx, claims.UserID)
+	if err != nil || !active {
+		return inactive, err
+	}
+	output.Username = username
+
+	return output, nil
+}
+
+// introspectRefreshToken retorna nil quando o token não corresponde a uma sessão
+func (uc *IntrospectTokenUseCase) introspectRefreshToken(ctx context.Context, client *entity.OAuthClient, token string) (*IntrospectTokenOutput, error) {
+	session, err := uc.sessionRepo.GetByRefreshToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, pkgerrors.ErrSessionNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	inactive := &IntrospectTokenOutput{Active: false}
+	if !session.IsValid() || session.IsRotated() || !session.IssuedTo(&client.ID) {
+		return inactive, nil
+	}
+
+	username, active, err := uc.userActive(ctx, session.UserID)
+	if err != nil || !active {
+		return inactive, err
+	}
+
+	return &IntrospectTokenOutput{
+		Active:      true,
+		TokenType:   TokenTypeHintRefreshToken,
+		SubjectType: crypto.SubjectTypeUser,
+		Subject:     session.UserID.String(),
+		Username:    username,
+		ClientID:    client.ID.String(),
+		Scope:       session.Scope,
+		ExpiresAt:   session.ExpiresAt,
+	}, nil
+}
+
+// userActive retorna o email do usuário e se ele ainda existe e está ativo
+func (uc *IntrospectTokenUseCase) userActive(ctx context.Context, userID uuid.UUID) (string, bool, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pkgerrors.ErrUserNotFound) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return user.Email, user.IsActive, nil
+}
+
+// clientActive indica se o cliente de um token de serviço ainda existe e está ativo
+func (uc *IntrospectTokenUseCase) clientActive(ctx context.Context, clientID string) (bool, error) {
+	id, err := uuid.Parse(clientID)
+	if err != nil {
+		return false, nil
+	}
+
+	client, err := uc.clientRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pkgerrors.ErrOAuthClientNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return client.IsActive, nil
+}
@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/service"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/directory"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+// LDAPIdentityProvider é o provedor registrado em user_identities para as contas do diretório
+const LDAPIdentityProvider = "ldap"
+
+// directoryRolePrecedence define a role atribuída a quem pertence a grupos de várias roles
+var directoryRolePrecedence = []entity.UserRole{
+	entity.RoleAdmin,
+	entity.RoleOperator,
+	entity.RoleAnalyst,
+	entity.RoleViewer,
+}
+
+// LDAPAuthenticator valida a senha por bind no diretório (OpenLDAP ou Active
+// Directory). O diretório é a fonte do nome, do email e da role: a cada login o
+// usuário local é criado ou atualizado a partir da entrada e dos grupos.
+type LDAPAuthenticator struct {
+	directory         *directory.Directory
+	userRepo          repository.UserRepository
+	identityRepo      repository.UserIdentityRepository
+	passwordService   *crypto.PasswordService
+	validationService *service.ValidationService
+	roleGroups        map[entity.UserRole][]string
+	defaultRole       entity.UserRole
+	fallback          Authenticator
+}
+
+// NewLDAPAuthenticator cria o autenticador. roleGroups associa cada role aos DNs dos
+// grupos que a concedem; quem não está em nenhum recebe defaultRole ou, se vazia, tem
+// o acesso negado. fallback (opcional) autentica as contas que não existem no
+// diretório, como o admin local.
+func NewLDAPAuthenticator(
+	dir *directory.Directory,
+	userRepo repository.UserRepository,
+	identityRepo repository.UserIdentityRepository,
+	passwordService *crypto.PasswordService,
+	validationService *service.ValidationService,
+	roleGroups map[entity.UserRole][]string,
+	defaultRole entity.UserRole,
+	fallback Authenticator,
+) *LDAPAuthenticator {
+	return &LDAPAuthenticator{
+		directory:         dir,
+		userRepo:          userRepo,
+		identityRepo:      identityRepo,
+		passwordService:   passwordService,
+		validationService: validationService,
+		roleGroups:        roleGroups,
+		defaultRole:       defaultRole,
+		fallback:          fallback,
+	}
+}
+
+func (a *LDAPAuthenticator) Authenticate(ctx context.Context, login, password string) (*entity.User, error) {
+	entry, err := a.directory.Authenticate(login, password)
+	if err != nil {
+		if errors.Is(err, directory.ErrUserNotFound) {
+			return a.authenticateLocal(ctx, login, password)
+		}
+		// Diretório fora do ar não cai no banco local: a conta pode ter sido
+		// desativada no diretório
+		return nil, err
+	}
+
+	role, ok := a.resolveRole(entry)
+	if !ok {
+		log.Printf("Directory login refused for %s: no group mapped to a role", entry.DN)
+		return nil, pkgerrors.ErrDirectoryAccessDenied
+	}
+
+	return a.resolveUser(ctx, entry, role)
+}
+
+// authenticateLocal usa o fallback para contas fora do diretório. Contas vinculadas
+// ao diretório são recusadas: se a entrada sumiu, o acesso foi revogado.
+func (a *LDAPAuthenticator) authenticateLocal(ctx context.Context, login, password string) (*entity.User, error) {
+	if a.fallback == nil {
+		a.passwordService.CompareDummy(password)
+		return nil, pkgerrors.ErrInvalidCredentials
+	}
+
+	user, err := a.fallback.Authenticate(ctx, login, password)
+	if err != nil {
+		return nil, err
+	}
+
+	directoryAccount, err := isDirectoryAccount(ctx, a.identityRepo, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if directoryAccount {
+		log.Printf("Local login refused for user %s: account is linked to a directory entry no longer found", user.ID)
+		return nil, pkgerrors.ErrInvalidCredentials
+	}
+
+	return user, nil
+}
+
+// isDirectoryAccount indica se o usuário está vinculado a uma entrada do diretório
+func isDirectoryAccount(ctx context.Context, identityRepo repository.UserIdentityRepository, userID uuid.UUID) (bool, error) {
+	identities, err := identityRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, identity := range identities {
+		if identity.Provider == LDAPIdentityProvider {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// resolveRole retorna a role de maior precedência entre os grupos do usuário
+func (a *LDAPAuthenticator) resolveRole(entry *directory.Entry) (entity.UserRole, bool) {
+	for _, role := range directoryRolePrecedence {
+		for _, group := range a.roleGroups[role] {
+			if entry.MemberOf(group) {
+				return role, true
+			}
+		}
+	}
+	return a.defaultRole, a.defaultRole != ""
+}
+
+// resolveUser encontra o usuário vinculado à entrada e sincroniza os dados do
+// diretório. Sem vínculo, um novo usuário é criado; se o email já pertence a uma conta
+// local, o login é recusado: vinculá-la trocaria a role de uma conta que o diretório
+// não controla, e o bind não comprova que o dono da entrada é o dono da conta.
+func (a *LDAPAuthenticator) resolveUser(ctx context.Context, entry *directory.Entry, role entity.UserRole) (*entity.User, error) {
+	email := a.validationService.NormalizeEmail(entry.Email)
+	name := profileName(a.validationService, entry.Name, email)
+
+	linked, err := a.identityRepo.Get(ctx, LDAPIdentityProvider, entry.ID)
+	if err == nil {
+		user, err := a.userRepo.GetByID(ctx, linked.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.sync(ctx, user, email, name, role); err != nil {
+			return nil, err
+		}
+
+		linked.RecordLogin(email)
+		if err := a.identityRepo.Update(ctx, linked); err != nil {
+			log.Printf("Failed to record directory login for user %s: %v", user.ID, err)
+		}
+		return user, nil
+	}
+	if !errors.Is(err, pkgerrors.ErrUserIdentityNotFound) {
+		return nil, err
+	}
+
+	if err := a.validationService.ValidateEmail(email); err != nil {
+		return nil, fmt.Errorf("directory entry %s has no valid email: %w", entry.DN, err)
+	}
+
+	user, err := a.userRepo.GetByEmail(ctx, email)
+	switch {
+	case err == nil:
+		log.Printf("Directory login refused for %s: email belongs to unlinked local user %s", entry.DN, user.ID)
+		return nil, pkgerrors.ErrDirectoryAccountConflict
+	case errors.Is(err, pkgerrors.ErrUserNotFound):
+		if user, err = a.provision(ctx, email, name, role); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	if err := a.identityRepo.Create(ctx, entity.NewUserIdentity(LDAPIdentityProvider, entry.ID, user.ID, email)); err != nil {
+		return nil, fmt.Errorf("failed to link directory entry: %w", err)
+	}
+	log.Printf("Linked directory entry %s to user %s", entry.DN, user.ID)
+
+	return user, nil
+}
+
+// sync aplica nome, email e role do diretório. A falha impede o login, para que uma
+// role reduzida no diretório nunca deixe de valer. Tokens já emitidos mantêm a role
+// antiga até expirarem.
+func (a *LDAPAuthenticator) sync(ctx context.Context, user *entity.User, email, name string, role entity.UserRole) error {
+	if email != user.Email && !a.emailAvailable(ctx, user, email) {
+		email = user.Email
+	}
+
+	previousRole := user.Role
+	if !user.SyncProfile(email, name, role) {
+		return nil
+	}
+
+	if err := a.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to sync user from directory: %w", err)
+	}
+	if previousRole != role {
+		log.Printf("Synced role of user %s from directory: %s -> %s", user.ID, previousRole, role)
+	}
+
+	return nil
+}
+
+// emailAvailable indica se o email vindo do diretório pode substituir o atual
+func (a *LDAPAuthenticator) emailAvailable(ctx context.Context, user *entity.User, email string) bool {
+	if a.validationService.ValidateEmail(email) != nil {
+		return false
+	}
+
+	owner, err := a.userRepo.GetByEmail(ctx, email)
+	if err == nil && owner.ID != user.ID {
+		log.Printf("Directory email of user %s already belongs to user %s; keeping %s", user.ID, owner.ID, user.Email)
+		return false
+	}
+	return errors.Is(err, pkgerrors.ErrUserNotFound)
+}
+
+// provision cria o usuário no primeiro login. A senha local é aleatória e descartada:
+// a senha vale no diretório.
+func (a *LDAPAuthenticator) provision(ctx context.Context, email, name string, role entity.UserRole) (*entity.User, error) {
+	password, err := crypto.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	passwordHash, err := a.passwordService.Hash(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user := entity.NewUser(email, passwordHash, name, role)
+	if err := a.userRepo.Create(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	log.Printf("Provisioned user %s (%s) from directory", user.ID, user.Role)
+
+	return user, nil
+}
+
+// profileName usa o nome informado pelo provedor ou, se inválido, a parte local do email
+func profileName(validationService *service.ValidationService, name, email string) string {
+	name = strings.TrimSpace(name)
+	if validationService.ValidateName(name) == nil {
+		return name
+	}
+
+	name, _, _ = strings.Cut(email, "@")
+	if validationService.ValidateName(name) == nil {
+		return name
+	}
+	return email
+}
@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/federation"
+)
+
+type FederatedProviderOutput struct {
+	Name        string
+	DisplayName string
+}
+
+type ListFederatedProvidersUseCase struct {
+	registry *federation.Registry
+}
+
+func NewListFederatedProvidersUseCase(registry *federation.Registry) *ListFederatedProvidersUseCase {
+	return &ListFederatedProvidersUseCase{registry: registry}
+}
+
+// Execute lista os provedores de identidade externos disponíveis no login
+func (uc *ListFederatedProvidersUseCase) Execute(ctx context.Context) ([]FederatedProviderOutput, error) {
+	providers := make([]FederatedProviderOutput, 0, len(uc.registry.Providers()))
+	for _, provider := range uc.registry.Providers() {
+		providers = append(providers, FederatedProviderOutput{
+			Name:        provider.Name(),
+			DisplayName: provider.DisplayName(),
+		})
+	}
+	return providers, nil
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+)
+
+type ListInvitationsInput struct {
+	Page    int
+	PerPage int
+}
+
+type InvitationOutput struct {
+	ID         string
+	Email      string
+	Role       string
+	Status     string
+	InvitedBy  string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	AcceptedAt *time.Time
+}
+
+type ListInvitationsOutput struct {
+	Invitations []InvitationOutput
+	Page        int
+	PerPage     int
+	Total       int
+}
+
+type ListInvitationsUseCase struct {
+	invitationRepo repository.InvitationRepository
+}
+
+func NewListInvitationsUseCase(invitationRepo repository.InvitationRepository) *ListInvitationsUseCase {
+	return &ListInvitationsUseCase{
+		invitationRepo: invitationRepo,
+	}
+}
+
+func (uc *ListInvitationsUseCase) Execute(ctx context.Context, input ListInvitationsInput) (*ListInvitationsOutput, error) {
+	// Normalizar paginação
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PerPage < 1 {
+		input.PerPage = defaultUsersPerPage
+	}
+	if input.PerPage > maxUsersPerPage {
+		input.PerPage = maxUsersPerPage
+	}
+
+	offset := (input.Page - 1) * input.PerPage
+	invitations, err := uc.invitationRepo.List(ctx, input.PerPage, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+
+	total, err := uc.invitationRepo.Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count invitations: %w", err)
+	}
+
+	output := &ListInvitationsOutput{
+		Invitations: make([]InvitationOutput, 0, len(invitations)),
+		Page:        input.Page,
+		PerPage:     input.PerPage,
+		Total:       total,
+	}
+	for _, invitation := range invitations {
+		output.Invitations = append(output.Invitations, toInvitationOutput(invitation))
+	}
+
+	return output, nil
+}
+
+func toInvitationOutput(invitation *entity.Invitation) InvitationOutput {
+	return InvitationOutput{
+		ID:         invitation.ID.String(),
+		Email:      invitation.Email,
+		Role:       string(invitation.Role),
+		Status:     string(invitation.Status()),
+		InvitedBy:  invitation.InvitedBy.String(),
+		ExpiresAt:  invitation.ExpiresAt,
+		CreatedAt:  invitation.CreatedAt,
+		AcceptedAt: invitation.AcceptedAt,
+	}
+}
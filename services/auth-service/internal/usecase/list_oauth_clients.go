@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+)
+
+type OAuthClientOutput struct {
+	ClientID     string
+	Name         string
+	Confidential bool
+	RedirectURIs []string
+	// PostLogoutRedirectURIs são os destinos aceitos após o logout
+	PostLogoutRedirectURIs []string
+	Scopes                 []string
+	GrantTypes             []string
+	// AccessTokenTTL é a validade dos tokens client_credentials (0 usa a padrão)
+	AccessTokenTTL time.Duration
+	SkipConsent    bool
+	IsActive       bool
+	CreatedAt      time.Time
+}
+
+type ListOAuthClientsUseCase struct {
+	clientRepo repository.OAuthClientRepository
+}
+
+func NewListOAuthClientsUseCase(clientRepo repository.OAuthClientRepository) *ListOAuthClientsUseCase {
+	return &ListOAuthClientsUseCase{
+		clientRepo: clientRepo,
+	}
+}
+
+func (uc *ListOAuthClientsUseCase) Execute(ctx context.Context) ([]OAuthClientOutput, error) {
+	clients, err := uc.clientRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list OAuth clients: %w", err)
+	}
+
+	output := make([]OAuthClientOutput, 0, len(clients))
+	for _, client := range clients {
+		output = append(output, toOAuthClientOutput(client))
+	}
+
+	return output, nil
+}
+
+func toOAuthClientOutput(client *entity.OAuthClient) OAuthClientOutput {
+	return OAuthClientOutput{
+		ClientID:               client.ID.String(),
+		Name:                   client.Name,
+		Confidential:           client.IsConfidential(),
+		RedirectURIs:           client.RedirectURIs,
+		PostLogoutRedirectURIs: client.PostLogoutRedirectURIs,
+		Scopes:                 client.Scopes,
+		GrantTypes:             client.GrantTypes,
+		AccessTokenTTL:         client.AccessTokenTTL,
+		SkipConsent:            client.SkipConsent,
+		IsActive:               client.IsActive,
+		CreatedAt:              client.CreatedAt,
+	}
+}
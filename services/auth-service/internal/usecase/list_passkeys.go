@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+)
+
+type ListPasskeysInput struct {
+	UserID uuid.UUID
+}
+
+type PasskeyOutput struct {
+	ID         uuid.UUID
+	Name       string
+	Transports []string
+	// Synced indica uma passkey sincronizada entre dispositivos (backup state)
+	Synced     bool
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+type ListPasskeysUseCase struct {
+	credentialRepo repository.WebAuthnCredentialRepository
+}
+
+func NewListPasskeysUseCase(credentialRepo repository.WebAuthnCredentialRepository) *ListPasskeysUseCase {
+	return &ListPasskeysUseCase{
+		credentialRepo: credentialRepo,
+	}
+}
+
+func (uc *ListPasskeysUseCase) Execute(ctx context.Context, input ListPasskeysInput) ([]PasskeyOutput, error) {
+	credentials, err := uc.credentialRepo.GetByUserID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list passkeys: %w", err)
+	}
+
+	output := make([]PasskeyOutput, 0, len(credentials))
+	for _, credential := range credentials {
+		output = append(output, toPasskeyOutput(credential))
+	}
+
+	return output, nil
+}
+
+func toPasskeyOutput(credential *entity.WebAuthnCredential) PasskeyOutput {
+	return PasskeyOutput{
+		ID:         credential.ID,
+		Name:       credential.Name,
+		Transports: credential.Transports,
+		Synced:     credential.BackupState,
+		CreatedAt:  credential.CreatedAt,
+		LastUsedAt: credential.LastUsedAt,
+	}
+}
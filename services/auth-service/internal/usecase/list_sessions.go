@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+)
+
+type ListSessionsInput struct {
+	UserID           uuid.UUID
+	CurrentSessionID string
+}
+
+type SessionOutput struct {
+	ID         uuid.UUID
+	IPAddress  string
+	UserAgent  string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	ExpiresAt  time.Time
+	Current    bool
+}
+
+type ListSessionsUseCase struct {
+	sessionRepo repository.SessionRepository
+}
+
+func NewListSessionsUseCase(sessionRepo repository.SessionRepository) *ListSessionsUseCase {
+	return &ListSessionsUseCase{
+		sessionRepo: sessionRepo,
+	}
+}
+
+func (uc *ListSessionsUseCase) Execute(ctx context.Context, input ListSessionsInput) ([]SessionOutput, error) {
+	sessions, err := uc.sessionRepo.GetByUserID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	// O repositório já retorna só a linha vigente de cada família; ignorar as encerradas
+	output := make([]SessionOutput, 0, len(sessions))
+	for _, session := range sessions {
+		if !session.IsValid() {
+			continue
+		}
+
+		output = append(output, SessionOutput{
+			ID:         session.FamilyID,
+			IPAddress:  session.IPAddress,
+			UserAgent:  session.UserAgent,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.FamilyID.String() == input.CurrentSessionID,
+		})
+	}
+
+	return output, nil
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+)
+
+type SigningKeyOutput struct {
+	KeyID     string
+	Algorithm string
+	NotBefore time.Time
+	RetireAt  *time.Time
+	Active    bool
+}
+
+type ListSigningKeysUseCase struct {
+	jwtService *crypto.JWTService
+}
+
+func NewListSigningKeysUseCase(jwtService *crypto.JWTService) *ListSigningKeysUseCase {
+	return &ListSigningKeysUseCase{
+		jwtService: jwtService,
+	}
+}
+
+func (uc *ListSigningKeysUseCase) Execute(ctx context.Context) ([]SigningKeyOutput, error) {
+	keyRing := uc.jwtService.KeyRing()
+
+	// Garantir que a listagem reflete rotações feitas por outras instâncias ou pelo CLI
+	if err := keyRing.Reload(); err != nil {
+		return nil, err
+	}
+
+	active := keyRing.Active()
+	keys := keyRing.Keys()
+
+	output := make([]SigningKeyOutput, 0, len(keys))
+	for _, key := range keys {
+		output = append(output, toSigningKeyOutput(key, active))
+	}
+
+	return output, nil
+}
+
+func toSigningKeyOutput(key, active *crypto.SigningKey) SigningKeyOutput {
+	return SigningKeyOutput{
+		KeyID:     key.ID,
+		Algorithm: key.Method.Alg(),
+		NotBefore: key.NotBefore,
+		RetireAt:  key.RetireAt,
+		Active:    key.ID == active.ID,
+	}
+}
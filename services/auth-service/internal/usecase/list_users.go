@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+)
+
+const (
+	defaultUsersPerPage = 20
+	maxUsersPerPage     = 100
+)
+
+type ListUsersInput struct {
+	Page    int
+	PerPage int
+}
+
+type ListUsersOutput struct {
+	Users   []UserDetailsOutput
+	Page    int
+	PerPage int
+	Total   int
+}
+
+type ListUsersUseCase struct {
+	userRepo repository.UserRepository
+}
+
+func NewListUsersUseCase(userRepo repository.UserRepository) *ListUsersUseCase {
+	return &ListUsersUseCase{
+		userRepo: userRepo,
+	}
+}
+
+func (uc *ListUsersUseCase) Execute(ctx context.Context, input ListUsersInput) (*ListUsersOutput, error) {
+	// Normalizar paginação
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PerPage < 1 {
+		input.PerPage = defaultUsersPerPage
+	}
+	if input.PerPage > maxUsersPerPage {
+		input.PerPage = maxUsersPerPage
+	}
+
+	offset := (input.Page - 1) * input.PerPage
+	users, err := uc.userRepo.List(ctx, input.PerPage, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	total, err := uc.userRepo.Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	output := &ListUsersOutput{
+		Users:   make([]UserDetailsOutput, 0, len(users)),
+		Page:    input.Page,
+		PerPage: input.PerPage,
+		Total:   total,
+	}
+	for _, user := range users {
+		output.Users = append(output.Users, toUserDetailsOutput(user))
+	}
+
+	return output, nil
+}
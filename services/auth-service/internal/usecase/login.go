@@ -2,24 +2,37 @@ package usecase
 
 import (
 	"context"
-	"fmt"
+	"errors"
 
-	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/service"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
 	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
 )
 
 type LoginInput struct {
-	Email    string
-	Password string
+	Email     string
+	Password  string
+	IPAddress string
+	UserAgent string
 }
 
+// Métodos aceitos para concluir um login com segundo fator
+const (
+	MFAMethodTOTP         = "totp"
+	MFAMethodRecoveryCode = "recovery_code"
+	MFAMethodWebAuthn     = "webauthn"
+)
+
+// LoginOutput contém os tokens emitidos ou, se MFARequired, o desafio de segundo fator
 type LoginOutput struct {
 	AccessToken  string
 	RefreshToken string
 	User         UserDTO
+	MFARequired  bool
+	MFAToken     string
+	MFAMethods   []string
 }
 
 type UserDTO struct {
@@ -30,26 +43,34 @@ type UserDTO struct {
 }
 
 type LoginUseCase struct {
-	userRepo          repository.UserRepository
-	sessionRepo       repository.SessionRepository
-	passwordService   *crypto.PasswordService
-	jwtService        *crypto.JWTService
+	authenticator     Authenticator
 	validationService *service.ValidationService
+	loginThrottle     *cache.LoginThrottle
+	mfa               mfaGate
+	issuer            sessionIssuer
 }
 
 func NewLoginUseCase(
-	userRepo repository.UserRepository,
 	sessionRepo repository.SessionRepository,
-	passwordService *crypto.PasswordService,
+	credentialRepo repository.WebAuthnCredentialRepository,
+	authenticator Authenticator,
 	jwtService *crypto.JWTService,
 	validationService *service.ValidationService,
+	mfaStore *cache.MFAStore,
+	loginThrottle *cache.LoginThrottle,
 ) *LoginUseCase {
 	return &LoginUseCase{
-		userRepo:          userRepo,
-		sessionRepo:       sessionRepo,
-		passwordService:   passwordService,
-		jwtService:        jwtService,
+		authenticator:     authenticator,
 		validationService: validationService,
+		loginThrottle:     loginThrottle,
+		mfa: mfaGate{
+			credentialRepo: credentialRepo,
+			mfaStore:       mfaStore,
+		},
+		issuer: sessionIssuer{
+			sessionRepo: sessionRepo,
+			jwtService:  jwtService,
+		},
 	}
 }
 
@@ -57,48 +78,50 @@ func (uc *LoginUseCase) Execute(ctx context.Context, input LoginInput) (*LoginOu
 	// Normalizar email
 	input.Email = uc.validationService.NormalizeEmail(input.Email)
 
-	// Buscar usuário por email
-	user, err := uc.userRepo.GetByEmail(ctx, input.Email)
+	// Conta ou IP bloqueados por excesso de falhas
+	if err := uc.loginThrottle.Check(ctx, input.Email, input.IPAddress); err != nil {
+		return nil, err
+	}
+
+	// Verificar a senha no banco local ou no diretório, conforme o backend configurado
+	user, err := uc.authenticator.Authenticate(ctx, input.Email, input.Password)
 	if err != nil {
-		return nil, pkgerrors.ErrInvalidCredentials
+		if errors.Is(err, pkgerrors.ErrInvalidCredentials) {
+			return nil, uc.failedAttempt(ctx, input)
+		}
+		return nil, err
 	}
 
-	// Verificar se usuário está ativo
+	// Verificar se usuário está ativo (apenas após a senha, para não revelar o estado da conta)
 	if !user.IsActive {
 		return nil, pkgerrors.ErrUserInactive
 	}
 
-	// Verificar senha
-	if err := uc.passwordService.Compare(user.PasswordHash, input.Password); err != nil {
-		return nil, pkgerrors.ErrInvalidCredentials
+	// Com segundo fator ativo, emitir apenas um desafio de curta duração. As falhas da
+	// conta só são zeradas quando o segundo fator também for aceito, para que a senha
+	// correta não libere novas tentativas de código.
+	challenge, err := uc.mfa.challenge(ctx, user, input.IPAddress, input.UserAgent)
+	if err != nil || challenge != nil {
+		return challenge, err
 	}
 
-	// Gerar access token
-	accessToken, err := uc.jwtService.GenerateAccessToken(user.ID, user.Email, string(user.Role))
+	output, err := uc.issuer.issue(ctx, user, input.IPAddress, input.UserAgent)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
+		return nil, err
 	}
 
-	// Gerar refresh token
-	refreshToken, expiresAt, err := uc.jwtService.GenerateRefreshToken(user.ID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	// Login concluído: zerar as falhas da conta
+	if err := uc.loginThrottle.Reset(ctx, input.Email); err != nil {
+		return nil, err
 	}
 
-	// Criar sessão
-	session := entity.NewSession(user.ID, refreshToken, expiresAt)
-	if err := uc.sessionRepo.Create(ctx, session); err != nil {
-		return nil, fmt.Errorf("failed to create session: %w", err)
-	}
+	return output, nil
+}
 
-	return &LoginOutput{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		User: UserDTO{
-			ID:    user.ID.String(),
-			Email: user.Email,
-			Name:  user.Name,
-			Role:  string(user.Role),
-		},
-	}, nil
+// failedAttempt contabiliza a falha da mesma forma para emails existentes ou não
+func (uc *LoginUseCase) failedAttempt(ctx context.Context, input LoginInput) error {
+	if err := uc.loginThrottle.RecordFailure(ctx, input.Email, input.IPAddress); err != nil {
+		return err
+	}
+	return pkgerrors.ErrInvalidCredentials
 }
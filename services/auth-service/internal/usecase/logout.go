@@ -3,30 +3,84 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
 )
 
 type LogoutInput struct {
-	UserID uuid.UUID
+	UserID         uuid.UUID
+	SessionID      string
+	RefreshToken   string
+	TokenID        string
+	TokenExpiresAt time.Time
 }
 
 type LogoutUseCase struct {
-	sessionRepo repository.SessionRepository
+	sessionRepo    repository.SessionRepository
+	revocationList *cache.TokenRevocationList
 }
 
-func NewLogoutUseCase(sessionRepo repository.SessionRepository) *LogoutUseCase {
+func NewLogoutUseCase(
+	sessionRepo repository.SessionRepository,
+	revocationList *cache.TokenRevocationList,
+) *LogoutUseCase {
 	return &LogoutUseCase{
-		sessionRepo: sessionRepo,
+		sessionRepo:    sessionRepo,
+		revocationList: revocationList,
 	}
 }
 
+// Execute encerra apenas a sessão atual, identificada pelo sid do access token
+// ou, na falta dele, pelo refresh token apresentado
 func (uc *LogoutUseCase) Execute(ctx context.Context, input LogoutInput) error {
-	// Revogar todas as sessões do usuário
-	if err := uc.sessionRepo.RevokeAllByUserID(ctx, input.UserID); err != nil {
-		return fmt.Errorf("failed to revoke sessions: %w", err)
+	familyID, err := uc.resolveSession(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	// Revogar a família de refresh tokens da sessão
+	if err := uc.sessionRepo.RevokeFamily(ctx, familyID); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	// Revogar os access tokens emitidos para a sessão
+	if err := uc.revocationList.RevokeSession(ctx, familyID); err != nil {
+		return fmt.Errorf("failed to revoke session access tokens: %w", err)
+	}
+
+	if err := uc.revocationList.RevokeToken(ctx, input.TokenID, input.TokenExpiresAt); err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
 	}
 
 	return nil
 }
+
+func (uc *LogoutUseCase) resolveSession(ctx context.Context, input LogoutInput) (uuid.UUID, error) {
+	if input.SessionID != "" {
+		familyID, err := uuid.Parse(input.SessionID)
+		if err != nil {
+			return uuid.Nil, pkgerrors.ErrInvalidToken
+		}
+
+		// A sessão raiz da família pertence ao mesmo usuário
+		session, err := uc.sessionRepo.GetByID(ctx, familyID)
+		if err != nil || session.UserID != input.UserID {
+			return uuid.Nil, pkgerrors.ErrSessionNotFound
+		}
+		return familyID, nil
+	}
+
+	if input.RefreshToken != "" {
+		session, err := uc.sessionRepo.GetByRefreshToken(ctx, input.RefreshToken)
+		if err != nil || session.UserID != input.UserID {
+			return uuid.Nil, pkgerrors.ErrSessionNotFound
+		}
+		return session.FamilyID, nil
+	}
+
+	return uuid.Nil, pkgerrors.ErrSessionNotFound
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+)
+
+type LogoutAllInput struct {
+	UserID         uuid.UUID
+	TokenID        string
+	TokenExpiresAt time.Time
+}
+
+type LogoutAllUseCase struct {
+	sessionRepo    repository.SessionRepository
+	revocationList *cache.TokenRevocationList
+}
+
+func NewLogoutAllUseCase(
+	sessionRepo repository.SessionRepository,
+	revocationList *cache.TokenRevocationList,
+) *LogoutAllUseCase {
+	return &LogoutAllUseCase{
+		sessionRepo:    sessionRepo,
+		revocationList: revocationList,
+	}
+}
+
+func (uc *LogoutAllUseCase) Execute(ctx context.Context, input LogoutAllInput) error {
+	// Revogar todas as sessões do usuário
+	if err := uc.sessionRepo.RevokeAllByUserID(ctx, input.UserID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	// Revogar o access token atual e os demais ainda não expirados
+	if err := uc.revocationList.RevokeToken(ctx, input.TokenID, input.TokenExpiresAt); err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	if err := uc.revocationList.RevokeUserTokens(ctx, input.UserID); err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+
+	return nil
+}
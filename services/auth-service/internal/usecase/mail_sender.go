@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/mail"
+)
+
+// mailSendTimeout limita o envio assíncrono de emails
+const mailSendTimeout = 30 * time.Second
+
+// sendMailAsync envia o email fora da requisição, para que o tempo de resposta
+// não revele se a conta existe. Falhas são apenas registradas em log.
+func sendMailAsync(mailer mail.Mailer, msg mail.Message, kind string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mailSendTimeout)
+		defer cancel()
+
+		if err := mailer.Send(ctx, msg); err != nil {
+			log.Printf("Failed to send %s email: %v", kind, err)
+		}
+	}()
+}
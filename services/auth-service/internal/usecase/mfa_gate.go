@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+)
+
+// mfaGate exige o segundo fator de usuários que o configuraram, após o primeiro fator.
+// Compartilhado pelo login com senha e pelo login federado.
+type mfaGate struct {
+	credentialRepo repository.WebAuthnCredentialRepository
+	mfaStore       *cache.MFAStore
+}
+
+// challenge emite um desafio de curta duração se o usuário tiver segundo fator ativo;
+// retorna nil quando o login pode ser concluído diretamente
+func (g mfaGate) challenge(ctx context.Context, user *entity.User, ipAddress, userAgent string) (*LoginOutput, error) {
+	methods, err := g.methods(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if len(methods) == 0 {
+		return nil, nil
+	}
+
+	token, err := g.mfaStore.CreateChallenge(ctx, cache.MFAChallenge{
+		UserID:    user.ID,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginOutput{
+		MFARequired: true,
+		MFAToken:    token,
+		MFAMethods:  methods,
+	}, nil
+}
+
+// methods lista os segundos fatores disponíveis; uma passkey registrada também torna o MFA obrigatório
+func (g mfaGate) methods(ctx context.Context, user *entity.User) ([]string, error) {
+	var methods []string
+	if user.TOTPEnabled {
+		methods = append(methods, MFAMethodTOTP, MFAMethodRecoveryCode)
+	}
+
+	credentials, err := g.credentialRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list passkeys: %w", err)
+	}
+	if len(credentials) > 0 {
+		methods = append(methods, MFAMethodWebAuthn)
+	}
+
+	return methods, nil
+}
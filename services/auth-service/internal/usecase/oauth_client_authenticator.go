@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+// oauthClientAuthenticator autentica o cliente nos endpoints OAuth. Clientes
+// confidenciais apresentam o client_secret; clientes públicos, apenas o client_id.
+type oauthClientAuthenticator struct {
+	clientRepo repository.OAuthClientRepository
+}
+
+func (a oauthClientAuthenticator) authenticate(ctx context.Context, clientID, clientSecret string) (*entity.OAuthClient, error) {
+	invalid := pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidClient, "falha na autenticação do cliente")
+
+	id, err := uuid.Parse(clientID)
+	if err != nil {
+		return nil, invalid
+	}
+
+	client, err := a.clientRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pkgerrors.ErrOAuthClientNotFound) {
+			return nil, invalid
+		}
+		return nil, err
+	}
+
+	if !client.IsActive {
+		return nil, invalid
+	}
+
+	if client.IsConfidential() {
+		if !client.VerifySecret(clientSecret) {
+			return nil, invalid
+		}
+	} else if clientSecret != "" {
+		// Segredo enviado por um cliente público indica configuração errada
+		return nil, invalid
+	}
+
+	return client, nil
+}
@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type OAuthTokenInput struct {
+	GrantType    string
+	ClientID     string
+	ClientSecret string
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	RefreshToken string
+	// Scope é usado apenas no client_credentials; vazio pede todos os escopos do cliente
+	Scope     string
+	IPAddress string
+	UserAgent string
+}
+
+type OAuthTokenOutput struct {
+	AccessToken  string
+	RefreshToken string
+	// ExpiresIn é a validade do access token em segundos
+	ExpiresIn int
+	Scope     string
+	// IDToken é emitido no authorization code quando o escopo openid foi concedido
+	IDToken string
+}
+
+type OAuthTokenUseCase struct {
+	clientAuth          oauthClientAuthenticator
+	userRepo            repository.UserRepository
+	oauthStore          *cache.OAuthStore
+	jwtService          *crypto.JWTService
+	issuer              sessionIssuer
+	refreshTokenUseCase *RefreshTokenUseCase
+	oidcIssuer          string
+	idTokenExpiry       time.Duration
+}
+
+func NewOAuthTokenUseCase(
+	clientRepo repository.OAuthClientRepository,
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	oauthStore *cache.OAuthStore,
+	jwtService *crypto.JWTService,
+	refreshTokenUseCase *RefreshTokenUseCase,
+	oidcIssuer string,
+	idTokenExpiry time.Duration,
+) *OAuthTokenUseCase {
+	return &OAuthTokenUseCase{
+		clientAuth:          oauthClientAuthenticator{clientRepo: clientRepo},
+		userRepo:            userRepo,
+		oauthStore:          oauthStore,
+		jwtService:          jwtService,
+		issuer:              sessionIssuer{sessionRepo: sessionRepo, jwtService: jwtService},
+		refreshTokenUseCase: refreshTokenUseCase,
+		oidcIssuer:          oidcIssuer,
+		idTokenExpiry:       idTokenExpiry,
+	}
+}
+
+// Execute implementa o token endpoint (RFC 6749, seção 3.2). Erros do protocolo
+// são devolvidos como *pkgerrors.OAuthError.
+func (uc *OAuthTokenUseCase) Execute(ctx context.Context, input OAuthTokenInput) (*OAuthTokenOutput, error) {
+	if input.GrantType == "" {
+		return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidRequest, "grant_type obrigatório")
+	}
+
+	client, err := uc.clientAuth.authenticate(ctx, input.ClientID, input.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	switch input.GrantType {
+	case entity.GrantTypeAuthorizationCode, entity.GrantTypeRefreshToken, entity.GrantTypeClientCredentials:
+	default:
+		return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthUnsupportedGrantType, "grant_type não suportado")
+	}
+
+	if !client.AllowsGrant(input.GrantType) {
+		return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthUnauthorizedClient, "grant_type não liberado para o cliente")
+	}
+
+	switch input.GrantType {
+	case entity.GrantTypeAuthorizationCode:
+		return uc.authorizationCode(ctx, client, input)
+	case entity.GrantTypeRefreshToken:
+		return uc.refreshToken(ctx, client, input)
+	default:
+		return uc.clientCredentials(client, input)
+	}
+}
+
+func (uc *OAuthTokenUseCase) authorizationCode(ctx context.Context, client *entity.OAuthClient, input OAuthTokenInput) (*OAuthTokenOutput, error) {
+	if input.Code == "" || input.CodeVerifier == "" {
+		return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidRequest, "code e code_verifier são obrigatórios")
+	}
+
+	// O código é consumido antes das verificações: uma tentativa errada o invalida
+	grant, err := uc.oauthStore.TakeCode(ctx, input.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	if grant.ClientID != client.ID || grant.RedirectURI != input.RedirectURI {
+		return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidGrant, "authorization code emitido para outro cliente ou redirect_uri")
+	}
+
+	if !crypto.VerifyPKCE(grant.CodeChallenge, input.CodeVerifier) {
+		return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidGrant, "code_verifier inválido")
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, grant.UserID)
+	if err != nil {
+		if errors.Is(err, pkgerrors.ErrUserNotFound) {
+			return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidGrant, err.Error())
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.IsActive {
+		return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidGrant, pkgerrors.ErrUserInactive.Error())
+	}
+
+	scope := entity.FormatScope(grant.Scopes)
+	output, session, err := uc.issuer.issueForClient(ctx, user, client, scope, input.IPAddress, input.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := &OAuthTokenOutput{
+		AccessToken:  output.AccessToken,
+		RefreshToken: output.RefreshToken,
+		ExpiresIn:    int(uc.jwtService.GetAccessTokenExpiry().Seconds()),
+		Scope:        scope,
+	}
+
+	if entity.HasScope(grant.Scopes, entity.ScopeOpenID) {
+		if tokens.IDToken, err = uc.idToken(user, client, session, grant); err != nil {
+			return nil, err
+		}
+	}
+
+	return tokens, nil
+}
+
+// idToken emite o ID token do OpenID Connect; sid é a sessão OAuth, encerrada pelo
+// end_session_endpoint
+func (uc *OAuthTokenUseCase) idToken(user *entity.User, client *entity.OAuthClient, session *entity.Session, grant *cache.AuthorizationCode) (string, error) {
+	info := userInfoClaims(user, grant.Scopes)
+
+	idToken, err := uc.jwtService.GenerateIDToken(crypto.IDToken{
+		Issuer:    uc.oidcIssuer,
+		Subject:   info.Subject,
+		Audience:  client.ID.String(),
+		Nonce:     grant.Nonce,
+		AuthTime:  grant.AuthTime,
+		SessionID: session.FamilyID.String(),
+		Email:     info.Email,
+		Name:      info.Name,
+		Role:      info.Role,
+		UpdatedAt: info.UpdatedAt,
+	}, uc.idTokenExpiry)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate ID token: %w", err)
+	}
+
+	return idToken, nil
+}
+
+func (uc *OAuthTokenUseCase) refreshToken(ctx context.Context, client *entity.OAuthClient, input OAuthTokenInput) (*OAuthTokenOutput, error) {
+	if input.RefreshToken == "" {
+		return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidRequest, "refresh_token obrigatório")
+	}
+
+	output, err := uc.refreshTokenUseCase.Execute(ctx, RefreshTokenInput{
+		RefreshToken: input.RefreshToken,
+		ClientID:     &client.ID,
+		IPAddress:    input.IPAddress,
+		UserAgent:    input.UserAgent,
+	})
+	if err != nil {
+		for _, grantErr := range []error{
+			pkgerrors.ErrInvalidToken,
+			pkgerrors.ErrExpiredToken,
+			pkgerrors.ErrTokenRevoked,
+			pkgerrors.ErrTokenReused,
+			pkgerrors.ErrUserInactive,
+		} {
+			if errors.Is(err, grantErr) {
+				return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidGrant, grantErr.Error())
+			}
+		}
+		return nil, err
+	}
+
+	return &OAuthTokenOutput{
+		AccessToken:  output.AccessToken,
+		RefreshToken: output.RefreshToken,
+		ExpiresIn:    int(uc.jwtService.GetAccessTokenExpiry().Seconds()),
+		Scope:        output.Scope,
+	}, nil
+}
+
+// clientCredentials emite um token em nome do próprio cliente, sem usuário nem
+// refresh token (RFC 6749, seção 4.4)
+func (uc *OAuthTokenUseCase) clientCredentials(client *entity.OAuthClient, input OAuthTokenInput) (*OAuthTokenOutput, error) {
+	// Apenas clientes confidenciais, que acabaram de apresentar o segredo
+	if !client.IsConfidential() {
+		return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthUnauthorizedClient, "client_credentials exige cliente confidencial")
+	}
+
+	scopes := entity.ParseScope(input.Scope)
+	if len(scopes) == 0 {
+		scopes = client.Scopes
+	}
+	if !client.AllowsScopes(scopes) {
+		return nil, pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidScope, "escopo não permitido para o cliente")
+	}
+
+	scope := entity.FormatScope(scopes)
+	accessToken, ttl, err := uc.jwtService.GenerateClientAccessToken(client.ID, scope, client.AccessTokenTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	return &OAuthTokenOutput{
+		AccessToken: accessToken,
+		ExpiresIn:   int(ttl.Seconds()),
+		Scope:       scope,
+	}, nil
+}
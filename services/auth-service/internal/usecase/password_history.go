@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+// passwordHistoryPolicy impede a reutilização das últimas size senhas,
+// contando a senha atual do usuário
+type passwordHistoryPolicy struct {
+	historyRepo     repository.PasswordHistoryRepository
+	passwordService *crypto.PasswordService
+	size            int
+}
+
+// check retorna ErrPasswordReused se a nova senha corresponder a um hash recente
+func (p passwordHistoryPolicy) check(ctx context.Context, user *entity.User, newPassword string) error {
+	if p.size <= 0 {
+		return nil
+	}
+
+	hashes := []string{user.PasswordHash}
+	if p.size > 1 {
+		recent, err := p.historyRepo.GetRecent(ctx, user.ID, p.size-1)
+		if err != nil {
+			return fmt.Errorf("failed to load password history: %w", err)
+		}
+		hashes = append(hashes, recent...)
+	}
+
+	for _, hash := range hashes {
+		if p.passwordService.Compare(hash, newPassword) == nil {
+			return pkgerrors.ErrPasswordReused
+		}
+	}
+
+	return nil
+}
+
+// record guarda o hash substituído e descarta os que já saíram da janela
+func (p passwordHistoryPolicy) record(ctx context.Context, user *entity.User, oldHash string) error {
+	if p.size <= 0 {
+		return nil
+	}
+
+	if err := p.historyRepo.Add(ctx, user.ID, oldHash); err != nil {
+		return fmt.Errorf("failed to record password history: %w", err)
+	}
+
+	if err := p.historyRepo.Prune(ctx, user.ID, p.size-1); err != nil {
+		return fmt.Errorf("failed to prune password history: %w", err)
+	}
+
+	return nil
+}
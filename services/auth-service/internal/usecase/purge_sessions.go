@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+)
+
+type PurgeSessionsUseCase struct {
+	sessionRepo       repository.SessionRepository
+	passwordResetRepo repository.PasswordResetRepository
+	// revokedRetention é por quanto tempo sessões revogadas ou rotacionadas são mantidas:
+	// apresentar um refresh token rotacionado dentro dela revoga a família (reutilização);
+	// depois, o token é apenas inválido
+	revokedRetention time.Duration
+}
+
+func NewPurgeSessionsUseCase(
+	sessionRepo repository.SessionRepository,
+	passwordResetRepo repository.PasswordResetRepository,
+	revokedRetention time.Duration,
+) *PurgeSessionsUseCase {
+	return &PurgeSessionsUseCase{
+		sessionRepo:       sessionRepo,
+		passwordResetRepo: passwordResetRepo,
+		revokedRetention:  revokedRetention,
+	}
+}
+
+// Execute remove as sessões expiradas e as revogadas há mais de revokedRetention,
+// retornando quantas foram removidas. Os tokens de redefinição de senha expirados
+// (usados ou não) são removidos na mesma passada.
+func (uc *PurgeSessionsUseCase) Execute(ctx context.Context) (int64, error) {
+	removed, err := uc.sessionRepo.DeleteExpired(ctx, time.Now().Add(-uc.revokedRetention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge sessions: %w", err)
+	}
+
+	if err := uc.passwordResetRepo.DeleteExpired(ctx); err != nil {
+		return removed, fmt.Errorf("failed to purge password reset tokens: %w", err)
+	}
+
+	return removed, nil
+}
@@ -2,38 +2,54 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 
+	"github.com/google/uuid"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
 	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
 )
 
 type RefreshTokenInput struct {
 	RefreshToken string
+	// ClientID é o cliente OAuth autenticado no token endpoint (nil em /auth/refresh)
+	ClientID  *uuid.UUID
+	IPAddress string
+	UserAgent string
 }
 
 type RefreshTokenOutput struct {
 	AccessToken  string
 	RefreshToken string
+	// Scope são os escopos concedidos ao cliente OAuth (vazio sem cliente)
+	Scope string
 }
 
 type RefreshTokenUseCase struct {
-	userRepo    repository.UserRepository
-	sessionRepo repository.SessionRepository
-	jwtService  *crypto.JWTService
+	userRepo       repository.UserRepository
+	sessionRepo    repository.SessionRepository
+	auditRepo      repository.AuditRepository
+	jwtService     *crypto.JWTService
+	revocationList *cache.TokenRevocationList
 }
 
 func NewRefreshTokenUseCase(
 	userRepo repository.UserRepository,
 	sessionRepo repository.SessionRepository,
+	auditRepo repository.AuditRepository,
 	jwtService *crypto.JWTService,
+	revocationList *cache.TokenRevocationList,
 ) *RefreshTokenUseCase {
 	return &RefreshTokenUseCase{
-		userRepo:    userRepo,
-		sessionRepo: sessionRepo,
-		jwtService:  jwtService,
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		auditRepo:      auditRepo,
+		jwtService:     jwtService,
+		revocationList: revocationList,
 	}
 }
 
@@ -44,6 +60,17 @@ func (uc *RefreshTokenUseCase) Execute(ctx context.Context, input RefreshTokenIn
 		return nil, pkgerrors.ErrInvalidToken
 	}
 
+	// Refresh tokens emitidos a um cliente OAuth só podem ser usados por ele
+	if !session.IssuedTo(input.ClientID) {
+		return nil, pkgerrors.ErrInvalidToken
+	}
+
+	// Token já rotacionado sendo apresentado novamente: tratar como roubo e
+	// encerrar toda a família (OAuth 2.0 Security BCP, refresh token rotation)
+	if session.IsRotated() {
+		return nil, uc.handleReuse(ctx, session)
+	}
+
 	// Verificar se sessão é válida
 	if !session.IsValid() {
 		if session.IsRevoked {
@@ -63,32 +90,55 @@ func (uc *RefreshTokenUseCase) Execute(ctx context.Context, input RefreshTokenIn
 		return nil, pkgerrors.ErrUserInactive
 	}
 
-	// Gerar novo access token
-	accessToken, err := uc.jwtService.GenerateAccessToken(user.ID, user.Email, string(user.Role))
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
-	}
-
 	// Gerar novo refresh token
 	newRefreshToken, expiresAt, err := uc.jwtService.GenerateRefreshToken(user.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
 	}
 
-	// Revogar sessão antiga
-	session.Revoke()
-	if err := uc.sessionRepo.Update(ctx, session); err != nil {
-		return nil, fmt.Errorf("failed to revoke old session: %w", err)
+	// Rotacionar: revogar sessão antiga e criar a sucessora na mesma família
+	newSession := session.Rotate(newRefreshToken, expiresAt, input.IPAddress, input.UserAgent)
+
+	// Gerar novo access token vinculado à sessão
+	email, role := tokenProfile(user, sessionClientID(newSession), newSession.Scope)
+	accessToken, err := uc.jwtService.GenerateOAuthAccessToken(user.ID, email, role, newSession.FamilyID, sessionClientID(newSession), newSession.Scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
-	// Criar nova sessão
-	newSession := entity.NewSession(user.ID, newRefreshToken, expiresAt)
-	if err := uc.sessionRepo.Create(ctx, newSession); err != nil {
-		return nil, fmt.Errorf("failed to create new session: %w", err)
+	// Duas requisições com o mesmo refresh token passam pela verificação acima; só a
+	// primeira consegue rotacionar, a outra é tratada como reutilização
+	if err := uc.sessionRepo.Rotate(ctx, session, newSession); err != nil {
+		if errors.Is(err, pkgerrors.ErrTokenReused) {
+			return nil, uc.handleReuse(ctx, session)
+		}
+		return nil, fmt.Errorf("failed to rotate session: %w", err)
 	}
 
 	return &RefreshTokenOutput{
 		AccessToken:  accessToken,
 		RefreshToken: newRefreshToken,
+		Scope:        newSession.Scope,
 	}, nil
 }
+
+func (uc *RefreshTokenUseCase) handleReuse(ctx context.Context, session *entity.Session) error {
+	if err := uc.sessionRepo.RevokeFamily(ctx, session.FamilyID); err != nil {
+		return fmt.Errorf("failed to revoke session family: %w", err)
+	}
+
+	// Access tokens da família também deixam de valer imediatamente
+	if err := uc.revocationList.RevokeSession(ctx, session.FamilyID); err != nil {
+		return fmt.Errorf("failed to revoke session access tokens: %w", err)
+	}
+
+	event := entity.NewAuditEvent(entity.AuditRefreshTokenReuse, &session.UserID, map[string]string{
+		"session_id": session.ID.String(),
+		"family_id":  session.FamilyID.String(),
+	})
+	if err := uc.auditRepo.Create(ctx, event); err != nil {
+		log.Printf("Failed to record audit event %s: %v", event.Type, err)
+	}
+
+	return pkgerrors.ErrTokenReused
+}
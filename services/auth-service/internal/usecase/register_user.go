@@ -2,12 +2,14 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/service"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/mail"
 	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
 )
 
@@ -18,32 +20,111 @@ type RegisterUserInput struct {
 	Role     entity.UserRole
 }
 
+// RegisterUserOutput contém o usuário criado. Com Concealed, o resultado não é
+// revelado ao chamador e os demais campos ficam vazios.
 type RegisterUserOutput struct {
-	UserID string
-	Email  string
-	Name   string
-	Role   string
+	UserID    string
+	Email     string
+	Name      string
+	Role      string
+	Concealed bool
 }
 
 type RegisterUserUseCase struct {
-	userRepo          repository.UserRepository
-	passwordService   *crypto.PasswordService
-	validationService *service.ValidationService
+	userRepo                repository.UserRepository
+	passwordService         *crypto.PasswordService
+	validationService       *service.ValidationService
+	mailer                  mail.Mailer
+	selfRegistrationEnabled bool
+	concealExisting         bool
 }
 
 func NewRegisterUserUseCase(
 	userRepo repository.UserRepository,
 	passwordService *crypto.PasswordService,
 	validationService *service.ValidationService,
+	mailer mail.Mailer,
+	selfRegistrationEnabled bool,
+	concealExisting bool,
 ) *RegisterUserUseCase {
 	return &RegisterUserUseCase{
-		userRepo:          userRepo,
-		passwordService:   passwordService,
-		validationService: validationService,
+		userRepo:                userRepo,
+		passwordService:         passwordService,
+		validationService:       validationService,
+		mailer:                  mailer,
+		selfRegistrationEnabled: selfRegistrationEnabled,
+		concealExisting:         concealExisting,
 	}
 }
 
+// Execute realiza o auto-cadastro público: sempre cria usuários com role viewer
 func (uc *RegisterUserUseCase) Execute(ctx context.Context, input RegisterUserInput) (*RegisterUserOutput, error) {
+	if !uc.selfRegistrationEnabled {
+		return nil, pkgerrors.ErrRegistrationDisabled
+	}
+
+	// Roles elevadas só podem ser concedidas por um admin
+	if input.Role != "" && input.Role != entity.RoleViewer {
+		return nil, pkgerrors.ErrRoleNotAllowed
+	}
+	input.Role = entity.RoleViewer
+
+	if uc.concealExisting {
+		return uc.registerConcealed(ctx, input)
+	}
+
+	return uc.CreateWithRole(ctx, input)
+}
+
+// registerConcealed responde da mesma forma para emails novos e já cadastrados; o
+// resultado chega ao dono do email por uma mensagem enviada fora da requisição
+func (uc *RegisterUserUseCase) registerConcealed(ctx context.Context, input RegisterUserInput) (*RegisterUserOutput, error) {
+	output, err := uc.CreateWithRole(ctx, input)
+	switch {
+	case errors.Is(err, pkgerrors.ErrUserAlreadyExists):
+		sendMailAsync(uc.mailer, mail.Message{
+			To:      uc.validationService.NormalizeEmail(input.Email),
+			Subject: "Titan Watch - Tentativa de cadastro",
+			Body: "Olá.\n\n" +
+				"Alguém tentou criar uma conta no Titan Watch com este email, que já está cadastrado.\n" +
+				"Se foi você, faça login ou use a opção \"Esqueci minha senha\".\n\n" +
+				"Se você não fez esta solicitação, ignore este email.\n",
+		}, "registration attempt")
+	case err != nil:
+		return nil, err
+	default:
+		sendMailAsync(uc.mailer, mail.Message{
+			To:      output.Email,
+			Subject: "Titan Watch - Conta criada",
+			Body: fmt.Sprintf("Olá, %s.\n\n"+
+				"Sua conta no Titan Watch foi criada. Você já pode fazer login com este email.\n", output.Name),
+		}, "registration")
+	}
+
+	return &RegisterUserOutput{Concealed: true}, nil
+}
+
+// CreateWithRole cria um usuário com a role informada. Uso restrito a fluxos
+// privilegiados (admin, convites e bootstrap).
+func (uc *RegisterUserUseCase) CreateWithRole(ctx context.Context, input RegisterUserInput) (*RegisterUserOutput, error) {
+	user, err := uc.newUser(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Salvar no banco
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		if errors.Is(err, pkgerrors.ErrUserAlreadyExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return toRegisterUserOutput(user), nil
+}
+
+// newUser valida os dados e monta o usuário com a senha já com hash, sem salvá-lo
+func (uc *RegisterUserUseCase) newUser(ctx context.Context, input RegisterUserInput) (*entity.User, error) {
 	// Normalizar email
 	input.Email = uc.validationService.NormalizeEmail(input.Email)
 
@@ -56,13 +137,20 @@ func (uc *RegisterUserUseCase) Execute(ctx context.Context, input RegisterUserIn
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
 
-	if err := uc.validationService.ValidatePassword(input.Password); err != nil {
+	if err := uc.validationService.ValidatePassword(input.Password, input.Email, input.Name); err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
 
 	// Validar role
 	if !entity.IsValidRole(input.Role) {
-		return nil, fmt.Errorf("validation error: invalid role")
+		return nil, pkgerrors.ErrInvalidRole
+	}
+
+	// Hash da senha antes da verificação de existência, para que o tempo de
+	// resposta seja o mesmo para emails novos e já cadastrados
+	passwordHash, err := uc.passwordService.Hash(input.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
 	// Verificar se email já existe
@@ -74,24 +162,15 @@ func (uc *RegisterUserUseCase) Execute(ctx context.Context, input RegisterUserIn
 		return nil, pkgerrors.ErrUserAlreadyExists
 	}
 
-	// Hash da senha
-	passwordHash, err := uc.passwordService.Hash(input.Password)
-	if err != nil {
-		return nil, fmt.Errorf("failed to hash password: %w", err)
-	}
-
 	// Criar usuário
-	user := entity.NewUser(input.Email, passwordHash, input.Name, input.Role)
-
-	// Salvar no banco
-	if err := uc.userRepo.Create(ctx, user); err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
-	}
+	return entity.NewUser(input.Email, passwordHash, input.Name, input.Role), nil
+}
 
+func toRegisterUserOutput(user *entity.User) *RegisterUserOutput {
 	return &RegisterUserOutput{
 		UserID: user.ID.String(),
 		Email:  user.Email,
 		Name:   user.Name,
 		Role:   string(user.Role),
-	}, nil
+	}
 }
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/service"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/mail"
+)
+
+const testPassword = "correct-horse-battery-staple"
+
+func newConcealedRegisterUseCase(repo *fakeUserRepository, mailer mail.Mailer) *RegisterUserUseCase {
+	return NewRegisterUserUseCase(
+		repo,
+		crypto.NewPasswordService(&countingHasher{}),
+		service.NewValidationService(service.PasswordPolicy{MinLength: 8}),
+		mailer,
+		true,
+		true,
+	)
+}
+
+func expectMail(t *testing.T, mailer *recordingMailer) mail.Message {
+	t.Helper()
+	select {
+	case msg := <-mailer.sent:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("expected an email to be sent")
+		return mail.Message{}
+	}
+}
+
+func TestRegisterConcealedNewEmail(t *testing.T) {
+	repo := newFakeUserRepository()
+	mailer := newRecordingMailer()
+	uc := newConcealedRegisterUseCase(repo, mailer)
+
+	output, err := uc.Execute(context.Background(), RegisterUserInput{
+		Email:    "New@Example.com",
+		Password: testPassword,
+		Name:     "New User",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(output, &RegisterUserOutput{Concealed: true}) {
+		t.Fatalf("expected a concealed output, got %+v", output)
+	}
+
+	user, ok := repo.users["new@example.com"]
+	if !ok {
+		t.Fatal("expected the user to be created")
+	}
+	if user.Role != entity.RoleViewer {
+		t.Fatalf("expected role viewer, got %s", user.Role)
+	}
+
+	msg := expectMail(t, mailer)
+	if msg.To != "new@example.com" || !strings.Contains(msg.Subject, "Conta criada") {
+		t.Fatalf("unexpected email: %+v", msg)
+	}
+}
+
+func TestRegisterConcealedExistingEmail(t *testing.T) {
+	existing := entity.NewUser("taken@example.com", "fake$old-password", "Existing", entity.RoleAdmin)
+	repo := newFakeUserRepository(existing)
+	mailer := newRecordingMailer()
+	uc := newConcealedRegisterUseCase(repo, mailer)
+
+	// Mesmo resultado de um email novo: nenhum erro e nenhum dado da conta
+	output, err := uc.Execute(context.Background(), RegisterUserInput{
+		Email:    "taken@example.com",
+		Password: testPassword,
+		Name:     "Someone Else",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(output, &RegisterUserOutput{Concealed: true}) {
+		t.Fatalf("expected a concealed output, got %+v", output)
+	}
+
+	if repo.users["taken@example.com"] != existing || existing.Name != "Existing" {
+		t.Fatal("existing account must not be changed")
+	}
+
+	msg := expectMail(t, mailer)
+	if msg.To != "taken@example.com" || !strings.Contains(msg.Subject, "Tentativa de cadastro") {
+		t.Fatalf("unexpected email: %+v", msg)
+	}
+}
+
+func TestRegisterConcealedValidationErrorIsReturned(t *testing.T) {
+	uc := newConcealedRegisterUseCase(newFakeUserRepository(), newRecordingMailer())
+
+	// Erros de validação não revelam nada sobre a conta e continuam sendo retornados
+	_, err := uc.Execute(context.Background(), RegisterUserInput{
+		Email:    "not-an-email",
+		Password: testPassword,
+		Name:     "User",
+	})
+	if err == nil {
+		t.Fatal("expected a validation error")
+	}
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/service"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/mail"
+)
+
+type RequestPasswordResetInput struct {
+	Email string
+}
+
+type RequestPasswordResetUseCase struct {
+	userRepo          repository.UserRepository
+	resetRepo         repository.PasswordResetRepository
+	validationService *service.ValidationService
+	mailer            mail.Mailer
+	expiry            time.Duration
+	resetURL          string
+}
+
+func NewRequestPasswordResetUseCase(
+	userRepo repository.UserRepository,
+	resetRepo repository.PasswordResetRepository,
+	validationService *service.ValidationService,
+	mailer mail.Mailer,
+	expiry time.Duration,
+	resetURL string,
+) *RequestPasswordResetUseCase {
+	return &RequestPasswordResetUseCase{
+		userRepo:          userRepo,
+		resetRepo:         resetRepo,
+		validationService: validationService,
+		mailer:            mailer,
+		expiry:            expiry,
+		resetURL:          resetURL,
+	}
+}
+
+// Execute emite um token de redefinição. Não informa ao chamador se o email
+// existe: contas desconhecidas ou inativas são ignoradas silenciosamente.
+func (uc *RequestPasswordResetUseCase) Execute(ctx context.Context, input RequestPasswordResetInput) error {
+	email := uc.validationService.NormalizeEmail(input.Email)
+	if err := uc.validationService.ValidateEmail(email); err != nil {
+		return nil
+	}
+
+	user, err := uc.userRepo.GetByEmail(ctx, email)
+	if err != nil || !user.IsActive {
+		return nil
+	}
+
+	// Apenas o token mais recente permanece válido
+	if err := uc.resetRepo.InvalidateByUserID(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to invalidate previous reset tokens: %w", err)
+	}
+
+	token, err := crypto.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	reset := entity.NewPasswordReset(user.ID, token, time.Now().Add(uc.expiry))
+	if err := uc.resetRepo.Create(ctx, reset); err != nil {
+		return fmt.Errorf("failed to create reset token: %w", err)
+	}
+
+	// Envio assíncrono para que o tempo de resposta não revele contas existentes
+	sendMailAsync(uc.mailer, mail.Message{
+		To:      user.Email,
+		Subject: "Titan Watch - Redefinição de senha",
+		Body:    uc.buildBody(user.Name, token),
+	}, "password reset")
+
+	return nil
+}
+
+func (uc *RequestPasswordResetUseCase) buildBody(name, token string) string {
+	link := uc.resetURL
+	if u, err := url.Parse(uc.resetURL); err == nil {
+		query := u.Query()
+		query.Set("token", token)
+		u.RawQuery = query.Encode()
+		link = u.String()
+	}
+
+	return fmt.Sprintf(
+		"Olá, %s.\n\n"+
+			"Recebemos uma solicitação para redefinir sua senha. Acesse o link abaixo em até %s:\n\n"+
+			"%s\n\n"+
+			"Se você não fez esta solicitação, ignore este email.\n",
+		name, uc.expiry, link,
+	)
+}
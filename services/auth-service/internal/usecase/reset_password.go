@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/service"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type ResetPasswordInput struct {
+	Token       string
+	NewPassword string
+}
+
+type ResetPasswordUseCase struct {
+	userRepo          repository.UserRepository
+	sessionRepo       repository.SessionRepository
+	resetRepo         repository.PasswordResetRepository
+	passwordService   *crypto.PasswordService
+	validationService *service.ValidationService
+	revocationList    *cache.TokenRevocationList
+	history           passwordHistoryPolicy
+}
+
+func NewResetPasswordUseCase(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	resetRepo repository.PasswordResetRepository,
+	historyRepo repository.PasswordHistoryRepository,
+	passwordService *crypto.PasswordService,
+	validationService *service.ValidationService,
+	revocationList *cache.TokenRevocationList,
+	historySize int,
+) *ResetPasswordUseCase {
+	return &ResetPasswordUseCase{
+		userRepo:          userRepo,
+		sessionRepo:       sessionRepo,
+		resetRepo:         resetRepo,
+		passwordService:   passwordService,
+		validationService: validationService,
+		revocationList:    revocationList,
+		history: passwordHistoryPolicy{
+			historyRepo:     historyRepo,
+			passwordService: passwordService,
+			size:            historySize,
+		},
+	}
+}
+
+func (uc *ResetPasswordUseCase) Execute(ctx context.Context, input ResetPasswordInput) error {
+	reset, err := uc.resetRepo.GetByToken(ctx, input.Token)
+	if err != nil {
+		return err
+	}
+
+	if !reset.IsValid() {
+		return pkgerrors.ErrResetTokenInvalid
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, reset.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !user.IsActive {
+		return pkgerrors.ErrUserInactive
+	}
+
+	// Validar antes de consumir o token, para que o usuário possa tentar outra senha
+	if err := uc.validationService.ValidatePassword(input.NewPassword, user.Email, user.Name); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+
+	if err := uc.history.check(ctx, user, input.NewPassword); err != nil {
+		return err
+	}
+
+	// Consumir o token (uso único)
+	if err := uc.resetRepo.MarkUsed(ctx, reset.ID); err != nil {
+		return err
+	}
+
+	passwordHash, err := uc.passwordService.Hash(input.NewPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	oldHash := user.PasswordHash
+	user.UpdatePassword(passwordHash)
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	if err := uc.history.record(ctx, user, oldHash); err != nil {
+		return err
+	}
+
+	// Invalidar outros tokens pendentes e encerrar todas as sessões
+	if err := uc.resetRepo.InvalidateByUserID(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to invalidate reset tokens: %w", err)
+	}
+
+	if err := uc.sessionRepo.RevokeAllByUserID(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	if err := uc.revocationList.RevokeUserTokens(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+
+	return nil
+}
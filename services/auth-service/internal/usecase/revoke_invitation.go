@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type RevokeInvitationInput struct {
+	InvitationID uuid.UUID
+}
+
+type RevokeInvitationUseCase struct {
+	invitationRepo repository.InvitationRepository
+}
+
+func NewRevokeInvitationUseCase(invitationRepo repository.InvitationRepository) *RevokeInvitationUseCase {
+	return &RevokeInvitationUseCase{
+		invitationRepo: invitationRepo,
+	}
+}
+
+func (uc *RevokeInvitationUseCase) Execute(ctx context.Context, input RevokeInvitationInput) error {
+	invitation, err := uc.invitationRepo.GetByID(ctx, input.InvitationID)
+	if err != nil {
+		return err
+	}
+
+	if !invitation.IsPending() {
+		return pkgerrors.ErrInvitationNotPending
+	}
+
+	invitation.Revoke()
+	return uc.invitationRepo.Revoke(ctx, invitation)
+}
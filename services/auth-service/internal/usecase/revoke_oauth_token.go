@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type RevokeOAuthTokenInput struct {
+	ClientID      string
+	ClientSecret  string
+	Token         string
+	TokenTypeHint string
+}
+
+type RevokeOAuthTokenUseCase struct {
+	clientAuth     oauthClientAuthenticator
+	sessionRepo    repository.SessionRepository
+	jwtService     *crypto.JWTService
+	revocationList *cache.TokenRevocationList
+}
+
+func NewRevokeOAuthTokenUseCase(
+	clientRepo repository.OAuthClientRepository,
+	sessionRepo repository.SessionRepository,
+	jwtService *crypto.JWTService,
+	revocationList *cache.TokenRevocationList,
+) *RevokeOAuthTokenUseCase {
+	return &RevokeOAuthTokenUseCase{
+		clientAuth:     oauthClientAuthenticator{clientRepo: clientRepo},
+		sessionRepo:    sessionRepo,
+		jwtService:     jwtService,
+		revocationList: revocationList,
+	}
+}
+
+// Execute implementa a revogação de tokens (RFC 7009). Refresh tokens encerram a
+// sessão OAuth inteira; access tokens entram na blacklist pelo tempo restante.
+// Tokens desconhecidos ou já inválidos não são erro.
+func (uc *RevokeOAuthTokenUseCase) Execute(ctx context.Context, input RevokeOAuthTokenInput) error {
+	client, err := uc.clientAuth.authenticate(ctx, input.ClientID, input.ClientSecret)
+	if err != nil {
+		return err
+	}
+
+	if input.Token == "" {
+		return pkgerrors.NewOAuthError(pkgerrors.OAuthInvalidRequest, "token obrigatório")
+	}
+
+	revokers := []func(context.Context, *entity.OAuthClient, string) (bool, error){
+		uc.revokeAccessToken,
+		uc.revokeRefreshToken,
+	}
+	if input.TokenTypeHint == TokenTypeHintRefreshToken {
+		revokers[0], revokers[1] = revokers[1], revokers[0]
+	}
+
+	for _, revoke := range revokers {
+		handled, err := revoke(ctx, client, input.Token)
+		if err != nil || handled {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// revokeAccessToken retorna false quando o token não é um access token deste serviço
+func (uc *RevokeOAuthTokenUseCase) revokeAccessToken(ctx context.Context, client *entity.OAuthClient, token string) (bool, error) {
+	claims, err := uc.jwtService.ValidateAccessToken(token)
+	if err != nil {
+		// Expirado: já não vale, nada a revogar
+		return errors.Is(err, crypto.ErrExpiredToken), nil
+	}
+
+	if claims.ClientID != client.ID.String() {
+		return true, errTokenIssuedToAnotherClient()
+	}
+
+	if err := uc.revocationList.RevokeToken(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+		return true, fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	return true, nil
+}
+
+// revokeRefreshToken retorna false quando o token não corresponde a uma sessão
+func (uc *RevokeOAuthTokenUseCase) revokeRefreshToken(ctx context.Context, client *entity.OAuthClient, token string) (bool, error) {
+	session, err := uc.sessionRepo.GetByRefreshToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, pkgerrors.ErrSessionNotFound) {
+			return false, nil
+		}
+		return true, err
+	}
+
+	if !session.IssuedTo(&client.ID) {
+		return true, errTokenIssuedToAnotherClient()
+	}
+
+	if err := uc.sessionRepo.RevokeFamily(ctx, session.FamilyID); err != nil {
+		return true, fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	if err := uc.revocationList.RevokeSession(ctx, session.FamilyID); err != nil {
+		return true, fmt.Errorf("failed to revoke session access tokens: %w", err)
+	}
+
+	return true, nil
+}
+
+func errTokenIssuedToAnotherClient() error {
+	return pkgerrors.NewOAuthError(pkgerrors.OAuthUnauthorizedClient, "token emitido a outro cliente")
+}
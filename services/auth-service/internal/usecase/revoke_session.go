@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type RevokeSessionInput struct {
+	UserID    uuid.UUID
+	SessionID uuid.UUID
+}
+
+type RevokeSessionUseCase struct {
+	sessionRepo    repository.SessionRepository
+	revocationList *cache.TokenRevocationList
+}
+
+func NewRevokeSessionUseCase(
+	sessionRepo repository.SessionRepository,
+	revocationList *cache.TokenRevocationList,
+) *RevokeSessionUseCase {
+	return &RevokeSessionUseCase{
+		sessionRepo:    sessionRepo,
+		revocationList: revocationList,
+	}
+}
+
+func (uc *RevokeSessionUseCase) Execute(ctx context.Context, input RevokeSessionInput) error {
+	// O ID exposto é o da família; a sessão raiz identifica o dono
+	session, err := uc.sessionRepo.GetByID(ctx, input.SessionID)
+	if err != nil {
+		return err
+	}
+
+	// Não revelar sessões de outros usuários
+	if session.UserID != input.UserID || session.FamilyID != input.SessionID {
+		return pkgerrors.ErrSessionNotFound
+	}
+
+	if err := uc.sessionRepo.RevokeFamily(ctx, session.FamilyID); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	if err := uc.revocationList.RevokeSession(ctx, session.FamilyID); err != nil {
+		return fmt.Errorf("failed to revoke session access tokens: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+)
+
+type RevokeUserTokensInput struct {
+	UserID uuid.UUID
+}
+
+type RevokeUserTokensUseCase struct {
+	userRepo       repository.UserRepository
+	sessionRepo    repository.SessionRepository
+	revocationList *cache.TokenRevocationList
+}
+
+func NewRevokeUserTokensUseCase(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	revocationList *cache.TokenRevocationList,
+) *RevokeUserTokensUseCase {
+	return &RevokeUserTokensUseCase{
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		revocationList: revocationList,
+	}
+}
+
+func (uc *RevokeUserTokensUseCase) Execute(ctx context.Context, input RevokeUserTokensInput) error {
+	// Garantir que o usuário existe
+	if _, err := uc.userRepo.GetByID(ctx, input.UserID); err != nil {
+		return err
+	}
+
+	// Revogar refresh tokens (sessões)
+	if err := uc.sessionRepo.RevokeAllByUserID(ctx, input.UserID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	// Revogar access tokens ainda não expirados
+	if err := uc.revocationList.RevokeUserTokens(ctx, input.UserID); err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+)
+
+type RotateSigningKeyInput struct {
+	Algorithm string
+}
+
+type RotateSigningKeyUseCase struct {
+	jwtService *crypto.JWTService
+	// activationDelay é o intervalo de recarga do keyring: a nova chave só assina depois
+	// que todas as instâncias tiveram a chance de carregá-la
+	activationDelay time.Duration
+}
+
+func NewRotateSigningKeyUseCase(jwtService *crypto.JWTService, activationDelay time.Duration) *RotateSigningKeyUseCase {
+	return &RotateSigningKeyUseCase{
+		jwtService:      jwtService,
+		activationDelay: activationDelay,
+	}
+}
+
+func (uc *RotateSigningKeyUseCase) Execute(ctx context.Context, input RotateSigningKeyInput) (*SigningKeyOutput, error) {
+	// A chave anterior permanece válida até o último refresh token emitido com ela expirar
+	retireAfter := uc.jwtService.GetRefreshTokenExpiry()
+
+	keyRing := uc.jwtService.KeyRing()
+	key, err := keyRing.Rotate(input.Algorithm, uc.activationDelay, retireAfter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate signing key: %w", err)
+	}
+
+	output := toSigningKeyOutput(key, keyRing.Active())
+	return &output, nil
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+)
+
+// sessionIssuer cria a sessão e emite o par access/refresh token de um usuário
+// já autenticado. Compartilhado pelos fluxos de login com e sem segundo fator.
+type sessionIssuer struct {
+	sessionRepo repository.SessionRepository
+	jwtService  *crypto.JWTService
+}
+
+func (i sessionIssuer) issue(ctx context.Context, user *entity.User, ipAddress, userAgent string) (*LoginOutput, error) {
+	output, _, err := i.issueForClient(ctx, user, nil, "", ipAddress, userAgent)
+	return output, err
+}
+
+// issueForClient emite a sessão vinculada a um cliente OAuth e aos escopos concedidos,
+// retornando também a sessão criada. client nil emite uma sessão de login direto na API.
+func (i sessionIssuer) issueForClient(ctx context.Context, user *entity.User, client *entity.OAuthClient, scope, ipAddress, userAgent string) (*LoginOutput, *entity.Session, error) {
+	// Gerar refresh token
+	refreshToken, expiresAt, err := i.jwtService.GenerateRefreshToken(user.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	// Criar sessão
+	session := entity.NewSession(user.ID, refreshToken, expiresAt, ipAddress, userAgent)
+	if client != nil {
+		session.BindToClient(client.ID, scope)
+	}
+
+	// Gerar access token vinculado à sessão
+	email, role := tokenProfile(user, sessionClientID(session), session.Scope)
+	accessToken, err := i.jwtService.GenerateOAuthAccessToken(user.ID, email, role, session.FamilyID, sessionClientID(session), session.Scope)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	if err := i.sessionRepo.Create(ctx, session); err != nil {
+		return nil, nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	output := &LoginOutput{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		User: UserDTO{
+			ID:    user.ID.String(),
+			Email: user.Email,
+			Name:  user.Name,
+			Role:  string(user.Role),
+		},
+	}
+
+	return output, session, nil
+}
+
+// sessionClientID retorna o client_id da sessão para o access token (vazio sem cliente OAuth)
+func sessionClientID(session *entity.Session) string {
+	if session.ClientID == nil {
+		return ""
+	}
+	return session.ClientID.String()
+}
+
+// tokenProfile retorna o email e a role expostos de um token de usuário. Tokens de
+// clientes OAuth só os carregam com os escopos que os liberam no userinfo (email e
+// profile), para que o token não revele mais do que o consentimento concedeu.
+func tokenProfile(user *entity.User, clientID, scope string) (email, role string) {
+	if clientID == "" {
+		return user.Email, string(user.Role)
+	}
+
+	scopes := entity.ParseScope(scope)
+	if entity.HasScope(scopes, entity.ScopeEmail) {
+		email = user.Email
+	}
+	if entity.HasScope(scopes, entity.ScopeProfile) {
+		role = string(user.Role)
+	}
+	return email, role
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type SetupTOTPInput struct {
+	UserID uuid.UUID
+}
+
+type SetupTOTPOutput struct {
+	Secret     string
+	OTPAuthURI string
+}
+
+type SetupTOTPUseCase struct {
+	userRepo  repository.UserRepository
+	secretBox *crypto.SecretBox
+	issuer    string
+}
+
+func NewSetupTOTPUseCase(
+	userRepo repository.UserRepository,
+	secretBox *crypto.SecretBox,
+	issuer string,
+) *SetupTOTPUseCase {
+	return &SetupTOTPUseCase{
+		userRepo:  userRepo,
+		secretBox: secretBox,
+		issuer:    issuer,
+	}
+}
+
+// Execute gera um novo segredo TOTP pendente; só passa a valer após a confirmação
+func (uc *SetupTOTPUseCase) Execute(ctx context.Context, input SetupTOTPInput) (*SetupTOTPOutput, error) {
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabled {
+		return nil, pkgerrors.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := crypto.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := uc.secretBox.Encrypt(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt TOTP secret: %w", err)
+	}
+
+	user.SetTOTPSecret(encrypted)
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to store TOTP secret: %w", err)
+	}
+
+	return &SetupTOTPOutput{
+		Secret:     secret,
+		OTPAuthURI: crypto.TOTPURI(uc.issuer, user.Email, secret),
+	}, nil
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/federation"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+type StartFederatedLoginInput struct {
+	Provider string
+}
+
+type StartFederatedLoginOutput struct {
+	// RedirectTo é a URL de autorização do provedor
+	RedirectTo string
+	// Binding vincula o login ao navegador que o iniciou; deve voltar no callback
+	Binding string
+}
+
+type StartFederatedLoginUseCase struct {
+	registry        *federation.Registry
+	federationStore *cache.FederationStore
+	callbackBaseURL string
+}
+
+func NewStartFederatedLoginUseCase(
+	registry *federation.Registry,
+	federationStore *cache.FederationStore,
+	callbackBaseURL string,
+) *StartFederatedLoginUseCase {
+	return &StartFederatedLoginUseCase{
+		registry:        registry,
+		federationStore: federationStore,
+		callbackBaseURL: callbackBaseURL,
+	}
+}
+
+// Execute inicia o login no provedor externo. O state identifica o login no retorno,
+// o nonce amarra o ID token a este login e o PKCE protege o authorization code.
+func (uc *StartFederatedLoginUseCase) Execute(ctx context.Context, input StartFederatedLoginInput) (*StartFederatedLoginOutput, error) {
+	provider, ok := uc.registry.Get(input.Provider)
+	if !ok {
+		return nil, pkgerrors.ErrFederatedProviderNotFound
+	}
+
+	nonce, err := crypto.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := crypto.GeneratePKCEVerifier()
+	if err != nil {
+		return nil, err
+	}
+	binding, err := crypto.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := uc.federationStore.CreateState(ctx, cache.FederatedLoginState{
+		Provider:     provider.Name(),
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		BindingHash:  entity.HashToken(binding),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	redirectTo, err := provider.AuthCodeURL(
+		ctx,
+		federatedCallbackURL(uc.callbackBaseURL, provider.Name()),
+		state,
+		nonce,
+		crypto.PKCEChallengeS256(verifier),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StartFederatedLoginOutput{
+		RedirectTo: redirectTo,
+		Binding:    binding,
+	}, nil
+}
+
+// federatedCallbackURL é a redirect URI registrada neste serviço junto ao provedor
+func federatedCallbackURL(baseURL, provider string) string {
+	return baseURL + "/" + url.PathEscape(provider) + "/callback"
+}
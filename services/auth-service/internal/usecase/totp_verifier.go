@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+// totpVerifier valida códigos TOTP contra o segredo cifrado do usuário,
+// recusando a reutilização de um código já aceito
+type totpVerifier struct {
+	secretBox *crypto.SecretBox
+	mfaStore  *cache.MFAStore
+}
+
+func (v totpVerifier) verify(ctx context.Context, user *entity.User, code string) error {
+	if user.TOTPSecret == "" {
+		return pkgerrors.ErrMFASetupRequired
+	}
+
+	secret, err := v.secretBox.Decrypt(user.TOTPSecret)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt TOTP secret: %w", err)
+	}
+
+	counter, ok := crypto.ValidateTOTP(secret, code, time.Now())
+	if !ok {
+		return pkgerrors.ErrInvalidMFACode
+	}
+
+	fresh, err := v.mfaStore.MarkTOTPUsed(ctx, user.ID, counter)
+	if err != nil {
+		return fmt.Errorf("failed to record TOTP usage: %w", err)
+	}
+	if !fresh {
+		return pkgerrors.ErrInvalidMFACode
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
+)
+
+type UnlockUserInput struct {
+	UserID uuid.UUID
+}
+
+type UnlockUserUseCase struct {
+	userRepo      repository.UserRepository
+	loginThrottle *cache.LoginThrottle
+}
+
+func NewUnlockUserUseCase(userRepo repository.UserRepository, loginThrottle *cache.LoginThrottle) *UnlockUserUseCase {
+	return &UnlockUserUseCase{
+		userRepo:      userRepo,
+		loginThrottle: loginThrottle,
+	}
+}
+
+// Execute remove o bloqueio temporário de login da conta e zera suas falhas
+func (uc *UnlockUserUseCase) Execute(ctx context.Context, input UnlockUserInput) error {
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.loginThrottle.Reset(ctx, user.Email); err != nil {
+		return fmt.Errorf("failed to unlock user: %w", err)
+	}
+
+	return nil
+}
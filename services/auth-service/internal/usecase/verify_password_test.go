@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/entity"
+	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
+)
+
+// stubAuthenticator devolve sempre o mesmo resultado, como um backend de diretório
+type stubAuthenticator struct {
+	user *entity.User
+	err  error
+}
+
+func (a stubAuthenticator) Authenticate(ctx context.Context, login, password string) (*entity.User, error) {
+	return a.user, a.err
+}
+
+func TestVerifyCurrentPassword(t *testing.T) {
+	user := entity.NewUser("user@example.com", "unused-local-hash", "User", entity.RoleViewer)
+	other := entity.NewUser("other@example.com", "unused-local-hash", "Other", entity.RoleViewer)
+	directoryDown := errors.New("directory unreachable")
+
+	tests := []struct {
+		name          string
+		authenticator Authenticator
+		want          error
+	}{
+		{name: "backend accepts the password", authenticator: stubAuthenticator{user: user}},
+		{name: "wrong password", authenticator: stubAuthenticator{err: pkgerrors.ErrInvalidCredentials}, want: pkgerrors.ErrIncorrectPassword},
+		{name: "password of another account", authenticator: stubAuthenticator{user: other}, want: pkgerrors.ErrIncorrectPassword},
+		{name: "backend error is returned", authenticator: stubAuthenticator{err: directoryDown}, want: directoryDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyCurrentPassword(context.Background(), tt.authenticator, user, "password")
+			if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -2,10 +2,12 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/domain/repository"
+	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/cache"
 	"github.com/jvieiradev/titanwatch/auth-service/internal/infrastructure/crypto"
 	pkgerrors "github.com/jvieiradev/titanwatch/auth-service/pkg/errors"
 )
@@ -15,24 +17,35 @@ type VerifyTokenInput struct {
 }
 
 type VerifyTokenOutput struct {
-	UserID uuid.UUID
-	Email  string
-	Role   string
-	Valid  bool
+	// SubjectType é crypto.SubjectTypeUser ou crypto.SubjectTypeClient (token de serviço)
+	SubjectType string
+	UserID      uuid.UUID
+	Email       string
+	Role        string
+	// ClientID e Scope vêm de tokens emitidos a clientes OAuth
+	ClientID string
+	Scope    string
+	Valid    bool
 }
 
 type VerifyTokenUseCase struct {
-	userRepo   repository.UserRepository
-	jwtService *crypto.JWTService
+	userRepo       repository.UserRepository
+	clientRepo     repository.OAuthClientRepository
+	jwtService     *crypto.JWTService
+	revocationList *cache.TokenRevocationList
 }
 
 func NewVerifyTokenUseCase(
 	userRepo repository.UserRepository,
+	clientRepo repository.OAuthClientRepository,
 	jwtService *crypto.JWTService,
+	revocationList *cache.TokenRevocationList,
 ) *VerifyTokenUseCase {
 	return &VerifyTokenUseCase{
-		userRepo:   userRepo,
-		jwtService: jwtService,
+		userRepo:       userRepo,
+		clientRepo:     clientRepo,
+		jwtService:     jwtService,
+		revocationList: revocationList,
 	}
 }
 
@@ -43,6 +56,19 @@ func (uc *VerifyTokenUseCase) Execute(ctx context.Context, input VerifyTokenInpu
 		return nil, err
 	}
 
+	// Verificar se o token foi revogado antes de expirar
+	revoked, err := uc.revocationList.IsRevoked(ctx, claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	if revoked {
+		return nil, pkgerrors.ErrTokenRevoked
+	}
+
+	if claims.IsClient() {
+		return uc.verifyClient(ctx, claims)
+	}
+
 	// Buscar usuário para garantir que ainda existe e está ativo
 	user, err := uc.userRepo.GetByID(ctx, claims.UserID)
 	if err != nil {
@@ -54,10 +80,41 @@ func (uc *VerifyTokenUseCase) Execute(ctx context.Context, input VerifyTokenInpu
 		return nil, pkgerrors.ErrUserInactive
 	}
 
+	email, role := tokenProfile(user, claims.ClientID, claims.Scope)
+	return &VerifyTokenOutput{
+		SubjectType: crypto.SubjectTypeUser,
+		UserID:      user.ID,
+		Email:       email,
+		Role:        role,
+		ClientID:    claims.ClientID,
+		Scope:       claims.Scope,
+		Valid:       true,
+	}, nil
+}
+
+// verifyClient garante que o cliente de um token de serviço ainda existe e está ativo
+func (uc *VerifyTokenUseCase) verifyClient(ctx context.Context, claims *crypto.Claims) (*VerifyTokenOutput, error) {
+	clientID, err := uuid.Parse(claims.ClientID)
+	if err != nil {
+		return nil, pkgerrors.ErrInvalidToken
+	}
+
+	client, err := uc.clientRepo.GetByID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, pkgerrors.ErrOAuthClientNotFound) {
+			return nil, fmt.Errorf("client not found: %w", pkgerrors.ErrInvalidToken)
+		}
+		return nil, err
+	}
+
+	if !client.IsActive {
+		return nil, pkgerrors.ErrInvalidToken
+	}
+
 	return &VerifyTokenOutput{
-		UserID: user.ID,
-		Email:  user.Email,
-		Role:   string(user.Role),
-		Valid:  true,
+		SubjectType: crypto.SubjectTypeClient,
+		ClientID:    client.ID.String(),
+		Scope:       claims.Scope,
+		Valid:       true,
 	}, nil
 }
@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_sessions_family_id;
+
+-- Drop family columns
+ALTER TABLE sessions DROP COLUMN IF EXISTS replaced_by;
+ALTER TABLE sessions DROP COLUMN IF EXISTS family_id;
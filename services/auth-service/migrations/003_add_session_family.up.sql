@@ -0,0 +1,10 @@
+-- Add refresh token family (lineage) to sessions
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS family_id UUID;
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS replaced_by UUID;
+
+-- Existing sessions start their own family
+UPDATE sessions SET family_id = id WHERE family_id IS NULL;
+ALTER TABLE sessions ALTER COLUMN family_id SET NOT NULL;
+
+-- Create index on family_id for family revocation
+CREATE INDEX IF NOT EXISTS idx_sessions_family_id ON sessions(family_id);
@@ -0,0 +1,7 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_audit_events_created_at;
+DROP INDEX IF EXISTS idx_audit_events_event_type;
+DROP INDEX IF EXISTS idx_audit_events_user_id;
+
+-- Drop audit_events table
+DROP TABLE IF EXISTS audit_events;
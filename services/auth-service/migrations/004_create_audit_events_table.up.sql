@@ -0,0 +1,17 @@
+-- Create audit_events table
+CREATE TABLE IF NOT EXISTS audit_events (
+    id UUID PRIMARY KEY,
+    event_type VARCHAR(100) NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    metadata JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index on user_id for per-user history
+CREATE INDEX IF NOT EXISTS idx_audit_events_user_id ON audit_events(user_id);
+
+-- Create index on event_type
+CREATE INDEX IF NOT EXISTS idx_audit_events_event_type ON audit_events(event_type);
+
+-- Create index on created_at for time range queries
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events(created_at);
@@ -0,0 +1,13 @@
+-- Plaintext tokens cannot be recovered from their digest: existing sessions
+-- stop working after this rollback and users must log in again
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'sessions' AND column_name = 'refresh_token_hash'
+    ) THEN
+        ALTER INDEX IF EXISTS idx_sessions_refresh_token_hash RENAME TO idx_sessions_refresh_token;
+        ALTER TABLE sessions ALTER COLUMN refresh_token_hash TYPE VARCHAR(512);
+        ALTER TABLE sessions RENAME COLUMN refresh_token_hash TO refresh_token;
+    END IF;
+END $$;
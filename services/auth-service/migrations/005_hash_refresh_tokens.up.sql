@@ -0,0 +1,17 @@
+-- Store only the SHA-256 digest of refresh tokens
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'sessions' AND column_name = 'refresh_token'
+    ) THEN
+        ALTER TABLE sessions RENAME COLUMN refresh_token TO refresh_token_hash;
+
+        -- Convert existing plaintext tokens to their digest
+        UPDATE sessions
+        SET refresh_token_hash = encode(sha256(convert_to(refresh_token_hash, 'UTF8')), 'hex');
+
+        ALTER TABLE sessions ALTER COLUMN refresh_token_hash TYPE CHAR(64);
+        ALTER INDEX IF EXISTS idx_sessions_refresh_token RENAME TO idx_sessions_refresh_token_hash;
+    END IF;
+END $$;
@@ -0,0 +1,9 @@
+-- Add client metadata captured at login and refresh time
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS ip_address VARCHAR(45) NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS user_agent VARCHAR(512) NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMP;
+
+-- Existing sessions were last used when created
+UPDATE sessions SET last_used_at = created_at WHERE last_used_at IS NULL;
+ALTER TABLE sessions ALTER COLUMN last_used_at SET DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE sessions ALTER COLUMN last_used_at SET NOT NULL;
@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_invitations_created_at;
+DROP INDEX IF EXISTS idx_invitations_email;
+
+-- Drop invitations table
+DROP TABLE IF EXISTS invitations;
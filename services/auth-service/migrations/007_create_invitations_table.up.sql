@@ -0,0 +1,19 @@
+-- Create invitations table
+CREATE TABLE IF NOT EXISTS invitations (
+    id UUID PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(50) NOT NULL CHECK (role IN ('admin', 'operator', 'analyst', 'viewer')),
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    accepted_at TIMESTAMP,
+    accepted_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    revoked_at TIMESTAMP
+);
+
+-- Create index on email
+CREATE INDEX IF NOT EXISTS idx_invitations_email ON invitations(email);
+
+-- Create index on created_at for listing
+CREATE INDEX IF NOT EXISTS idx_invitations_created_at ON invitations(created_at);
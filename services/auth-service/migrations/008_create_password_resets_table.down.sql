@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_password_resets_expires_at;
+DROP INDEX IF EXISTS idx_password_resets_user_id;
+
+-- Drop password_resets table
+DROP TABLE IF EXISTS password_resets;
@@ -0,0 +1,9 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_mfa_recovery_codes_user_id_code_hash;
+
+-- Drop mfa_recovery_codes table
+DROP TABLE IF EXISTS mfa_recovery_codes;
+
+-- Remove TOTP columns
+ALTER TABLE users DROP COLUMN IF EXISTS totp_enabled;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_secret;
@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_webauthn_credentials_user_id;
+DROP INDEX IF EXISTS idx_webauthn_credentials_credential_id;
+
+-- Drop webauthn_credentials table
+DROP TABLE IF EXISTS webauthn_credentials;
@@ -0,0 +1,5 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_oauth_consents_client_id;
+
+-- Drop oauth_consents table
+DROP TABLE IF EXISTS oauth_consents;
@@ -0,0 +1,6 @@
+-- Bind sessions issued through OAuth to the client and granted scopes
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS client_id UUID REFERENCES oauth_clients(id) ON DELETE CASCADE;
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS scope TEXT NOT NULL DEFAULT '';
+
+-- Create index
+CREATE INDEX IF NOT EXISTS idx_sessions_client_id ON sessions(client_id);
@@ -0,0 +1,3 @@
+-- Drop client credentials columns
+ALTER TABLE oauth_clients DROP COLUMN IF EXISTS access_token_ttl_seconds;
+ALTER TABLE oauth_clients DROP COLUMN IF EXISTS grant_types;
@@ -0,0 +1,3 @@
+-- Allowed grant types and token lifetime for machine clients (client_credentials)
+ALTER TABLE oauth_clients ADD COLUMN IF NOT EXISTS grant_types TEXT[] NOT NULL DEFAULT '{authorization_code,refresh_token}';
+ALTER TABLE oauth_clients ADD COLUMN IF NOT EXISTS access_token_ttl_seconds INTEGER NOT NULL DEFAULT 0;
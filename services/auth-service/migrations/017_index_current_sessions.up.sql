@@ -0,0 +1,2 @@
+-- Listing a user's sessions only reads the current (not replaced) row of each family
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id_current ON sessions(user_id) WHERE replaced_by IS NULL;
@@ -0,0 +1,2 @@
+-- Drop post-logout redirect URIs
+ALTER TABLE oauth_clients DROP COLUMN IF EXISTS post_logout_redirect_uris;
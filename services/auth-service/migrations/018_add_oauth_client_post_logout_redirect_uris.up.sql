@@ -0,0 +1,2 @@
+-- Redirect URIs accepted by the end_session_endpoint, registered apart from the login redirect URIs
+ALTER TABLE oauth_clients ADD COLUMN IF NOT EXISTS post_logout_redirect_uris TEXT[] NOT NULL DEFAULT '{}';
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -11,16 +12,29 @@ import (
 
 // Config armazena todas as configurações da aplicação
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	JWT      JWTConfig
-	Env      string
+	Server     ServerConfig
+	Database   DatabaseConfig
+	Redis      RedisConfig
+	JWT        JWTConfig
+	Auth       AuthConfig
+	Password   PasswordConfig
+	Mail       MailConfig
+	MFA        MFAConfig
+	WebAuthn   WebAuthnConfig
+	RateLimit  RateLimitConfig
+	OAuth      OAuthConfig
+	OIDC       OIDCConfig
+	Federation FederationConfig
+	LDAP       LDAPConfig
+	Env        string
 }
 
 type ServerConfig struct {
 	Host string
 	Port string
+	// TrustedProxies são os CIDRs ou IPs dos proxies reversos cujos headers
+	// X-Forwarded-For/X-Real-IP definem o IP do cliente
+	TrustedProxies []string
 }
 
 type DatabaseConfig struct {
@@ -40,9 +54,165 @@ type RedisConfig struct {
 }
 
 type JWTConfig struct {
-	Secret               string
-	AccessTokenExpiry    time.Duration
-	RefreshTokenExpiry   time.Duration
+	Secret                string
+	SigningAlgorithm      string
+	PrivateKeyPath        string
+	KeyID                 string
+	KeysDir               string
+	KeyRingReloadInterval time.Duration
+	AccessTokenExpiry     time.Duration
+	RefreshTokenExpiry    time.Duration
+}
+
+type AuthConfig struct {
+	// Backend valida a senha do login: "local" (hash no banco) ou "ldap" (bind no diretório)
+	Backend string
+	// SelfRegistration habilita POST /auth/register (sempre com role viewer)
+	SelfRegistration bool
+	// ConcealRegistration faz o auto-cadastro responder sempre 202, avisando o dono do email por mensagem
+	ConcealRegistration bool
+	// InvitationExpiry é a validade padrão dos convites emitidos por admins
+	InvitationExpiry time.Duration
+	// PasswordResetExpiry é a validade dos tokens de redefinição de senha
+	PasswordResetExpiry time.Duration
+	// PasswordResetURL é a página do frontend que recebe o token de redefinição
+	PasswordResetURL string
+	// PasswordHistorySize é quantas senhas recentes (incluindo a atual) não podem ser reutilizadas
+	PasswordHistorySize int
+	// LoginMaxAttempts é o número de falhas por conta até o bloqueio temporário (0 desativa)
+	LoginMaxAttempts int
+	// LoginIPMaxAttempts é o número de falhas por IP até o bloqueio temporário (0 desativa)
+	LoginIPMaxAttempts int
+	// LoginLockout é a duração do primeiro bloqueio; dobra a cada nova falha até LoginMaxLockout
+	LoginLockout    time.Duration
+	LoginMaxLockout time.Duration
+	// LoginFailureWindow é por quanto tempo uma falha de login continua contando
+	LoginFailureWindow time.Duration
+	// SessionCleanupInterval é o intervalo da limpeza de sessões expiradas e revogadas e
+	// de tokens de redefinição de senha expirados (0 desativa)
+	SessionCleanupInterval time.Duration
+	// RevokedSessionRetention é por quanto tempo sessões revogadas ou rotacionadas são
+	// mantidas, o que define a janela de detecção de reutilização de refresh tokens
+	RevokedSessionRetention time.Duration
+}
+
+type PasswordConfig struct {
+	// Algorithm dos novos hashes: "argon2id" ou "bcrypt"; hashes antigos são migrados no login
+	Algorithm string
+	// Argon2Memory em KiB
+	Argon2Memory      int
+	Argon2Time        int
+	Argon2Parallelism int
+	BcryptCost        int
+	// MinLength é o tamanho mínimo das novas senhas, em caracteres
+	MinLength int
+	// MinStrength é a força estimada mínima, de 0 a 4 (0 desativa)
+	MinStrength int
+	// BreachedCorpusPath aponta para a base offline de senhas vazadas (SHA-1, formato HIBP)
+	BreachedCorpusPath string
+}
+
+type MFAConfig struct {
+	// Issuer aparece no aplicativo autenticador
+	Issuer string
+	// EncryptionKey (base64, 32 bytes) cifra os segredos TOTP no banco
+	EncryptionKey   string
+	ChallengeExpiry time.Duration
+}
+
+type RateLimitConfig struct {
+	// Enabled ativa os limites por rota da aplicação (além dos do nginx)
+	Enabled bool
+}
+
+type OAuthConfig struct {
+	// LoginURL é a página do frontend que conduz o login e o consentimento (recebe request_id)
+	LoginURL string
+	// RequestExpiry é por quanto tempo um pedido de autorização aguarda a decisão do usuário
+	RequestExpiry time.Duration
+	// CodeExpiry é a validade dos authorization codes
+	CodeExpiry time.Duration
+}
+
+type OIDCConfig struct {
+	// Issuer é a URL pública do serviço (claim iss); os endpoints da discovery derivam dela
+	Issuer        string
+	IDTokenExpiry time.Duration
+}
+
+type FederationConfig struct {
+	// CallbackBaseURL é a URL pública de /auth/federated; a redirect URI de cada
+	// provedor é <CallbackBaseURL>/<provedor>/callback
+	CallbackBaseURL string
+	// LoginURL é a página do frontend que recebe o code (ou o error) do login federado
+	LoginURL string
+	// StateExpiry é por quanto tempo o serviço aguarda o retorno do provedor
+	StateExpiry time.Duration
+	// CodeExpiry é a validade do code trocado pelos tokens em /auth/federated/exchange
+	CodeExpiry time.Duration
+	Providers  []FederatedProviderConfig
+}
+
+// FederatedProviderConfig descreve um provedor de identidade OpenID Connect externo,
+// configurado por FEDERATION_<NOME>_*
+type FederatedProviderConfig struct {
+	Name         string
+	DisplayName  string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// DefaultRole é a role dos usuários criados no primeiro login pelo provedor
+	DefaultRole string
+	// TrustEmail vincula a uma conta existente pelo email, quando verificado pelo provedor
+	TrustEmail bool
+}
+
+// LDAPConfig descreve o diretório usado quando AUTH_BACKEND=ldap. Nos filtros,
+// {login} é o email ou usuário digitado e {dn} o DN do usuário.
+type LDAPConfig struct {
+	URL                string
+	StartTLS           bool
+	InsecureSkipVerify bool
+	BindDN             string
+	BindPassword       string
+	BaseDN             string
+	UserFilter         string
+	// IDAttribute é o identificador estável da entrada: entryUUID (OpenLDAP) ou objectGUID (AD)
+	IDAttribute    string
+	EmailAttribute string
+	NameAttribute  string
+	GroupAttribute string
+	GroupBaseDN    string
+	GroupFilter    string
+	// RoleGroups são os DNs dos grupos que concedem cada role; vale a de maior privilégio
+	RoleGroups map[string][]string
+	// DefaultRole é a role de quem não está em nenhum grupo mapeado ("none" nega o acesso)
+	DefaultRole string
+	// LocalFallback autentica no banco as contas que não existem no diretório
+	LocalFallback bool
+	Timeout       time.Duration
+}
+
+type WebAuthnConfig struct {
+	// RPID é o domínio da Relying Party; as passkeys ficam vinculadas a ele
+	RPID          string
+	RPDisplayName string
+	// RPOrigins são as origens do frontend autorizadas a executar as cerimônias
+	RPOrigins       []string
+	ChallengeExpiry time.Duration
+}
+
+type MailConfig struct {
+	// Driver seleciona a entrega: "smtp" ou "log" (desenvolvimento)
+	Driver       string
+	From         string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	// OutboxDir grava os emails em arquivos quando Driver é "log"
+	OutboxDir string
 }
 
 // Load carrega as configurações do ambiente
@@ -52,8 +222,9 @@ func Load() (*Config, error) {
 
 	cfg := &Config{
 		Server: ServerConfig{
-			Host: getEnv("SERVER_HOST", "0.0.0.0"),
-			Port: getEnv("SERVER_PORT", "8001"),
+			Host:           getEnv("SERVER_HOST", "0.0.0.0"),
+			Port:           getEnv("SERVER_PORT", "8001"),
+			TrustedProxies: getEnvAsSlice("SERVER_TRUSTED_PROXIES", nil),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -70,9 +241,103 @@ func Load() (*Config, error) {
 			DB:       getEnvAsInt("REDIS_DB", 0),
 		},
 		JWT: JWTConfig{
-			Secret:               getEnv("JWT_SECRET", "change-this-secret-key"),
-			AccessTokenExpiry:    getEnvAsDuration("JWT_ACCESS_TOKEN_EXPIRY", 15*time.Minute),
-			RefreshTokenExpiry:   getEnvAsDuration("JWT_REFRESH_TOKEN_EXPIRY", 7*24*time.Hour),
+			Secret:                getEnv("JWT_SECRET", "change-this-secret-key"),
+			SigningAlgorithm:      getEnv("JWT_SIGNING_ALGORITHM", ""),
+			PrivateKeyPath:        getEnv("JWT_PRIVATE_KEY_PATH", ""),
+			KeyID:                 getEnv("JWT_KEY_ID", ""),
+			KeysDir:               getEnv("JWT_KEYS_DIR", ""),
+			KeyRingReloadInterval: getEnvAsDuration("JWT_KEYRING_RELOAD_INTERVAL", time.Minute),
+			AccessTokenExpiry:     getEnvAsDuration("JWT_ACCESS_TOKEN_EXPIRY", 15*time.Minute),
+			RefreshTokenExpiry:    getEnvAsDuration("JWT_REFRESH_TOKEN_EXPIRY", 7*24*time.Hour),
+		},
+		Auth: AuthConfig{
+			Backend:                 getEnv("AUTH_BACKEND", "local"),
+			SelfRegistration:        getEnvAsBool("AUTH_SELF_REGISTRATION", true),
+			ConcealRegistration:     getEnvAsBool("AUTH_CONCEAL_REGISTRATION", false),
+			InvitationExpiry:        getEnvAsDuration("AUTH_INVITATION_EXPIRY", 72*time.Hour),
+			PasswordResetExpiry:     getEnvAsDuration("AUTH_PASSWORD_RESET_EXPIRY", 30*time.Minute),
+			PasswordResetURL:        getEnv("AUTH_PASSWORD_RESET_URL", "http://localhost:3000/reset-password"),
+			PasswordHistorySize:     getEnvAsInt("AUTH_PASSWORD_HISTORY_SIZE", 5),
+			LoginMaxAttempts:        getEnvAsInt("AUTH_LOGIN_MAX_ATTEMPTS", 5),
+			LoginIPMaxAttempts:      getEnvAsInt("AUTH_LOGIN_IP_MAX_ATTEMPTS", 20),
+			LoginLockout:            getEnvAsDuration("AUTH_LOGIN_LOCKOUT", time.Minute),
+			LoginMaxLockout:         getEnvAsDuration("AUTH_LOGIN_MAX_LOCKOUT", time.Hour),
+			LoginFailureWindow:      getEnvAsDuration("AUTH_LOGIN_FAILURE_WINDOW", 15*time.Minute),
+			SessionCleanupInterval:  getEnvAsDuration("AUTH_SESSION_CLEANUP_INTERVAL", time.Hour),
+			RevokedSessionRetention: getEnvAsDuration("AUTH_REVOKED_SESSION_RETENTION", 7*24*time.Hour),
+		},
+		Password: PasswordConfig{
+			Algorithm:          getEnv("PASSWORD_HASH_ALGORITHM", "argon2id"),
+			Argon2Memory:       getEnvAsInt("PASSWORD_ARGON2_MEMORY", 64*1024),
+			Argon2Time:         getEnvAsInt("PASSWORD_ARGON2_TIME", 3),
+			Argon2Parallelism:  getEnvAsInt("PASSWORD_ARGON2_PARALLELISM", 2),
+			BcryptCost:         getEnvAsInt("PASSWORD_BCRYPT_COST", 10),
+			MinLength:          getEnvAsInt("PASSWORD_MIN_LENGTH", 8),
+			MinStrength:        getEnvAsInt("PASSWORD_MIN_STRENGTH", 3),
+			BreachedCorpusPath: getEnv("PASSWORD_BREACHED_CORPUS_PATH", ""),
+		},
+		Mail: MailConfig{
+			Driver:       getEnv("MAIL_DRIVER", "log"),
+			From:         getEnv("MAIL_FROM", "Titan Watch <no-reply@titanwatch.local>"),
+			SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+			SMTPPort:     getEnv("SMTP_PORT", "587"),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			OutboxDir:    getEnv("MAIL_OUTBOX_DIR", ""),
+		},
+		MFA: MFAConfig{
+			Issuer:          getEnv("MFA_ISSUER", "Titan Watch"),
+			EncryptionKey:   getEnv("MFA_ENCRYPTION_KEY", ""),
+			ChallengeExpiry: getEnvAsDuration("MFA_CHALLENGE_EXPIRY", 5*time.Minute),
+		},
+		WebAuthn: WebAuthnConfig{
+			RPID:            getEnv("WEBAUTHN_RP_ID", "localhost"),
+			RPDisplayName:   getEnv("WEBAUTHN_RP_NAME", "Titan Watch"),
+			RPOrigins:       getEnvAsSlice("WEBAUTHN_RP_ORIGINS", []string{"http://localhost:3000"}),
+			ChallengeExpiry: getEnvAsDuration("WEBAUTHN_CHALLENGE_EXPIRY", 5*time.Minute),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: getEnvAsBool("RATE_LIMIT_ENABLED", true),
+		},
+		OAuth: OAuthConfig{
+			LoginURL:      getEnv("OAUTH_LOGIN_URL", "http://localhost:3000/oauth/authorize"),
+			RequestExpiry: getEnvAsDuration("OAUTH_REQUEST_EXPIRY", 10*time.Minute),
+			CodeExpiry:    getEnvAsDuration("OAUTH_CODE_EXPIRY", time.Minute),
+		},
+		OIDC: OIDCConfig{
+			Issuer:        strings.TrimSuffix(getEnv("OIDC_ISSUER", "http://localhost:8001"), "/"),
+			IDTokenExpiry: getEnvAsDuration("OIDC_ID_TOKEN_EXPIRY", time.Hour),
+		},
+		Federation: FederationConfig{
+			CallbackBaseURL: strings.TrimSuffix(getEnv("FEDERATION_CALLBACK_BASE_URL", "http://localhost:8001/api/v1/auth/federated"), "/"),
+			LoginURL:        getEnv("FEDERATION_LOGIN_URL", "http://localhost:3000/login/federated"),
+			StateExpiry:     getEnvAsDuration("FEDERATION_STATE_EXPIRY", 10*time.Minute),
+			CodeExpiry:      getEnvAsDuration("FEDERATION_CODE_EXPIRY", time.Minute),
+			Providers:       loadFederatedProviders(getEnv("FEDERATION_DEFAULT_ROLE", "viewer")),
+		},
+		LDAP: LDAPConfig{
+			URL:                getEnv("LDAP_URL", "ldap://localhost:389"),
+			StartTLS:           getEnvAsBool("LDAP_START_TLS", false),
+			InsecureSkipVerify: getEnvAsBool("LDAP_INSECURE_SKIP_VERIFY", false),
+			BindDN:             getEnv("LDAP_BIND_DN", ""),
+			BindPassword:       getEnv("LDAP_BIND_PASSWORD", ""),
+			BaseDN:             getEnv("LDAP_BASE_DN", "ou=people,dc=titanwatch,dc=local"),
+			UserFilter:         getEnv("LDAP_USER_FILTER", "(&(objectClass=inetOrgPerson)(|(uid={login})(mail={login})))"),
+			IDAttribute:        getEnv("LDAP_ID_ATTRIBUTE", "entryUUID"),
+			EmailAttribute:     getEnv("LDAP_EMAIL_ATTRIBUTE", "mail"),
+			NameAttribute:      getEnv("LDAP_NAME_ATTRIBUTE", "cn"),
+			GroupAttribute:     getEnv("LDAP_GROUP_ATTRIBUTE", "memberOf"),
+			GroupBaseDN:        getEnv("LDAP_GROUP_BASE_DN", ""),
+			GroupFilter:        getEnv("LDAP_GROUP_FILTER", "(&(objectClass=groupOfNames)(member={dn}))"),
+			RoleGroups: map[string][]string{
+				"admin":    getEnvAsDNs("LDAP_ROLE_ADMIN_GROUPS"),
+				"operator": getEnvAsDNs("LDAP_ROLE_OPERATOR_GROUPS"),
+				"analyst":  getEnvAsDNs("LDAP_ROLE_ANALYST_GROUPS"),
+				"viewer":   getEnvAsDNs("LDAP_ROLE_VIEWER_GROUPS"),
+			},
+			DefaultRole:   getEnv("LDAP_DEFAULT_ROLE", "viewer"),
+			LocalFallback: getEnvAsBool("LDAP_LOCAL_FALLBACK", true),
+			Timeout:       getEnvAsDuration("LDAP_TIMEOUT", 5*time.Second),
 		},
 		Env: getEnv("ENVIRONMENT", "development"),
 	}
@@ -80,6 +345,27 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// loadFederatedProviders lê os provedores listados em FEDERATION_PROVIDERS
+func loadFederatedProviders(defaultRole string) []FederatedProviderConfig {
+	var providers []FederatedProviderConfig
+	for _, name := range getEnvAsSlice("FEDERATION_PROVIDERS", nil) {
+		name = strings.ToLower(name)
+		prefix := "FEDERATION_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+
+		providers = append(providers, FederatedProviderConfig{
+			Name:         name,
+			DisplayName:  getEnv(prefix+"DISPLAY_NAME", name),
+			Issuer:       strings.TrimSuffix(getEnv(prefix+"ISSUER", ""), "/"),
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			Scopes:       strings.Fields(getEnv(prefix+"SCOPES", "openid email profile")),
+			DefaultRole:  getEnv(prefix+"DEFAULT_ROLE", defaultRole),
+			TrustEmail:   getEnvAsBool(prefix+"TRUST_EMAIL", false),
+		})
+	}
+	return providers
+}
+
 // GetDSN retorna a connection string do PostgreSQL
 func (c *Config) GetDSN() string {
 	return fmt.Sprintf(
@@ -120,3 +406,35 @@ func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvAsSlice(key string, defaultValue []string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return defaultValue
+	}
+	return values
+}
+
+// getEnvAsDNs lê uma lista de DNs separados por ";", já que os DNs contêm vírgulas
+func getEnvAsDNs(key string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, ""), ";") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
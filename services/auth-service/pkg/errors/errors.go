@@ -1,6 +1,9 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 // Domain errors
 var (
@@ -8,12 +11,58 @@ var (
 	ErrUserNotFound      = errors.New("usuário não encontrado")
 	ErrUserAlreadyExists = errors.New("usuário já existe")
 	ErrUserInactive      = errors.New("usuário inativo")
+	ErrInvalidRole       = errors.New("role inválida")
+	ErrSelfModification  = errors.New("operação não permitida sobre a própria conta")
+	ErrRoleNotAllowed    = errors.New("role não permitida no auto-cadastro")
+
+	// Registration errors
+	ErrRegistrationDisabled = errors.New("auto-cadastro desabilitado")
+
+	// Invitation errors
+	ErrInvitationNotFound   = errors.New("convite não encontrado")
+	ErrInvitationNotPending = errors.New("convite já utilizado, revogado ou expirado")
+
+	// Password errors
+	ErrResetTokenInvalid          = errors.New("token de redefinição inválido ou expirado")
+	ErrIncorrectPassword          = errors.New("senha atual incorreta")
+	ErrPasswordReused             = errors.New("a nova senha não pode repetir senhas usadas recentemente")
+	ErrPasswordTooLong            = errors.New("senha excede o tamanho máximo suportado")
+	ErrPasswordManagedByDirectory = errors.New("a senha desta conta é gerenciada no diretório LDAP")
+
+	// MFA errors
+	ErrMFAAlreadyEnabled   = errors.New("autenticação em dois fatores já está ativa")
+	ErrMFANotEnabled       = errors.New("autenticação em dois fatores não está ativa")
+	ErrMFASetupRequired    = errors.New("configure o TOTP antes de confirmar")
+	ErrInvalidMFACode      = errors.New("código de verificação inválido")
+	ErrMFAChallengeInvalid = errors.New("desafio MFA inválido ou expirado")
+
+	// Passkey (WebAuthn) errors
+	ErrPasskeyNotFound          = errors.New("passkey não encontrada")
+	ErrPasskeyAlreadyRegistered = errors.New("passkey já registrada")
+	ErrPasskeyChallengeInvalid  = errors.New("desafio WebAuthn inválido ou expirado")
+	ErrPasskeyVerification      = errors.New("falha na verificação da passkey")
+	ErrPasskeyCloned            = errors.New("contador de assinaturas inválido - possível autenticador clonado")
+
+	// OAuth errors
+	ErrOAuthClientNotFound         = errors.New("cliente OAuth não encontrado")
+	ErrOAuthConsentNotFound        = errors.New("consentimento não encontrado")
+	ErrAuthorizationRequestInvalid = errors.New("pedido de autorização inválido ou expirado")
+
+	// Federated login errors
+	ErrFederatedProviderNotFound = errors.New("provedor de identidade não encontrado")
+	ErrFederatedLoginInvalid     = errors.New("login federado inválido ou expirado")
+	ErrIdentityProviderDown      = errors.New("provedor de identidade indisponível")
+	ErrUserIdentityNotFound      = errors.New("identidade externa não encontrada")
+	ErrDirectoryAccessDenied     = errors.New("usuário sem grupo autorizado no diretório")
+	ErrDirectoryAccountConflict  = errors.New("email do diretório já pertence a uma conta local não vinculada")
 
 	// Auth errors
 	ErrInvalidCredentials = errors.New("credenciais inválidas")
 	ErrInvalidToken       = errors.New("token inválido")
 	ErrExpiredToken       = errors.New("token expirado")
 	ErrTokenRevoked       = errors.New("token revogado")
+	ErrTokenReused        = errors.New("reutilização de refresh token detectada - sessão encerrada")
+	ErrTooManyAttempts    = errors.New("muitas tentativas - tente novamente mais tarde")
 
 	// Session errors
 	ErrSessionNotFound = errors.New("sessão não encontrada")
@@ -24,3 +73,49 @@ var (
 	ErrInternalServer = errors.New("erro interno do servidor")
 	ErrBadRequest     = errors.New("requisição inválida")
 )
+
+// RateLimitError indica que o cliente deve aguardar RetryAfter antes de tentar novamente.
+// Compatível com errors.Is(err, ErrTooManyAttempts).
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return ErrTooManyAttempts.Error()
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrTooManyAttempts
+}
+
+// Códigos de erro do OAuth 2.0 (RFC 6749, seções 4.1.2.1 e 5.2; RFC 6750, seção 3.1)
+const (
+	OAuthInvalidRequest          = "invalid_request"
+	OAuthInvalidClient           = "invalid_client"
+	OAuthInvalidGrant            = "invalid_grant"
+	OAuthUnauthorizedClient      = "unauthorized_client"
+	OAuthUnsupportedGrantType    = "unsupported_grant_type"
+	OAuthUnsupportedResponseType = "unsupported_response_type"
+	OAuthInvalidScope            = "invalid_scope"
+	OAuthAccessDenied            = "access_denied"
+	OAuthInvalidToken            = "invalid_token"
+	OAuthInsufficientScope       = "insufficient_scope"
+)
+
+// OAuthError é um erro do protocolo OAuth 2.0, devolvido ao cliente com o código padronizado
+type OAuthError struct {
+	Code        string
+	Description string
+}
+
+// NewOAuthError cria um erro OAuth com o código e a descrição informados
+func NewOAuthError(code, description string) *OAuthError {
+	return &OAuthError{Code: code, Description: description}
+}
+
+func (e *OAuthError) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}